@@ -202,14 +202,17 @@ func (avd AccountVerificationDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, s
 		)
 	}
 
-	evmDenom := avd.evmKeeper.GetParams(ctx).EvmDenom
+	evmParams := avd.evmKeeper.GetParams(ctx)
+	evmDenom := evmParams.EvmDenom
 
 	// validate sender has enough funds to pay for gas cost
+	// NOTE: the tx cost has 18 decimals, so the balance is scaled to the EVM decimals
 	balance := acc.GetCoins().AmountOf(evmDenom)
-	if balance.BigInt().Cmp(msgEthTx.Cost()) < 0 {
+	if evmParams.ToEVMAmount(balance).Cmp(msgEthTx.Cost()) < 0 {
 		return ctx, sdkerrors.Wrapf(
 			sdkerrors.ErrInsufficientFunds,
-			"sender balance < tx gas cost (%s%s < %s%s)", balance.String(), evmDenom, msgEthTx.Cost().String(), evmDenom,
+			"sender balance < tx gas cost (%s%s < %s%s)",
+			balance.String(), evmDenom, evmParams.FromEVMAmountCeil(msgEthTx.Cost()).String(), evmDenom,
 		)
 	}
 
@@ -315,7 +318,7 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 	}
 
 	gasLimit := msgEthTx.GetGas()
-	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, msgEthTx.To() == nil, true, false)
+	gas, err := ethcore.IntrinsicGas(msgEthTx.Data.Payload, nil, msgEthTx.To() == nil, true, false)
 	if err != nil {
 		return ctx, sdkerrors.Wrap(err, "failed to compute intrinsic gas cost")
 	}
@@ -330,10 +333,11 @@ func (egcd EthGasConsumeDecorator) AnteHandle(ctx sdk.Context, tx sdk.Tx, simula
 		// Cost calculates the fees paid to validators based on gas limit and price
 		cost := new(big.Int).Mul(msgEthTx.Data.Price.BigInt(), new(big.Int).SetUint64(gasLimit))
 
-		evmDenom := egcd.evmKeeper.GetParams(ctx).EvmDenom
+		evmParams := egcd.evmKeeper.GetParams(ctx)
 
+		// the sub-unit remainder of the cost is rounded up on the EVM denomination
 		feeAmt := sdk.NewCoins(
-			sdk.NewCoin(evmDenom, sdk.NewIntFromBigInt(cost)),
+			sdk.NewCoin(evmParams.EvmDenom, evmParams.FromEVMAmountCeil(cost)),
 		)
 
 		err = auth.DeductFees(egcd.sk, ctx, senderAcc, feeAmt)
@@ -29,7 +29,11 @@ import (
 	"github.com/cosmos/ethermint/app/ante"
 	ethermintcodec "github.com/cosmos/ethermint/codec"
 	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/erc20"
+	erc20client "github.com/cosmos/ethermint/x/erc20/client"
 	"github.com/cosmos/ethermint/x/evm"
+	evmclient "github.com/cosmos/ethermint/x/evm/client"
+	"github.com/cosmos/ethermint/x/evm/precompiles"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 	"github.com/tendermint/tendermint/libs/log"
@@ -44,7 +48,19 @@ func init() {
 	ethermint.SetBip44CoinType(config)
 }
 
-const appName = "Ethermint"
+const (
+	appName = "Ethermint"
+
+	// UpgradeNameEVMStorageCleanup is the name of the software upgrade that deletes
+	// the EVM storage orphaned by the contracts destructed before SELFDESTRUCT
+	// deleted their storage.
+	UpgradeNameEVMStorageCleanup = "evm-storage-cleanup"
+
+	// UpgradeNameEVMLogsIndex is the name of the software upgrade that indexes by
+	// height the EVM logs stored before the height index, so that they can be
+	// pruned.
+	UpgradeNameEVMLogsIndex = "evm-logs-index"
+)
 
 var (
 	// DefaultCLIHome sets the default home directories for the application CLI
@@ -66,6 +82,10 @@ var (
 		distr.AppModuleBasic{},
 		gov.NewAppModuleBasic(
 			paramsclient.ProposalHandler, distr.ProposalHandler, upgradeclient.ProposalHandler,
+			erc20client.RegisterCoinProposalHandler, erc20client.RegisterERC20ProposalHandler,
+			erc20client.ToggleTokenConversionProposalHandler, evmclient.ChainConfigUpgradeProposalHandler,
+			evmclient.RegisterSystemContractProposalHandler, evmclient.RemoveSystemContractProposalHandler,
+			evmclient.DeployContractProposalHandler, evmclient.PatchContractProposalHandler,
 		),
 		params.AppModuleBasic{},
 		crisis.AppModuleBasic{},
@@ -73,6 +93,7 @@ var (
 		evidence.AppModuleBasic{},
 		upgrade.AppModuleBasic{},
 		evm.AppModuleBasic{},
+		erc20.AppModuleBasic{},
 	)
 
 	// module account permissions
@@ -83,6 +104,8 @@ var (
 		staking.BondedPoolName:    {supply.Burner, supply.Staking},
 		staking.NotBondedPoolName: {supply.Burner, supply.Staking},
 		gov.ModuleName:            {supply.Burner},
+		erc20.ModuleName:          {supply.Minter, supply.Burner},
+		evm.ReserveName:           nil,
 	}
 
 	// module accounts that are allowed to receive tokens
@@ -123,6 +146,7 @@ type EthermintApp struct {
 	ParamsKeeper   params.Keeper
 	EvidenceKeeper evidence.Keeper
 	EvmKeeper      *evm.Keeper
+	Erc20Keeper    erc20.Keeper
 
 	// the module manager
 	mm *module.Manager
@@ -153,7 +177,7 @@ func NewEthermintApp(
 		bam.MainStoreKey, auth.StoreKey, staking.StoreKey,
 		supply.StoreKey, mint.StoreKey, distr.StoreKey, slashing.StoreKey,
 		gov.StoreKey, params.StoreKey, upgrade.StoreKey, evidence.StoreKey,
-		evm.StoreKey,
+		evm.StoreKey, erc20.StoreKey,
 	)
 
 	tkeys := sdk.NewTransientStoreKeys(params.TStoreKey)
@@ -179,6 +203,7 @@ func NewEthermintApp(
 	app.subspaces[crisis.ModuleName] = app.ParamsKeeper.Subspace(crisis.DefaultParamspace)
 	app.subspaces[evidence.ModuleName] = app.ParamsKeeper.Subspace(evidence.DefaultParamspace)
 	app.subspaces[evm.ModuleName] = app.ParamsKeeper.Subspace(evm.DefaultParamspace)
+	app.subspaces[erc20.ModuleName] = app.ParamsKeeper.Subspace(erc20.DefaultParamspace)
 
 	// use custom Ethermint account for contracts
 	app.AccountKeeper = auth.NewAccountKeeper(
@@ -210,6 +235,10 @@ func NewEthermintApp(
 	app.UpgradeKeeper = upgrade.NewKeeper(skipUpgradeHeights, keys[upgrade.StoreKey], app.cdc)
 	app.EvmKeeper = evm.NewKeeper(
 		app.cdc, keys[evm.StoreKey], app.subspaces[evm.ModuleName], app.AccountKeeper,
+		app.SupplyKeeper, app.BlacklistedAccAddrs(),
+	)
+	app.Erc20Keeper = erc20.NewKeeper(
+		app.cdc, keys[erc20.StoreKey], app.subspaces[erc20.ModuleName], app.SupplyKeeper, app.EvmKeeper,
 	)
 
 	// create evidence keeper with router
@@ -226,19 +255,55 @@ func NewEthermintApp(
 	govRouter.AddRoute(gov.RouterKey, gov.ProposalHandler).
 		AddRoute(params.RouterKey, params.NewParamChangeProposalHandler(app.ParamsKeeper)).
 		AddRoute(distr.RouterKey, distr.NewCommunityPoolSpendProposalHandler(app.DistrKeeper)).
-		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper))
+		AddRoute(upgrade.RouterKey, upgrade.NewSoftwareUpgradeProposalHandler(app.UpgradeKeeper)).
+		AddRoute(erc20.RouterKey, erc20.NewTokenPairProposalHandler(app.Erc20Keeper)).
+		AddRoute(evm.RouterKey, evm.NewProposalHandler(app.EvmKeeper))
 
 	app.GovKeeper = gov.NewKeeper(
 		cdc, keys[gov.StoreKey], app.subspaces[gov.ModuleName], app.SupplyKeeper,
 		&stakingKeeper, govRouter,
 	)
 
+	app.UpgradeKeeper.SetUpgradeHandler(UpgradeNameEVMStorageCleanup, func(ctx sdk.Context, _ upgrade.Plan) {
+		// the params added since the chain started must be set before they are read,
+		// e.g by the pruning of the destructed storage on EndBlock
+		params := app.EvmKeeper.SetMissingParams(ctx)
+		ctx.Logger().Info("set the default EVM params", "keys", params)
+
+		deleted := app.EvmKeeper.DeleteOrphanedStorage(ctx)
+		ctx.Logger().Info("deleted orphaned EVM storage", "slots", deleted)
+	})
+
+	app.UpgradeKeeper.SetUpgradeHandler(UpgradeNameEVMLogsIndex, func(ctx sdk.Context, _ upgrade.Plan) {
+		// the params added since the chain started must be set before they are read,
+		// e.g by the pruning of the logs on EndBlock
+		params := app.EvmKeeper.SetMissingParams(ctx)
+		ctx.Logger().Info("set the default EVM params", "keys", params)
+
+		indexed, err := app.EvmKeeper.IndexLogsHeight(ctx)
+		if err != nil {
+			panic(err)
+		}
+
+		ctx.Logger().Info("indexed EVM logs by height", "transactions", indexed)
+	})
+
 	// register the staking hooks
 	// NOTE: stakingKeeper above is passed by reference, so that it will contain these hooks
 	app.StakingKeeper = *stakingKeeper.SetHooks(
 		staking.NewMultiStakingHooks(app.DistrKeeper.Hooks(), app.SlashingKeeper.Hooks()),
 	)
 
+	// register the stateful precompiled contracts. They need to be enabled through
+	// the evm ActivePrecompiles param.
+	// NOTE: the precompiles are registered after the staking hooks are set, so that
+	// the staking keeper used by the precompiles contains them.
+	app.EvmKeeper.RegisterPrecompiles(
+		precompiles.NewBankPrecompile(app.SupplyKeeper, app.BlacklistedAccAddrs()),
+		precompiles.NewStakingPrecompile(app.StakingKeeper, app.DistrKeeper),
+		precompiles.NewDistributionPrecompile(app.DistrKeeper),
+	)
+
 	// NOTE: Any module instantiated in the module manager that is later modified
 	// must be passed by reference here.
 	app.mm = module.NewManager(
@@ -254,6 +319,7 @@ func NewEthermintApp(
 		staking.NewAppModule(app.StakingKeeper, app.AccountKeeper, app.SupplyKeeper),
 		evidence.NewAppModule(app.EvidenceKeeper),
 		evm.NewAppModule(app.EvmKeeper, app.AccountKeeper),
+		erc20.NewAppModule(app.Erc20Keeper),
 	)
 
 	// During begin block slashing happens after distr.BeginBlocker so that
@@ -272,7 +338,7 @@ func NewEthermintApp(
 	app.mm.SetOrderInitGenesis(
 		auth.ModuleName, distr.ModuleName, staking.ModuleName, bank.ModuleName,
 		slashing.ModuleName, gov.ModuleName, mint.ModuleName, supply.ModuleName,
-		evm.ModuleName, crisis.ModuleName, genutil.ModuleName, evidence.ModuleName,
+		evm.ModuleName, erc20.ModuleName, crisis.ModuleName, genutil.ModuleName, evidence.ModuleName,
 	)
 
 	app.mm.RegisterInvariants(&app.CrisisKeeper)
@@ -324,6 +390,15 @@ func (app *EthermintApp) BeginBlocker(ctx sdk.Context, req abci.RequestBeginBloc
 	return app.mm.BeginBlock(ctx, req)
 }
 
+// DeliverTx implements the ABCI interface. It delivers the transaction on the
+// BaseApp, then pays the gas refund of a failed MsgEthereumTx on the state of the
+// block, as the state changes of the failed message are discarded.
+func (app *EthermintApp) DeliverTx(req abci.RequestDeliverTx) abci.ResponseDeliverTx {
+	res := app.BaseApp.DeliverTx(req)
+	app.EvmKeeper.PayFailedTxRefund(app.NewContext(false, abci.Header{}).MultiStore())
+	return res
+}
+
 // EndBlocker updates every end block
 func (app *EthermintApp) EndBlocker(ctx sdk.Context, req abci.RequestEndBlock) abci.ResponseEndBlock {
 	return app.mm.EndBlock(ctx, req)
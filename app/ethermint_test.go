@@ -11,6 +11,11 @@ import (
 	dbm "github.com/tendermint/tm-db"
 
 	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/upgrade"
+
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 )
 
 func TestEthermintAppExport(t *testing.T) {
@@ -35,3 +40,39 @@ func TestEthermintAppExport(t *testing.T) {
 	_, _, err = app2.ExportAppStateAndValidators(false, []string{})
 	require.NoError(t, err, "ExportAppStateAndValidators should not have an error")
 }
+
+func TestEVMUpgradesSetMissingParams(t *testing.T) {
+	for _, name := range []string{UpgradeNameEVMLogsIndex, UpgradeNameEVMStorageCleanup} {
+		app := NewEthermintApp(log.NewNopLogger(), dbm.NewMemDB(), nil, true, map[int64]bool{}, 0)
+
+		genesisState := ModuleBasics.DefaultGenesis()
+		stateBytes, err := codec.MarshalJSONIndent(app.cdc, genesisState)
+		require.NoError(t, err)
+
+		app.InitChain(abci.RequestInitChain{Validators: []abci.ValidatorUpdate{}, AppStateBytes: stateBytes})
+		ctx := app.BaseApp.NewContext(true, abci.Header{Height: 2})
+
+		// the param space of a chain started before the params were added only has
+		// the baseline params
+		paramStore := prefix.NewStore(ctx.KVStore(app.GetKey(params.StoreKey)), []byte(evmtypes.ModuleName+"/"))
+		baseline := map[string]bool{
+			string(evmtypes.ParamStoreKeyEVMDenom):     true,
+			string(evmtypes.ParamStoreKeyEnableCreate): true,
+			string(evmtypes.ParamStoreKeyEnableCall):   true,
+			string(evmtypes.ParamStoreKeyExtraEIPs):    true,
+		}
+
+		evmParams := evmtypes.DefaultParams()
+		for _, pair := range evmParams.ParamSetPairs() {
+			if !baseline[string(pair.Key)] {
+				paramStore.Delete(pair.Key)
+			}
+		}
+		ctx.KVStore(app.GetKey(evmtypes.StoreKey)).Delete(evmtypes.KeyEVMDenomDecimals)
+
+		require.Panics(t, func() { app.EvmKeeper.GetParams(ctx) }, name)
+
+		app.UpgradeKeeper.ApplyUpgrade(ctx, upgrade.Plan{Name: name, Height: 2})
+		require.Equal(t, evmtypes.DefaultParams(), app.EvmKeeper.GetParams(ctx), name)
+	}
+}
@@ -0,0 +1,118 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+
+	"github.com/tendermint/tendermint/libs/cli"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	"github.com/cosmos/cosmos-sdk/server"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/genutil"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+)
+
+const (
+	flagWrappedTokenName   = "wrapped-token-name"
+	flagWrappedTokenSymbol = "wrapped-token-symbol"
+)
+
+// AddGenesisPreinstallsCmd returns add-genesis-preinstalls cobra Command.
+func AddGenesisPreinstallsCmd(ctx *server.Context, cdc *codec.Codec, defaultNodeHome string) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "add-genesis-preinstalls",
+		Short: "Add the well-known contracts to genesis.json",
+		Long: fmt.Sprintf(`Add the well-known contracts to genesis.json, with their code and storage:
+  - the deterministic deployment proxy (CREATE2 factory) at %s
+  - Multicall3 at %s
+  - the WETH9 wrapped native token at %s
+
+An auth account and an EVM genesis account are added for each contract. The command
+fails if any of the accounts already exists.
+`, evmtypes.DeterministicDeployerAddress, evmtypes.Multicall3Address, evmtypes.WrappedTokenAddress()),
+		Args: cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			config := ctx.Config
+			config.SetRoot(viper.GetString(cli.HomeFlag))
+
+			preinstalls := []evmtypes.Preinstall{
+				evmtypes.DeterministicDeployerPreinstall(),
+				evmtypes.Multicall3Preinstall(),
+				evmtypes.WrappedTokenPreinstall(
+					viper.GetString(flagWrappedTokenName), viper.GetString(flagWrappedTokenSymbol),
+				),
+			}
+
+			genFile := config.GenesisFile()
+			appState, genDoc, err := genutil.GenesisStateFromGenFile(cdc, genFile)
+			if err != nil {
+				return fmt.Errorf("failed to unmarshal genesis state: %w", err)
+			}
+
+			authGenState := auth.GetGenesisStateFromAppState(cdc, appState)
+
+			var evmGenState evmtypes.GenesisState
+			if err := cdc.UnmarshalJSON(appState[evmtypes.ModuleName], &evmGenState); err != nil {
+				return fmt.Errorf("failed to unmarshal evm genesis state: %w", err)
+			}
+
+			if err := evmGenState.AddPreinstalls(preinstalls...); err != nil {
+				return err
+			}
+
+			for _, p := range preinstalls {
+				addr := sdk.AccAddress(p.Address.Bytes())
+				if authGenState.Accounts.Contains(addr) {
+					return fmt.Errorf("cannot add %s preinstall at existing address %s", p.Name, p.Address)
+				}
+
+				// the contract accounts start with nonce 1 (EIP-161)
+				genAccount := ethermint.EthAccount{
+					BaseAccount: auth.NewBaseAccount(addr, sdk.NewCoins(), nil, 0, 1),
+					CodeHash:    p.CodeHash(),
+				}
+
+				if err := genAccount.Validate(); err != nil {
+					return fmt.Errorf("failed to validate %s preinstall account: %w", p.Name, err)
+				}
+
+				authGenState.Accounts = append(authGenState.Accounts, genAccount)
+			}
+
+			authGenState.Accounts = auth.SanitizeGenesisAccounts(authGenState.Accounts)
+
+			authGenStateBz, err := cdc.MarshalJSON(authGenState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal auth genesis state: %w", err)
+			}
+
+			evmGenStateBz, err := cdc.MarshalJSON(evmGenState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal evm genesis state: %w", err)
+			}
+
+			appState[auth.ModuleName] = authGenStateBz
+			appState[evmtypes.ModuleName] = evmGenStateBz
+
+			appStateJSON, err := cdc.MarshalJSON(appState)
+			if err != nil {
+				return fmt.Errorf("failed to marshal application genesis state: %w", err)
+			}
+
+			genDoc.AppState = appStateJSON
+			return genutil.ExportGenesisFile(genDoc, genFile)
+		},
+	}
+
+	cmd.Flags().String(cli.HomeFlag, defaultNodeHome, "node's home directory")
+	cmd.Flags().String(flagWrappedTokenName, evmtypes.DefaultWrappedTokenName, "name of the wrapped native token")
+	cmd.Flags().String(flagWrappedTokenSymbol, evmtypes.DefaultWrappedTokenSymbol, "symbol of the wrapped native token")
+
+	return cmd
+}
@@ -3,6 +3,7 @@ package main
 import (
 	"encoding/json"
 	"io"
+	"path/filepath"
 
 	"github.com/spf13/cobra"
 	"github.com/spf13/viper"
@@ -32,9 +33,22 @@ import (
 	"github.com/cosmos/ethermint/codec"
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	ethermint "github.com/cosmos/ethermint/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 )
 
-const flagInvCheckPeriod = "inv-check-period"
+const (
+	flagInvCheckPeriod = "inv-check-period"
+
+	// app.toml keys of the EVM state cache sizes, set under the [evm] section
+	flagEVMCodeCacheSize    = "evm.code-cache-size"
+	flagEVMStorageCacheSize = "evm.storage-cache-size"
+	// app.toml key that enables the call traces of the trace_ JSON-RPC namespace
+	flagEVMTracing = "evm.tracing"
+
+	evmTracesDBName = "evmtraces"
+
+	metricsNamespace = "ethermint"
+)
 
 var invCheckPeriod uint
 
@@ -80,6 +94,8 @@ func main() {
 		client.TestnetCmd(ctx, cdc, app.ModuleBasics, auth.GenesisAccountIterator{}),
 		// AddGenesisAccountCmd allows users to add accounts to the genesis file
 		AddGenesisAccountCmd(ctx, cdc, app.DefaultNodeHome, app.DefaultCLIHome),
+		// AddGenesisPreinstallsCmd adds the well-known contracts to the genesis file
+		AddGenesisPreinstallsCmd(ctx, cdc, app.DefaultNodeHome),
 		flags.NewCompletionCmd(rootCmd, true),
 	)
 
@@ -90,6 +106,11 @@ func main() {
 	executor := cli.PrepareBaseCmd(rootCmd, "EM", app.DefaultNodeHome)
 	rootCmd.PersistentFlags().UintVar(&invCheckPeriod, flagInvCheckPeriod,
 		0, "Assert registered invariants every N blocks")
+
+	viper.SetDefault(flagEVMCodeCacheSize, evmtypes.DefaultCodeCacheSize)
+	viper.SetDefault(flagEVMStorageCacheSize, evmtypes.DefaultStorageCacheSize)
+	viper.SetDefault(flagEVMTracing, false)
+
 	err := executor.Execute()
 	if err != nil {
 		panic(err)
@@ -97,7 +118,7 @@ func main() {
 }
 
 func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application {
-	return app.NewEthermintApp(
+	ethermintApp := app.NewEthermintApp(
 		logger,
 		db,
 		traceStore,
@@ -108,6 +129,25 @@ func newApp(logger log.Logger, db dbm.DB, traceStore io.Writer) abci.Application
 		baseapp.SetMinGasPrices(viper.GetString(server.FlagMinGasPrices)),
 		baseapp.SetHaltHeight(uint64(viper.GetInt(server.FlagHaltHeight))),
 	)
+
+	// the cache hit and miss counters are served by the Tendermint Prometheus
+	// endpoint when the instrumentation is enabled
+	stateCache := ethermintApp.EvmKeeper.StateCache()
+	stateCache.Resize(viper.GetInt(flagEVMCodeCacheSize), viper.GetInt(flagEVMStorageCacheSize))
+	stateCache.SetMetrics(evmtypes.PrometheusStateCacheMetrics(metricsNamespace))
+
+	// the call traces are stored on a separate database of the data directory, as
+	// they aren't part of the application state
+	if viper.GetBool(flagEVMTracing) {
+		tracesDB, err := dbm.NewGoLevelDB(evmTracesDBName, filepath.Join(viper.GetString(cli.HomeFlag), "data"))
+		if err != nil {
+			panic(err)
+		}
+
+		ethermintApp.EvmKeeper.TraceStore().SetDB(tracesDB)
+	}
+
+	return ethermintApp
 }
 
 func exportAppStateAndTMValidators(
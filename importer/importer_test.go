@@ -134,12 +134,6 @@ func createAndTestGenesis(t *testing.T, cms sdk.CommitMultiStore, ak auth.Accoun
 	b := evmKeeper.GetBalance(ctx, genInvestor)
 	require.Equal(t, "200000000000000000000", b.String())
 
-	// commit the stateDB with 'false' to delete empty objects
-	//
-	// NOTE: Commit does not yet return the intra merkle root (version)
-	_, err := evmKeeper.Commit(ctx, false)
-	require.NoError(t, err)
-
 	// persist multi-store cache state
 	ms.Write()
 
@@ -195,7 +189,7 @@ func TestImportBlocks(t *testing.T) {
 	authSubspace := paramsKeeper.Subspace(auth.DefaultParamspace)
 	evmSubspace := paramsKeeper.Subspace(evmtypes.DefaultParamspace).WithKeyTable(evmtypes.ParamKeyTable())
 	ak := auth.NewAccountKeeper(cdc, authStoreKey, authSubspace, types.ProtoAccount)
-	evmKeeper := evm.NewKeeper(cdc, evmStoreKey, evmSubspace, ak)
+	evmKeeper := evm.NewKeeper(cdc, evmStoreKey, evmSubspace, ak, nil, nil)
 
 	cms.SetPruning(sdkstore.PruneNothing)
 
@@ -249,26 +243,29 @@ func TestImportBlocks(t *testing.T) {
 		ctx := sdk.NewContext(ms, abci.Header{}, false, logger)
 		ctx = ctx.WithBlockHeight(int64(block.NumberU64()))
 
+		// the state of the whole block is applied on the same StateDB
+		csdb := evmKeeper.NewStateDB(ctx)
+		csdb.SetBlockHash(block.Hash())
+
 		if chainConfig.DAOForkSupport && chainConfig.DAOForkBlock != nil && chainConfig.DAOForkBlock.Cmp(block.Number()) == 0 {
-			applyDAOHardFork(evmKeeper)
+			applyDAOHardFork(csdb)
 		}
 
 		for i, tx := range block.Transactions() {
-			evmKeeper.Prepare(ctx, tx.Hash(), i)
-			evmKeeper.CommitStateDB.SetBlockHash(block.Hash())
+			csdb.Prepare(tx.Hash(), i)
 
 			receipt, gas, err := applyTransaction(
-				chainConfig, chainContext, nil, gp, evmKeeper, header, tx, usedGas, vmConfig,
+				chainConfig, chainContext, nil, gp, csdb, header, tx, usedGas, vmConfig,
 			)
 			require.NoError(t, err, "failed to apply tx at block %d; tx: %X; gas %d; receipt:%v", block.NumberU64(), tx.Hash(), gas, receipt)
 			require.NotNil(t, receipt)
 		}
 
 		// apply mining rewards
-		accumulateRewards(chainConfig, evmKeeper, header, block.Uncles())
+		accumulateRewards(chainConfig, csdb, header, block.Uncles())
 
 		// commit stateDB
-		_, err := evmKeeper.CommitStateDB.Commit(chainConfig.IsEIP158(block.Number()))
+		_, err := csdb.Commit(chainConfig.IsEIP158(block.Number()))
 		require.NoError(t, err, "failed to commit StateDB")
 
 		// simulate BaseApp EndBlocker commitment
@@ -286,7 +283,7 @@ func TestImportBlocks(t *testing.T) {
 // reward. The total reward consists of the static block reward and rewards for
 // included uncles. The coinbase of each uncle block is also rewarded.
 func accumulateRewards(
-	config *ethparams.ChainConfig, evmKeeper *evm.Keeper,
+	config *ethparams.ChainConfig, csdb *evmtypes.CommitStateDB,
 	header *ethtypes.Header, uncles []*ethtypes.Header,
 ) {
 
@@ -305,12 +302,12 @@ func accumulateRewards(
 		r.Sub(r, header.Number)
 		r.Mul(r, blockReward)
 		r.Div(r, rewardBig8)
-		evmKeeper.CommitStateDB.AddBalance(uncle.Coinbase, r)
+		csdb.AddBalance(uncle.Coinbase, r)
 		r.Div(blockReward, rewardBig32)
 		reward.Add(reward, r)
 	}
 
-	evmKeeper.CommitStateDB.AddBalance(header.Coinbase, reward)
+	csdb.AddBalance(header.Coinbase, reward)
 }
 
 // ApplyDAOHardFork modifies the state database according to the DAO hard-fork
@@ -319,16 +316,16 @@ func accumulateRewards(
 // Code is pulled from go-ethereum 1.9 because the StateDB interface does not include the
 // SetBalance function implementation
 // Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/consensus/misc/dao.go#L74
-func applyDAOHardFork(evmKeeper *evm.Keeper) {
+func applyDAOHardFork(csdb *evmtypes.CommitStateDB) {
 	// Retrieve the contract to refund balances into
-	if !evmKeeper.CommitStateDB.Exist(ethparams.DAORefundContract) {
-		evmKeeper.CommitStateDB.CreateAccount(ethparams.DAORefundContract)
+	if !csdb.Exist(ethparams.DAORefundContract) {
+		csdb.CreateAccount(ethparams.DAORefundContract)
 	}
 
 	// Move every DAO account and extra-balance account funds into the refund contract
 	for _, addr := range ethparams.DAODrainList() {
-		evmKeeper.CommitStateDB.AddBalance(ethparams.DAORefundContract, evmKeeper.CommitStateDB.GetBalance(addr))
-		evmKeeper.CommitStateDB.SetBalance(addr, new(big.Int))
+		csdb.AddBalance(ethparams.DAORefundContract, csdb.GetBalance(addr))
+		csdb.SetBalance(addr, new(big.Int))
 	}
 }
 
@@ -340,10 +337,10 @@ func applyDAOHardFork(evmKeeper *evm.Keeper) {
 // Ref: https://github.com/ethereum/go-ethereum/blob/52f2461774bcb8cdd310f86b4bc501df5b783852/core/state_processor.go#L88
 func applyTransaction(
 	config *ethparams.ChainConfig, bc ethcore.ChainContext, author *ethcmn.Address,
-	gp *ethcore.GasPool, evmKeeper *evm.Keeper, header *ethtypes.Header,
+	gp *ethcore.GasPool, csdb *evmtypes.CommitStateDB, header *ethtypes.Header,
 	tx *ethtypes.Transaction, usedGas *uint64, cfg ethvm.Config,
 ) (*ethtypes.Receipt, uint64, error) {
-	msg, err := tx.AsMessage(ethtypes.MakeSigner(config, header.Number))
+	msg, err := tx.AsMessage(ethtypes.MakeSigner(config, header.Number), header.BaseFee)
 	if err != nil {
 		return nil, 0, err
 	}
@@ -354,7 +351,7 @@ func applyTransaction(
 
 	// Create a new environment which holds all relevant information
 	// about the transaction and calling mechanisms.
-	vmenv := ethvm.NewEVM(blockCtx, txCtx, evmKeeper.CommitStateDB, config, cfg)
+	vmenv := ethvm.NewEVM(blockCtx, txCtx, csdb, config, cfg)
 
 	// Apply the transaction to the current state (included in the env)
 	execResult, err := ethcore.ApplyMessage(vmenv, msg, gp)
@@ -366,9 +363,9 @@ func applyTransaction(
 	// Update the state with pending changes
 	var intRoot ethcmn.Hash
 	if config.IsByzantium(header.Number) {
-		err = evmKeeper.CommitStateDB.Finalise(true)
+		err = csdb.Finalise(true)
 	} else {
-		intRoot, err = evmKeeper.CommitStateDB.IntermediateRoot(config.IsEIP158(header.Number))
+		intRoot, err = csdb.IntermediateRoot(config.IsEIP158(header.Number))
 	}
 
 	if err != nil {
@@ -390,11 +387,11 @@ func applyTransaction(
 	}
 
 	// Set the receipt logs and create a bloom for filtering
-	receipt.Logs, err = evmKeeper.CommitStateDB.GetLogs(tx.Hash())
+	receipt.Logs, err = csdb.GetLogs(tx.Hash())
 	receipt.Bloom = ethtypes.CreateBloom(ethtypes.Receipts{receipt})
-	receipt.BlockHash = evmKeeper.CommitStateDB.BlockHash()
+	receipt.BlockHash = csdb.BlockHash()
 	receipt.BlockNumber = header.Number
-	receipt.TransactionIndex = uint(evmKeeper.CommitStateDB.TxIndex())
+	receipt.TransactionIndex = uint(csdb.TxIndex())
 
 	return receipt, execResult.UsedGas, err
 }
@@ -7,10 +7,13 @@ import (
 
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	"github.com/cosmos/ethermint/rpc/backend"
+	"github.com/cosmos/ethermint/rpc/indexer"
+	"github.com/cosmos/ethermint/rpc/namespaces/debug"
 	"github.com/cosmos/ethermint/rpc/namespaces/eth"
 	"github.com/cosmos/ethermint/rpc/namespaces/eth/filters"
 	"github.com/cosmos/ethermint/rpc/namespaces/net"
 	"github.com/cosmos/ethermint/rpc/namespaces/personal"
+	"github.com/cosmos/ethermint/rpc/namespaces/trace"
 	"github.com/cosmos/ethermint/rpc/namespaces/web3"
 	rpctypes "github.com/cosmos/ethermint/rpc/types"
 )
@@ -21,15 +24,20 @@ const (
 	EthNamespace      = "eth"
 	PersonalNamespace = "personal"
 	NetNamespace      = "net"
+	TraceNamespace    = "trace"
+	DebugNamespace    = "debug"
 	flagRPCAPI        = "rpc-api"
 
 	apiVersion = "1.0"
 )
 
-// GetAPIs returns the list of all APIs from the Ethereum namespaces
-func GetAPIs(clientCtx context.CLIContext, selectedApis []string, keys ...ethsecp256k1.PrivKey) []rpc.API {
+// GetAPIs returns the list of all APIs from the Ethereum namespaces. The indexer
+// is optional.
+func GetAPIs(
+	clientCtx context.CLIContext, idx *indexer.Indexer, selectedApis []string, keys ...ethsecp256k1.PrivKey,
+) []rpc.API {
 	nonceLock := new(rpctypes.AddrLocker)
-	backend := backend.New(clientCtx)
+	backend := backend.New(clientCtx, idx)
 	ethAPI := eth.NewAPI(clientCtx, backend, nonceLock, keys...)
 
 	var apis []rpc.API
@@ -80,6 +88,24 @@ func GetAPIs(clientCtx context.CLIContext, selectedApis []string, keys ...ethsec
 					Public:    true,
 				},
 			)
+		case TraceNamespace:
+			apis = append(apis,
+				rpc.API{
+					Namespace: TraceNamespace,
+					Version:   apiVersion,
+					Service:   trace.NewAPI(clientCtx, backend),
+					Public:    true,
+				},
+			)
+		case DebugNamespace:
+			apis = append(apis,
+				rpc.API{
+					Namespace: DebugNamespace,
+					Version:   apiVersion,
+					Service:   debug.NewAPI(clientCtx, backend),
+					Public:    true,
+				},
+			)
 		}
 	}
 
@@ -7,6 +7,7 @@ import (
 
 	"github.com/tendermint/tendermint/libs/log"
 
+	"github.com/cosmos/ethermint/rpc/indexer"
 	rpctypes "github.com/cosmos/ethermint/rpc/types"
 	evmtypes "github.com/cosmos/ethermint/x/evm/types"
 
@@ -27,6 +28,7 @@ type Backend interface {
 	HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error)
 	GetBlockByNumber(blockNum rpctypes.BlockNumber, fullTx bool) (map[string]interface{}, error)
 	GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error)
+	BlockHeightByHash(blockHash common.Hash) (int64, error)
 
 	// returns the logs of a given block
 	GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, error)
@@ -37,6 +39,11 @@ type Backend interface {
 	// Used by log filter
 	GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
+
+	// Used by the indexed queries, which fall back to the node queries if they
+	// return an error
+	GetTxRecord(txHash common.Hash) (*indexer.TxRecord, error)
+	GetFilteredLogs(begin, end int64, addresses []common.Address, topics [][]common.Hash) ([]*ethtypes.Log, error)
 }
 
 var _ Backend = (*EthermintBackend)(nil)
@@ -47,13 +54,16 @@ type EthermintBackend struct {
 	clientCtx clientcontext.CLIContext
 	logger    log.Logger
 	gasLimit  int64
+	indexer   *indexer.Indexer
 }
 
-// New creates a new EthermintBackend instance
-func New(clientCtx clientcontext.CLIContext) *EthermintBackend {
+// New creates a new EthermintBackend instance. The indexer is optional: when it's
+// set, the backend prefers the indexed data over the node queries.
+func New(clientCtx clientcontext.CLIContext, idx *indexer.Indexer) *EthermintBackend {
 	return &EthermintBackend{
 		ctx:       context.Background(),
 		clientCtx: clientCtx,
+		indexer:   idx,
 		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc"),
 		gasLimit:  int64(^uint32(0)),
 	}
@@ -90,19 +100,35 @@ func (b *EthermintBackend) GetBlockByNumber(blockNum rpctypes.BlockNumber, fullT
 	return rpctypes.EthBlockFromTendermint(b.clientCtx, resBlock.Block)
 }
 
-// GetBlockByHash returns the block identified by hash.
-func (b *EthermintBackend) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
-	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, hash.Hex()))
+// BlockHeightByHash returns the height of the block identified by hash.
+func (b *EthermintBackend) BlockHeightByHash(blockHash common.Hash) (int64, error) {
+	if b.indexer != nil {
+		if height, err := b.indexer.BlockHeight(blockHash); err == nil {
+			return height, nil
+		}
+	}
+
+	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, blockHash.Hex()))
 	if err != nil {
-		return nil, err
+		return 0, err
 	}
 
 	var out evmtypes.QueryResBlockNumber
 	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return 0, err
+	}
+
+	return out.Number, nil
+}
+
+// GetBlockByHash returns the block identified by hash.
+func (b *EthermintBackend) GetBlockByHash(hash common.Hash, fullTx bool) (map[string]interface{}, error) {
+	height, err := b.BlockHeightByHash(hash)
+	if err != nil {
 		return nil, err
 	}
 
-	resBlock, err := b.clientCtx.Client.Block(&out.Number)
+	resBlock, err := b.clientCtx.Client.Block(&height)
 	if err != nil {
 		return nil, err
 	}
@@ -128,46 +154,35 @@ func (b *EthermintBackend) HeaderByNumber(blockNum rpctypes.BlockNumber) (*ethty
 		return nil, err
 	}
 
-	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height))
+	bloom, err := rpctypes.BlockBloom(b.clientCtx, resBlock.Block.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	var bloomRes evmtypes.QueryBloomFilter
-	b.clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
-
 	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header)
-	ethHeader.Bloom = bloomRes.Bloom
+	ethHeader.Bloom = bloom
 	return ethHeader, nil
 }
 
 // HeaderByHash returns the block header identified by hash.
 func (b *EthermintBackend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header, error) {
-	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, blockHash.Hex()))
+	height, err := b.BlockHeightByHash(blockHash)
 	if err != nil {
 		return nil, err
 	}
 
-	var out evmtypes.QueryResBlockNumber
-	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
-		return nil, err
-	}
-
-	resBlock, err := b.clientCtx.Client.Block(&out.Number)
+	resBlock, err := b.clientCtx.Client.Block(&height)
 	if err != nil {
 		return nil, err
 	}
 
-	res, _, err = b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, resBlock.Block.Height))
+	bloom, err := rpctypes.BlockBloom(b.clientCtx, resBlock.Block.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	var bloomRes evmtypes.QueryBloomFilter
-	b.clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
-
 	ethHeader := rpctypes.EthHeaderFromTendermint(resBlock.Block.Header)
-	ethHeader.Bloom = bloomRes.Bloom
+	ethHeader.Bloom = bloom
 	return ethHeader, nil
 }
 
@@ -175,17 +190,11 @@ func (b *EthermintBackend) HeaderByHash(blockHash common.Hash) (*ethtypes.Header
 // It returns an error if there's an encoding error.
 // If no logs are found for the tx hash, the error is nil.
 func (b *EthermintBackend) GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error) {
-	res, _, err := b.clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, txHash.String()), nil)
-	if err != nil {
-		return nil, err
-	}
-
-	out := new(evmtypes.QueryETHLogs)
-	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
-		return nil, err
+	if record, err := b.GetTxRecord(txHash); err == nil {
+		return record.Logs, nil
 	}
 
-	return out.Logs, nil
+	return rpctypes.TransactionLogs(b.clientCtx, txHash)
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
@@ -216,17 +225,18 @@ func (b *EthermintBackend) PendingTransactions() ([]*rpctypes.Transaction, error
 
 // GetLogs returns all the logs from all the ethereum transactions in a block.
 func (b *EthermintBackend) GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, error) {
-	res, _, err := b.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, blockHash.Hex()))
+	height, err := b.BlockHeightByHash(blockHash)
 	if err != nil {
 		return nil, err
 	}
 
-	var out evmtypes.QueryResBlockNumber
-	if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
-		return nil, err
+	if b.indexer != nil {
+		if blockLogs, err := b.indexer.BlockLogs(height); err == nil {
+			return blockLogs, nil
+		}
 	}
 
-	block, err := b.clientCtx.Client.Block(&out.Number)
+	block, err := b.clientCtx.Client.Block(&height)
 	if err != nil {
 		return nil, err
 	}
@@ -234,22 +244,40 @@ func (b *EthermintBackend) GetLogs(blockHash common.Hash) ([][]*ethtypes.Log, er
 	var blockLogs = [][]*ethtypes.Log{}
 	for _, tx := range block.Block.Txs {
 		// NOTE: we query the state in case the tx result logs are not persisted after an upgrade.
-		res, _, err := b.clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, common.BytesToHash(tx.Hash()).String()), nil)
+		logs, err := rpctypes.TransactionLogs(b.clientCtx, common.BytesToHash(tx.Hash()))
 		if err != nil {
 			continue
 		}
 
-		out := new(evmtypes.QueryETHLogs)
-		if err := b.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
-			return nil, err
-		}
-
-		blockLogs = append(blockLogs, out.Logs)
+		blockLogs = append(blockLogs, logs)
 	}
 
 	return blockLogs, nil
 }
 
+// GetTxRecord returns the indexed record of an Ethereum transaction. It returns
+// indexer.ErrNotIndexed if the indexer is disabled or hasn't indexed the tx.
+func (b *EthermintBackend) GetTxRecord(txHash common.Hash) (*indexer.TxRecord, error) {
+	if b.indexer == nil {
+		return nil, indexer.ErrNotIndexed
+	}
+
+	return b.indexer.GetTx(txHash)
+}
+
+// GetFilteredLogs returns the indexed logs of the block range that match the
+// addresses and topics. It returns indexer.ErrNotIndexed if the indexer is
+// disabled or hasn't indexed the whole range.
+func (b *EthermintBackend) GetFilteredLogs(
+	begin, end int64, addresses []common.Address, topics [][]common.Hash,
+) ([]*ethtypes.Log, error) {
+	if b.indexer == nil {
+		return nil, indexer.ErrNotIndexed
+	}
+
+	return b.indexer.FilterLogs(begin, end, addresses, topics)
+}
+
 // BloomStatus returns the BloomBitsBlocks and the number of processed sections maintained
 // by the chain indexer.
 func (b *EthermintBackend) BloomStatus() (uint64, uint64) {
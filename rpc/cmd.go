@@ -13,9 +13,11 @@ import (
 // Cosmos rest-server endpoints
 func ServeCmd(cdc *codec.Codec) *cobra.Command {
 	cmd := lcd.ServeCommand(cdc, RegisterRoutes)
-	cmd.Flags().String(flagRPCAPI, "", fmt.Sprintf("Comma separated list of RPC API modules to enable: %s, %s, %s, %s", Web3Namespace, EthNamespace, PersonalNamespace, NetNamespace))
+	cmd.Flags().String(flagRPCAPI, "", fmt.Sprintf("Comma separated list of RPC API modules to enable: %s, %s, %s, %s, %s, %s", Web3Namespace, EthNamespace, PersonalNamespace, NetNamespace, TraceNamespace, DebugNamespace))
 	cmd.Flags().String(flagUnlockKey, "", "Select a key to unlock on the RPC server")
 	cmd.Flags().String(flagWebsocket, "8546", "websocket port to listen to")
+	cmd.Flags().Bool(flagEVMIndexer, false, "Index the Ethereum transactions, receipts and logs on a local database used by the web3 RPC API")
+	cmd.Flags().Int64(flagEVMIndexerReindexFrom, 0, "Reindex the blocks from the given height when the EVM indexer is enabled")
 	cmd.Flags().StringP(flags.FlagBroadcastMode, "b", flags.BroadcastSync, "Transaction broadcasting mode (sync|async|block)")
 	return cmd
 }
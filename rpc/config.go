@@ -4,9 +4,14 @@ import (
 	"bufio"
 	"fmt"
 	"os"
+	"path/filepath"
 	"strings"
 
+	"github.com/tendermint/tendermint/libs/log"
+	dbm "github.com/tendermint/tm-db"
+
 	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
 	"github.com/cosmos/cosmos-sdk/client/flags"
 	"github.com/cosmos/cosmos-sdk/client/input"
 	"github.com/cosmos/cosmos-sdk/client/lcd"
@@ -15,6 +20,7 @@ import (
 	"github.com/cosmos/ethermint/app"
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	"github.com/cosmos/ethermint/crypto/hd"
+	"github.com/cosmos/ethermint/rpc/indexer"
 	"github.com/cosmos/ethermint/rpc/websockets"
 	evmrest "github.com/cosmos/ethermint/x/evm/client/rest"
 	"github.com/ethereum/go-ethereum/rpc"
@@ -22,8 +28,12 @@ import (
 )
 
 const (
-	flagUnlockKey = "unlock-key"
-	flagWebsocket = "wsport"
+	flagUnlockKey             = "unlock-key"
+	flagWebsocket             = "wsport"
+	flagEVMIndexer            = "evm-indexer"
+	flagEVMIndexerReindexFrom = "evm-indexer-reindex-from"
+
+	evmIndexerDBName = "evmindexer"
 )
 
 // RegisterRoutes creates a new server and registers the `/rpc` endpoint.
@@ -62,7 +72,12 @@ func RegisterRoutes(rs *lcd.RestServer) {
 	rpcapi = strings.ReplaceAll(rpcapi, " ", "")
 	rpcapiArr := strings.Split(rpcapi, ",")
 
-	apis := GetAPIs(rs.CliCtx, rpcapiArr, privkeys...)
+	var idx *indexer.Indexer
+	if viper.GetBool(flagEVMIndexer) {
+		idx = startIndexer(rs.CliCtx)
+	}
+
+	apis := GetAPIs(rs.CliCtx, idx, rpcapiArr, privkeys...)
 
 	// Register all the APIs exposed by the namespace services
 	// TODO: handle allowlist and private APIs
@@ -86,6 +101,27 @@ func RegisterRoutes(rs *lcd.RestServer) {
 	ws.Start()
 }
 
+// startIndexer opens the EVM indexer database on the data directory of the home
+// directory and starts indexing the blocks of the node.
+func startIndexer(clientCtx context.CLIContext) *indexer.Indexer {
+	db, err := dbm.NewGoLevelDB(evmIndexerDBName, filepath.Join(viper.GetString(flags.FlagHome), "data"))
+	if err != nil {
+		panic(err)
+	}
+
+	logger := log.NewTMLogger(log.NewSyncWriter(os.Stdout))
+	idx := indexer.New(clientCtx, db, logger)
+
+	if height := viper.GetInt64(flagEVMIndexerReindexFrom); height > 0 {
+		if err := idx.Reindex(height); err != nil {
+			panic(err)
+		}
+	}
+
+	idx.Start()
+	return idx
+}
+
 func unlockKeyFromNameAndPassphrase(accountNames []string, passphrase string) ([]ethsecp256k1.PrivKey, error) {
 	keybase, err := keys.NewKeyring(
 		sdk.KeyringServiceName(),
@@ -0,0 +1,525 @@
+package indexer
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"sort"
+	"time"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// PollInterval is the interval at which the indexer checks for new blocks once it
+// has caught up with the node.
+const PollInterval = time.Second
+
+// Indexer indexes the Ethereum transactions, receipts and logs of the committed
+// blocks into a local database, so that the JSON-RPC server can serve them
+// without querying the application state or the Tendermint tx indexer.
+//
+// The indexer follows the blocks of the node the client context is connected to.
+// Its database is only written by the indexer goroutine, while the queries can be
+// done concurrently.
+type Indexer struct {
+	db        dbm.DB
+	clientCtx clientcontext.CLIContext
+	logger    log.Logger
+	quit      chan struct{}
+}
+
+// New creates a new Indexer instance that stores the indexed data on the given
+// database.
+func New(clientCtx clientcontext.CLIContext, db dbm.DB, logger log.Logger) *Indexer {
+	return &Indexer{
+		db:        db,
+		clientCtx: clientCtx,
+		logger:    logger.With("module", "evm-indexer"),
+		quit:      make(chan struct{}),
+	}
+}
+
+// Start starts indexing the blocks committed by the node, from the block after
+// the last indexed one, on a new goroutine.
+func (idx *Indexer) Start() {
+	go idx.run()
+}
+
+// Stop stops the indexer goroutine.
+func (idx *Indexer) Stop() {
+	close(idx.quit)
+}
+
+// Reindex sets the height from which the indexer resumes, so that the blocks from
+// the given height are indexed again. It must be called before Start.
+func (idx *Indexer) Reindex(height int64) error {
+	if height < 1 {
+		return fmt.Errorf("invalid reindex height %d", height)
+	}
+
+	if last, err := idx.LastHeight(); err == nil && height > last+1 {
+		return fmt.Errorf("cannot reindex from height %d, the last indexed height is %d", height, last)
+	}
+
+	if first, err := idx.FirstHeight(); err == nil && height <= first {
+		// the whole index is rebuilt
+		if err := idx.db.Delete(KeyFirstHeight); err != nil {
+			return err
+		}
+	}
+
+	return idx.db.SetSync(KeyLastHeight, sdk.Uint64ToBigEndian(uint64(height-1)))
+}
+
+func (idx *Indexer) run() {
+	for {
+		if err := idx.catchUp(); err != nil {
+			idx.logger.Error("failed to index blocks", "error", err)
+		}
+
+		select {
+		case <-idx.quit:
+			return
+		case <-time.After(PollInterval):
+		}
+	}
+}
+
+// catchUp indexes the blocks from the last indexed height up to the latest height
+// of the node.
+func (idx *Indexer) catchUp() error {
+	status, err := idx.clientCtx.Client.Status()
+	if err != nil {
+		return err
+	}
+
+	next := status.SyncInfo.EarliestBlockHeight
+	if next < 1 {
+		next = 1
+	}
+
+	if last, err := idx.LastHeight(); err == nil {
+		if last+1 >= next {
+			next = last + 1
+		} else {
+			// the node pruned the blocks after the last indexed one, so the index
+			// restarts from the earliest block to avoid a gap
+			idx.logger.Info("restarting index from the earliest block", "last", last, "earliest", next)
+			if err := idx.db.Delete(KeyFirstHeight); err != nil {
+				return err
+			}
+		}
+	}
+
+	for height := next; height <= status.SyncInfo.LatestBlockHeight; height++ {
+		select {
+		case <-idx.quit:
+			return nil
+		default:
+		}
+
+		h := height
+		block, err := idx.clientCtx.Client.Block(&h)
+		if err != nil {
+			return err
+		}
+
+		results, err := idx.clientCtx.Client.BlockResults(&h)
+		if err != nil {
+			return err
+		}
+
+		if err := idx.IndexBlock(block.Block, results.TxsResults); err != nil {
+			return fmt.Errorf("failed to index block %d: %w", height, err)
+		}
+	}
+
+	return nil
+}
+
+// IndexBlock indexes the block hash and the Ethereum transactions of the block,
+// with their receipt and logs. The non Ethereum transactions are ignored.
+//
+// The logs are stored with their block number and hash, and their transaction
+// index and index are set to the positions of the transaction and the log within
+// the block.
+func (idx *Indexer) IndexBlock(block *tmtypes.Block, txResults []*abci.ResponseDeliverTx) error {
+	if len(txResults) != len(block.Txs) {
+		return fmt.Errorf("block has %d txs, got %d results", len(block.Txs), len(txResults))
+	}
+
+	height := block.Height
+	blockHash := common.BytesToHash(block.Hash())
+
+	batch := idx.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(BlockHashKey(height), blockHash.Bytes())
+	batch.Set(BlockHeightKey(blockHash), sdk.Uint64ToBigEndian(uint64(height)))
+
+	var (
+		cumulativeGasUsed uint64
+		logIndex          uint32
+	)
+
+	for i, txBz := range block.Txs {
+		result := txResults[i]
+		cumulativeGasUsed += uint64(result.GasUsed)
+
+		ethTx, err := rpctypes.RawTxToEthTx(idx.clientCtx, txBz)
+		if err != nil {
+			// ignore non Ethermint EVM transactions
+			continue
+		}
+
+		from, err := ethTx.VerifySig(ethTx.ChainID())
+		if err != nil {
+			idx.logger.Error("failed to verify tx signature", "hash", common.BytesToHash(txBz.Hash()), "error", err)
+			continue
+		}
+
+		record := TxRecord{
+			Hash:              common.BytesToHash(txBz.Hash()),
+			BlockHeight:       height,
+			BlockHash:         blockHash,
+			Index:             uint32(i),
+			Tx:                txBz,
+			From:              from,
+			To:                ethTx.To(),
+			Failed:            !result.IsOK(),
+			GasUsed:           uint64(result.GasUsed),
+			CumulativeGasUsed: cumulativeGasUsed,
+			Logs:              []*ethtypes.Log{},
+		}
+
+		if data, err := evmtypes.DecodeResultData(result.Data); err == nil && !record.Failed {
+			record.ContractAddress = data.ContractAddress
+			record.Bloom = data.Bloom
+			record.Logs = data.Logs
+		} else {
+			// transaction failed
+			record.Failed = true
+		}
+
+		for _, ethLog := range record.Logs {
+			ethLog.BlockNumber = uint64(height)
+			ethLog.BlockHash = blockHash
+			ethLog.TxIndex = uint(i)
+			ethLog.Index = uint(logIndex)
+
+			if err := idx.indexLog(batch, ethLog, height, logIndex); err != nil {
+				return err
+			}
+
+			logIndex++
+		}
+
+		bz, err := evmtypes.ModuleCdc.MarshalBinaryBare(record)
+		if err != nil {
+			return err
+		}
+
+		batch.Set(TxKey(record.Hash), bz)
+	}
+
+	if first, err := idx.FirstHeight(); err != nil || height < first {
+		batch.Set(KeyFirstHeight, sdk.Uint64ToBigEndian(uint64(height)))
+	}
+
+	if last, err := idx.LastHeight(); err != nil || height > last {
+		batch.Set(KeyLastHeight, sdk.Uint64ToBigEndian(uint64(height)))
+	}
+
+	return batch.WriteSync()
+}
+
+// indexLog stores the log and indexes it by its address and topics.
+func (idx *Indexer) indexLog(batch dbm.Batch, ethLog *ethtypes.Log, height int64, index uint32) error {
+	bz, err := evmtypes.ModuleCdc.MarshalBinaryBare(ethLog)
+	if err != nil {
+		return err
+	}
+
+	batch.Set(LogKey(height, index), bz)
+	batch.Set(AddressLogKey(ethLog.Address, height, index), []byte{0x01})
+
+	for i, topic := range ethLog.Topics {
+		batch.Set(TopicLogKey(i, topic, height, index), []byte{0x01})
+	}
+
+	return nil
+}
+
+// FirstHeight returns the lowest indexed block height.
+func (idx *Indexer) FirstHeight() (int64, error) {
+	return idx.getHeight(KeyFirstHeight)
+}
+
+// LastHeight returns the highest indexed block height.
+func (idx *Indexer) LastHeight() (int64, error) {
+	return idx.getHeight(KeyLastHeight)
+}
+
+// IsIndexed returns true if all the blocks of the given height range are indexed.
+func (idx *Indexer) IsIndexed(begin, end int64) bool {
+	first, err := idx.FirstHeight()
+	if err != nil {
+		return false
+	}
+
+	last, err := idx.LastHeight()
+	if err != nil {
+		return false
+	}
+
+	return first <= begin && begin <= end && end <= last
+}
+
+// BlockHeight returns the height of the block with the given hash.
+func (idx *Indexer) BlockHeight(blockHash common.Hash) (int64, error) {
+	return idx.getHeight(BlockHeightKey(blockHash))
+}
+
+// BlockHash returns the hash of the block at the given height.
+func (idx *Indexer) BlockHash(height int64) (common.Hash, error) {
+	bz, err := idx.db.Get(BlockHashKey(height))
+	if err != nil {
+		return common.Hash{}, err
+	}
+
+	if len(bz) == 0 {
+		return common.Hash{}, ErrNotIndexed
+	}
+
+	return common.BytesToHash(bz), nil
+}
+
+// GetTx returns the record of the Ethereum transaction with the given hash.
+func (idx *Indexer) GetTx(txHash common.Hash) (*TxRecord, error) {
+	bz, err := idx.db.Get(TxKey(txHash))
+	if err != nil {
+		return nil, err
+	}
+
+	if len(bz) == 0 {
+		return nil, ErrNotIndexed
+	}
+
+	var record TxRecord
+	if err := evmtypes.ModuleCdc.UnmarshalBinaryBare(bz, &record); err != nil {
+		return nil, err
+	}
+
+	return &record, nil
+}
+
+// BlockLogs returns the logs of the block at the given height, grouped by
+// transaction.
+func (idx *Indexer) BlockLogs(height int64) ([][]*ethtypes.Log, error) {
+	if !idx.IsIndexed(height, height) {
+		return nil, ErrNotIndexed
+	}
+
+	keys, err := idx.logKeys(LogKey(height, 0), LogKey(height+1, 0), false)
+	if err != nil {
+		return nil, err
+	}
+
+	logs, err := idx.getLogs(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	blockLogs := [][]*ethtypes.Log{}
+	for i, ethLog := range logs {
+		if i == 0 || ethLog.TxIndex != logs[i-1].TxIndex {
+			blockLogs = append(blockLogs, []*ethtypes.Log{})
+		}
+
+		blockLogs[len(blockLogs)-1] = append(blockLogs[len(blockLogs)-1], ethLog)
+	}
+
+	return blockLogs, nil
+}
+
+// FilterLogs returns the logs of the given block range that match the addresses
+// and topics criteria, ordered by their position on the chain. It uses the address
+// index if any address is given, or else the index of the first topic position
+// with any topic. It returns ErrNotIndexed if the range isn't fully indexed.
+func (idx *Indexer) FilterLogs(begin, end int64, addresses []common.Address, topics [][]common.Hash) ([]*ethtypes.Log, error) {
+	if !idx.IsIndexed(begin, end) {
+		return nil, ErrNotIndexed
+	}
+
+	var prefixes [][]byte
+	if len(addresses) > 0 {
+		for _, address := range addresses {
+			prefixes = append(prefixes, append(KeyPrefixAddressLog, address.Bytes()...))
+		}
+	} else {
+		for i, topicList := range topics {
+			if len(topicList) == 0 {
+				continue
+			}
+
+			for _, topic := range topicList {
+				prefixes = append(prefixes, append(append(KeyPrefixTopicLog, byte(i)), topic.Bytes()...))
+			}
+
+			break
+		}
+	}
+
+	var keys [][]byte
+	if len(prefixes) == 0 {
+		var err error
+		if keys, err = idx.logKeys(LogKey(begin, 0), LogKey(end+1, 0), false); err != nil {
+			return nil, err
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, prefix := range prefixes {
+			start := append(append([]byte{}, prefix...), logPosition(begin, 0)...)
+			stop := append(append([]byte{}, prefix...), logPosition(end+1, 0)...)
+
+			prefixKeys, err := idx.logKeys(start, stop, true)
+			if err != nil {
+				return nil, err
+			}
+
+			// skip the duplicated addresses and topics of the criteria
+			for _, key := range prefixKeys {
+				if !seen[string(key)] {
+					seen[string(key)] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		// the log keys are ordered by height and index
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+	}
+
+	logs, err := idx.getLogs(keys)
+	if err != nil {
+		return nil, err
+	}
+
+	return filterLogs(logs, addresses, topics), nil
+}
+
+// logKeys returns the log keys of the given range. If the range belongs to an
+// address or topic index, the log key is built from the suffix of the index key.
+func (idx *Indexer) logKeys(start, end []byte, isIndex bool) ([][]byte, error) {
+	iterator, err := idx.db.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if isIndex {
+			key = append(append([]byte{}, KeyPrefixLog...), key[len(key)-12:]...)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+// getLogs returns the logs of the given log keys.
+func (idx *Indexer) getLogs(keys [][]byte) ([]*ethtypes.Log, error) {
+	logs := make([]*ethtypes.Log, len(keys))
+	for i, key := range keys {
+		bz, err := idx.db.Get(key)
+		if err != nil {
+			return nil, err
+		}
+
+		var ethLog ethtypes.Log
+		if err := evmtypes.ModuleCdc.UnmarshalBinaryBare(bz, &ethLog); err != nil {
+			return nil, err
+		}
+
+		logs[i] = &ethLog
+	}
+
+	return logs, nil
+}
+
+func (idx *Indexer) getHeight(key []byte) (int64, error) {
+	bz, err := idx.db.Get(key)
+	if err != nil {
+		return 0, err
+	}
+
+	if len(bz) == 0 {
+		return 0, ErrNotIndexed
+	}
+
+	return int64(binary.BigEndian.Uint64(bz)), nil
+}
+
+// filterLogs returns the logs that match the addresses and the topics of each
+// position. An empty list of addresses or topics matches any log.
+func filterLogs(logs []*ethtypes.Log, addresses []common.Address, topics [][]common.Hash) []*ethtypes.Log {
+	filtered := []*ethtypes.Log{}
+
+Logs:
+	for _, ethLog := range logs {
+		if len(addresses) > 0 && !containsAddress(addresses, ethLog.Address) {
+			continue
+		}
+
+		if len(topics) > len(ethLog.Topics) {
+			continue
+		}
+
+		for i, topicList := range topics {
+			if len(topicList) > 0 && !containsHash(topicList, ethLog.Topics[i]) {
+				continue Logs
+			}
+		}
+
+		filtered = append(filtered, ethLog)
+	}
+
+	return filtered
+}
+
+func containsAddress(addresses []common.Address, address common.Address) bool {
+	for _, addr := range addresses {
+		if addr == address {
+			return true
+		}
+	}
+
+	return false
+}
+
+func containsHash(hashes []common.Hash, hash common.Hash) bool {
+	for _, h := range hashes {
+		if h == hash {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,193 @@
+package indexer_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/libs/log"
+	tmtypes "github.com/tendermint/tendermint/types"
+	dbm "github.com/tendermint/tm-db"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/codec"
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/rpc/indexer"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var (
+	contractA = common.BytesToAddress([]byte("contractA"))
+	contractB = common.BytesToAddress([]byte("contractB"))
+	topic1    = common.BytesToHash([]byte("topic1"))
+	topic2    = common.BytesToHash([]byte("topic2"))
+)
+
+func newTestIndexer(t *testing.T) (*indexer.Indexer, clientcontext.CLIContext) {
+	clientCtx := clientcontext.CLIContext{Codec: codec.MakeCodec(app.ModuleBasics)}
+	return indexer.New(clientCtx, dbm.NewMemDB(), log.NewNopLogger()), clientCtx
+}
+
+// makeBlock returns a block with the given txs. The block must have a last commit
+// and a validators hash for its hash to be computed.
+func makeBlock(height int64, txs []tmtypes.Tx) *tmtypes.Block {
+	block := tmtypes.MakeBlock(height, txs, &tmtypes.Commit{}, nil)
+	block.ValidatorsHash = []byte("validators")
+	return block
+}
+
+func ethTxResult(t *testing.T, clientCtx clientcontext.CLIContext, nonce uint64, logs ...*ethtypes.Log) (tmtypes.Tx, *abci.ResponseDeliverTx) {
+	priv, err := ethsecp256k1.GenerateKey()
+	require.NoError(t, err)
+
+	msg := evmtypes.NewMsgEthereumTx(nonce, &contractA, big.NewInt(0), 100000, big.NewInt(1), nil)
+	require.NoError(t, msg.Sign(big.NewInt(3), priv.ToECDSA()))
+
+	txBz, err := clientCtx.Codec.MarshalBinaryLengthPrefixed(msg)
+	require.NoError(t, err)
+
+	data, err := evmtypes.EncodeResultData(evmtypes.ResultData{Logs: logs})
+	require.NoError(t, err)
+
+	return txBz, &abci.ResponseDeliverTx{Data: data, GasUsed: 21000}
+}
+
+func TestIndexBlock(t *testing.T) {
+	idx, clientCtx := newTestIndexer(t)
+
+	tx1, res1 := ethTxResult(t, clientCtx, 0,
+		&ethtypes.Log{Address: contractA, Topics: []common.Hash{topic1, topic2}},
+		&ethtypes.Log{Address: contractA, Topics: []common.Hash{topic2}},
+	)
+	tx2, res2 := ethTxResult(t, clientCtx, 1)
+	res2.Code = 1
+	tx3, res3 := ethTxResult(t, clientCtx, 2, &ethtypes.Log{Address: contractB, Topics: []common.Hash{topic1}})
+
+	// non Ethereum txs are ignored but count for the cumulative gas
+	otherTx := tmtypes.Tx("other tx")
+	otherRes := &abci.ResponseDeliverTx{GasUsed: 1000}
+
+	block := makeBlock(1, []tmtypes.Tx{tx1, otherTx, tx2, tx3})
+	require.Error(t, idx.IndexBlock(block, []*abci.ResponseDeliverTx{res1}))
+	require.NoError(t, idx.IndexBlock(block, []*abci.ResponseDeliverTx{res1, otherRes, res2, res3}))
+
+	block2 := makeBlock(2, nil)
+	require.NoError(t, idx.IndexBlock(block2, nil))
+
+	blockHash := common.BytesToHash(block.Hash())
+	height, err := idx.BlockHeight(blockHash)
+	require.NoError(t, err)
+	require.Equal(t, int64(1), height)
+
+	hash, err := idx.BlockHash(2)
+	require.NoError(t, err)
+	require.Equal(t, common.BytesToHash(block2.Hash()), hash)
+
+	_, err = idx.BlockHash(3)
+	require.Equal(t, indexer.ErrNotIndexed, err)
+
+	record, err := idx.GetTx(common.BytesToHash(tx3.Hash()))
+	require.NoError(t, err)
+	require.Equal(t, int64(1), record.BlockHeight)
+	require.Equal(t, blockHash, record.BlockHash)
+	require.Equal(t, uint32(3), record.Index)
+	require.Equal(t, uint64(21000*3+1000), record.CumulativeGasUsed)
+	require.Equal(t, contractA, *record.To)
+	require.False(t, record.Failed)
+	require.Len(t, record.Logs, 1)
+	require.Equal(t, uint(2), record.Logs[0].Index)
+	require.Equal(t, uint(3), record.Logs[0].TxIndex)
+	require.Equal(t, uint64(1), record.Logs[0].BlockNumber)
+
+	record, err = idx.GetTx(common.BytesToHash(tx2.Hash()))
+	require.NoError(t, err)
+	require.True(t, record.Failed)
+	require.Empty(t, record.Logs)
+
+	_, err = idx.GetTx(common.BytesToHash(otherTx.Hash()))
+	require.Equal(t, indexer.ErrNotIndexed, err)
+
+	blockLogs, err := idx.BlockLogs(1)
+	require.NoError(t, err)
+	require.Len(t, blockLogs, 2)
+	require.Len(t, blockLogs[0], 2)
+	require.Len(t, blockLogs[1], 1)
+
+	blockLogs, err = idx.BlockLogs(2)
+	require.NoError(t, err)
+	require.Empty(t, blockLogs)
+}
+
+func TestFilterLogs(t *testing.T) {
+	idx, clientCtx := newTestIndexer(t)
+
+	tx1, res1 := ethTxResult(t, clientCtx, 0,
+		&ethtypes.Log{Address: contractA, Topics: []common.Hash{topic1, topic2}},
+		&ethtypes.Log{Address: contractA, Topics: []common.Hash{topic2}},
+	)
+	tx2, res2 := ethTxResult(t, clientCtx, 1, &ethtypes.Log{Address: contractB, Topics: []common.Hash{topic1}})
+
+	require.NoError(t, idx.IndexBlock(makeBlock(1, []tmtypes.Tx{tx1}), []*abci.ResponseDeliverTx{res1}))
+	require.NoError(t, idx.IndexBlock(makeBlock(2, []tmtypes.Tx{tx2}), []*abci.ResponseDeliverTx{res2}))
+
+	testCases := []struct {
+		name       string
+		begin, end int64
+		addresses  []common.Address
+		topics     [][]common.Hash
+		expLogs    []uint64 // block numbers of the expected logs
+	}{
+		{"all logs", 1, 2, nil, nil, []uint64{1, 1, 2}},
+		{"single block", 2, 2, nil, nil, []uint64{2}},
+		{"address", 1, 2, []common.Address{contractA}, nil, []uint64{1, 1}},
+		{"duplicated addresses", 1, 2, []common.Address{contractB, contractA, contractB}, nil, []uint64{1, 1, 2}},
+		{"address and topic", 1, 2, []common.Address{contractA}, [][]common.Hash{{topic1}}, []uint64{1}},
+		{"first topic", 1, 2, nil, [][]common.Hash{{topic1}}, []uint64{1, 2}},
+		{"second topic", 1, 2, nil, [][]common.Hash{{}, {topic2}}, []uint64{1}},
+		{"any topic", 1, 2, nil, [][]common.Hash{{topic1, topic2}}, []uint64{1, 1, 2}},
+		{"no match", 1, 1, []common.Address{contractB}, nil, []uint64{}},
+	}
+
+	for _, tc := range testCases {
+		logs, err := idx.FilterLogs(tc.begin, tc.end, tc.addresses, tc.topics)
+		require.NoError(t, err, tc.name)
+
+		blockNumbers := []uint64{}
+		for _, ethLog := range logs {
+			blockNumbers = append(blockNumbers, ethLog.BlockNumber)
+		}
+		require.Equal(t, tc.expLogs, blockNumbers, tc.name)
+	}
+
+	_, err := idx.FilterLogs(1, 3, nil, nil)
+	require.Equal(t, indexer.ErrNotIndexed, err)
+}
+
+func TestReindex(t *testing.T) {
+	idx, _ := newTestIndexer(t)
+
+	require.NoError(t, idx.IndexBlock(makeBlock(1, nil), nil))
+	require.NoError(t, idx.IndexBlock(makeBlock(2, nil), nil))
+	require.True(t, idx.IsIndexed(1, 2))
+
+	require.Error(t, idx.Reindex(0))
+	require.Error(t, idx.Reindex(4))
+
+	require.NoError(t, idx.Reindex(2))
+	require.True(t, idx.IsIndexed(1, 1))
+	require.False(t, idx.IsIndexed(1, 2))
+
+	require.NoError(t, idx.Reindex(1))
+	_, err := idx.FirstHeight()
+	require.Equal(t, indexer.ErrNotIndexed, err)
+
+	require.NoError(t, idx.IndexBlock(makeBlock(1, nil), nil))
+	require.True(t, idx.IsIndexed(1, 1))
+}
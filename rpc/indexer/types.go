@@ -0,0 +1,91 @@
+package indexer
+
+import (
+	"encoding/binary"
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+// ErrNotIndexed is returned when the requested data hasn't been indexed, either
+// because it doesn't exist or because the indexer hasn't processed its block yet.
+var ErrNotIndexed = errors.New("not indexed")
+
+// Database key prefixes
+var (
+	KeyPrefixBlockHash   = []byte{0x01}
+	KeyPrefixBlockHeight = []byte{0x02}
+	KeyPrefixTx          = []byte{0x03}
+	KeyPrefixLog         = []byte{0x04}
+	KeyPrefixAddressLog  = []byte{0x05}
+	KeyPrefixTopicLog    = []byte{0x06}
+
+	KeyFirstHeight = []byte{0x07}
+	KeyLastHeight  = []byte{0x08}
+)
+
+// TxRecord defines the indexed data of an Ethereum transaction, which contains
+// the fields of the transaction receipt.
+type TxRecord struct {
+	Hash              common.Hash     `json:"hash"`
+	BlockHeight       int64           `json:"block_height"`
+	BlockHash         common.Hash     `json:"block_hash"`
+	Index             uint32          `json:"index"`
+	Tx                []byte          `json:"tx"`
+	From              common.Address  `json:"from"`
+	To                *common.Address `json:"to"`
+	Failed            bool            `json:"failed"`
+	GasUsed           uint64          `json:"gas_used"`
+	CumulativeGasUsed uint64          `json:"cumulative_gas_used"`
+	ContractAddress   common.Address  `json:"contract_address"`
+	Bloom             ethtypes.Bloom  `json:"bloom"`
+	Logs              []*ethtypes.Log `json:"logs"`
+}
+
+// BlockHashKey returns the key of the block hash of the given height.
+func BlockHashKey(height int64) []byte {
+	return append(KeyPrefixBlockHash, sdk.Uint64ToBigEndian(uint64(height))...)
+}
+
+// BlockHeightKey returns the key of the height of the given block hash.
+func BlockHeightKey(hash common.Hash) []byte {
+	return append(KeyPrefixBlockHeight, hash.Bytes()...)
+}
+
+// TxKey returns the key of the transaction record of the given tx hash.
+func TxKey(hash common.Hash) []byte {
+	return append(KeyPrefixTx, hash.Bytes()...)
+}
+
+// LogKey returns the key of the log with the given index within the block. The
+// key is composed of the prefix, the big endian height and the big endian log
+// index, which allows to iterate the logs of a block range in order.
+func LogKey(height int64, index uint32) []byte {
+	return append(KeyPrefixLog, logPosition(height, index)...)
+}
+
+// AddressLogKey returns the key that indexes a log by its contract address.
+func AddressLogKey(address common.Address, height int64, index uint32) []byte {
+	key := append(KeyPrefixAddressLog, address.Bytes()...)
+	return append(key, logPosition(height, index)...)
+}
+
+// TopicLogKey returns the key that indexes a log by one of its topics and the
+// position of the topic.
+func TopicLogKey(position int, topic common.Hash, height int64, index uint32) []byte {
+	key := append(KeyPrefixTopicLog, byte(position))
+	key = append(key, topic.Bytes()...)
+	return append(key, logPosition(height, index)...)
+}
+
+// logPosition returns the position of a log on the chain, which is the suffix of
+// all the log keys.
+func logPosition(height int64, index uint32) []byte {
+	bz := make([]byte, 12)
+	binary.BigEndian.PutUint64(bz, uint64(height))
+	binary.BigEndian.PutUint32(bz[8:], index)
+	return bz
+}
@@ -0,0 +1,116 @@
+package debug
+
+import (
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/rpc/backend"
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// PublicDebugAPI is the debug_ prefixed set of APIs of the Geth JSON-RPC spec
+// that are served from the committed application state.
+type PublicDebugAPI struct {
+	clientCtx clientcontext.CLIContext
+	logger    log.Logger
+	backend   backend.Backend
+}
+
+// NewAPI creates an instance of the public Debug API.
+func NewAPI(clientCtx clientcontext.CLIContext, backend backend.Backend) *PublicDebugAPI {
+	return &PublicDebugAPI{
+		clientCtx: clientCtx,
+		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc", "namespace", "debug"),
+		backend:   backend,
+	}
+}
+
+// StorageRangeAt returns at most maxResult storage slots of an account, starting
+// from the slot stored under keyStart, and the key of the next slot. The state is
+// only committed at the end of a block, so the transaction index must either be
+// zero, for the state of the parent block, or the number of Ethereum transactions
+// of the block, for the state at the end of the block.
+func (api *PublicDebugAPI) StorageRangeAt(
+	blockHash common.Hash, txIndex int, address common.Address, keyStart hexutil.Bytes, maxResult int,
+) (rpctypes.StorageRangeResult, error) {
+	api.logger.Debug("debug_storageRangeAt", "block hash", blockHash, "tx index", txIndex, "address", address, "key start", keyStart, "max result", maxResult)
+
+	if maxResult < 0 {
+		return rpctypes.StorageRangeResult{}, errors.New("invalid max result")
+	}
+
+	height, err := api.stateHeight(blockHash, txIndex)
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+
+	clientCtx := api.clientCtx.WithHeight(height)
+	res, _, err := clientCtx.Query(fmt.Sprintf(
+		"custom/%s/%s/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryStorageRange,
+		address.Hex(), common.BytesToHash(keyStart).Hex(), maxResult,
+	))
+	if err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+
+	var out evmtypes.QueryResStorageRange
+	if err := api.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return rpctypes.StorageRangeResult{}, err
+	}
+
+	result := rpctypes.StorageRangeResult{
+		Storage: make(map[common.Hash]rpctypes.StorageEntry, len(out.Storage)),
+	}
+
+	for _, state := range out.Storage {
+		result.Storage[common.HexToHash(state.Key)] = rpctypes.StorageEntry{Value: common.HexToHash(state.Value)}
+	}
+
+	if out.NextKey != "" {
+		nextKey := common.HexToHash(out.NextKey)
+		result.NextKey = &nextKey
+	}
+
+	return result, nil
+}
+
+// stateHeight returns the height of the committed state before the transaction
+// at the given index of a block.
+func (api *PublicDebugAPI) stateHeight(blockHash common.Hash, txIndex int) (int64, error) {
+	height, err := api.backend.BlockHeightByHash(blockHash)
+	if err != nil {
+		return 0, err
+	}
+
+	resBlock, err := api.clientCtx.Client.Block(&height)
+	if err != nil {
+		return 0, err
+	}
+
+	txHashes, _, err := rpctypes.EthTransactionsFromTendermint(api.clientCtx, resBlock.Block.Txs)
+	if err != nil {
+		return 0, err
+	}
+
+	switch {
+	case txIndex == len(txHashes):
+		return height, nil
+	case txIndex == 0 && height > 1:
+		return height - 1, nil
+	case txIndex == 0:
+		return 0, errors.New("the state before the first block isn't available")
+	default:
+		return 0, fmt.Errorf(
+			"transaction index %d isn't 0 or %d, the state within a block isn't available", txIndex, len(txHashes),
+		)
+	}
+}
@@ -3,6 +3,7 @@ package eth
 import (
 	"bytes"
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
@@ -15,6 +16,7 @@ import (
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	"github.com/cosmos/ethermint/crypto/hd"
 	"github.com/cosmos/ethermint/rpc/backend"
+	"github.com/cosmos/ethermint/rpc/indexer"
 	rpctypes "github.com/cosmos/ethermint/rpc/types"
 	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/utils"
@@ -304,17 +306,12 @@ func (api *PublicEthereumAPI) GetTransactionCount(address common.Address, blockN
 // GetBlockTransactionCountByHash returns the number of transactions in the block identified by hash.
 func (api *PublicEthereumAPI) GetBlockTransactionCountByHash(hash common.Hash) *hexutil.Uint {
 	api.logger.Debug("eth_getBlockTransactionCountByHash", "hash", hash)
-	res, _, err := api.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, hash.Hex()))
+	height, err := api.backend.BlockHeightByHash(hash)
 	if err != nil {
 		return nil
 	}
 
-	var out evmtypes.QueryResBlockNumber
-	if err := api.clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
-		return nil
-	}
-
-	resBlock, err := api.clientCtx.Client.Block(&out.Number)
+	resBlock, err := api.clientCtx.Client.Block(&height)
 	if err != nil {
 		return nil
 	}
@@ -514,31 +511,104 @@ func (api *PublicEthereumAPI) SendRawTransaction(data hexutil.Bytes) (common.Has
 // Call performs a raw contract call.
 func (api *PublicEthereumAPI) Call(args rpctypes.CallArgs, blockNr rpctypes.BlockNumber, _ *map[common.Address]rpctypes.Account) (hexutil.Bytes, error) {
 	api.logger.Debug("eth_call", "args", args, "block number", blockNr)
-	simRes, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit))
+	res, err := api.doCall(args, blockNr, big.NewInt(ethermint.DefaultRPCGasLimit))
 	if err != nil {
 		return []byte{}, err
 	}
 
-	data, err := evmtypes.DecodeResultData(simRes.Result.Data)
+	return (hexutil.Bytes)(res.Result.Ret), nil
+}
+
+// DoCall performs a simulated call operation through the EVM call query. It returns
+// the result data and the gas used on the operation or an error if fails. The calls
+// on the pending block are executed after the pending transactions.
+func (api *PublicEthereumAPI) doCall(
+	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int,
+) (*evmtypes.QueryResCall, error) {
+	params := api.callParams(args, globalGasCap)
+	if blockNum == rpctypes.PendingBlockNumber {
+		return api.doPendingCall(params)
+	}
+
+	res, err := api.queryEVM(evmtypes.QueryCall, params, blockNum)
 	if err != nil {
-		return []byte{}, err
+		return nil, err
 	}
 
-	return (hexutil.Bytes)(data.Ret), nil
+	var out evmtypes.QueryResCall
+	if err := json.Unmarshal(res, &out); err != nil {
+		return nil, err
+	}
+
+	return &out, nil
 }
 
-// DoCall performs a simulated call operation through the evmtypes. It returns the
-// estimated gas used on the operation or an error if fails.
-func (api *PublicEthereumAPI) doCall(
-	args rpctypes.CallArgs, blockNum rpctypes.BlockNumber, globalGasCap *big.Int,
-) (*sdk.SimulationResponse, error) {
+// doPendingCall executes the call on the latest state after the pending transactions
+// of the mempool, through the EVM call bundle query. The pending transactions that
+// don't fit in the bundle along with the call are ignored.
+func (api *PublicEthereumAPI) doPendingCall(params evmtypes.QueryCallParams) (*evmtypes.QueryResCall, error) {
+	pendingTxs, err := api.PendingTransactions()
+	if err != nil {
+		return nil, err
+	}
 
-	clientCtx := api.clientCtx
-	// pass the given block height to the context if the height is not pending or latest
-	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
-		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	if len(pendingTxs) > evmtypes.MaxCallBundleSize-1 {
+		pendingTxs = pendingTxs[:evmtypes.MaxCallBundleSize-1]
+	}
+
+	bundle := evmtypes.QueryCallBundleParams{
+		Calls: make([]evmtypes.QueryCallParams, 0, len(pendingTxs)+1),
+	}
+
+	for _, pendingTx := range pendingTxs {
+		nonce := uint64(pendingTx.Nonce)
+		call := evmtypes.QueryCallParams{
+			From:  pendingTx.From,
+			To:    pendingTx.To,
+			Nonce: &nonce,
+			Gas:   uint64(pendingTx.Gas),
+			Data:  pendingTx.Input,
+		}
+		if pendingTx.GasPrice != nil {
+			call.GasPrice = sdk.NewIntFromBigInt(pendingTx.GasPrice.ToInt())
+		}
+		if pendingTx.Value != nil {
+			call.Value = sdk.NewIntFromBigInt(pendingTx.Value.ToInt())
+		}
+
+		bundle.Calls = append(bundle.Calls, call)
 	}
+	bundle.Calls = append(bundle.Calls, params)
 
+	res, err := api.queryEVM(evmtypes.QueryCallBundle, bundle, rpctypes.LatestBlockNumber)
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResCallBundle
+	if err := json.Unmarshal(res, &out); err != nil {
+		return nil, err
+	}
+
+	if len(out.Results) != len(bundle.Calls) {
+		return nil, fmt.Errorf("expected %d call results, got %d", len(bundle.Calls), len(out.Results))
+	}
+
+	callRes := out.Results[len(out.Results)-1]
+	if callRes.Error != "" {
+		return nil, errors.New(callRes.Error)
+	}
+
+	resultData := evmtypes.ResultData{Ret: callRes.Ret, Logs: callRes.Logs}
+	if callRes.ContractAddress != nil {
+		resultData.ContractAddress = *callRes.ContractAddress
+	}
+
+	return &evmtypes.QueryResCall{Result: resultData, GasUsed: callRes.GasUsed, GasLimit: params.Gas}, nil
+}
+
+// callParams returns the EVM call query params of the call arguments.
+func (api *PublicEthereumAPI) callParams(args rpctypes.CallArgs, globalGasCap *big.Int) evmtypes.QueryCallParams {
 	// Set sender address or use a default if none specified
 	var addr common.Address
 
@@ -551,8 +621,6 @@ func (api *PublicEthereumAPI) doCall(
 		addr = *args.From
 	}
 
-	nonce, _ := api.accountNonce(api.clientCtx, addr, true)
-
 	// Set default gas & gas price if none were set
 	// Change this to uint64(math.MaxUint64 / 2) if gas cap can be configured
 	gas := uint64(ethermint.DefaultRPCGasLimit)
@@ -564,8 +632,9 @@ func (api *PublicEthereumAPI) doCall(
 		gas = globalGasCap.Uint64()
 	}
 
-	// Set gas price using default or parameter if passed in
-	gasPrice := new(big.Int).SetUint64(ethermint.DefaultGasPrice)
+	// Set gas price if passed in. As on Ethereum, the sender doesn't need a balance
+	// to pay for the gas of a call without gas price.
+	gasPrice := new(big.Int)
 	if args.GasPrice != nil {
 		gasPrice = args.GasPrice.ToInt()
 	}
@@ -582,71 +651,151 @@ func (api *PublicEthereumAPI) doCall(
 		data = []byte(*args.Data)
 	}
 
-	// Set destination address for call
-	var toAddr sdk.AccAddress
-	if args.To != nil {
-		toAddr = sdk.AccAddress(args.To.Bytes())
+	return evmtypes.QueryCallParams{
+		From:     addr,
+		To:       args.To,
+		Gas:      gas,
+		GasPrice: sdk.NewIntFromBigInt(gasPrice),
+		Value:    sdk.NewIntFromBigInt(value),
+		Data:     data,
+	}
+}
+
+// rawTxCallParams returns the EVM call query params and the hash of a signed raw
+// transaction.
+func (api *PublicEthereumAPI) rawTxCallParams(data hexutil.Bytes) (evmtypes.QueryCallParams, common.Hash, error) {
+	tx := new(evmtypes.MsgEthereumTx)
+	if err := rlp.DecodeBytes(data, tx); err != nil {
+		return evmtypes.QueryCallParams{}, common.Hash{}, err
 	}
 
-	var msgs []sdk.Msg
-	// Create new call message
-	msg := evmtypes.NewMsgEthermint(nonce, &toAddr, sdk.NewIntFromBigInt(value), gas,
-		sdk.NewIntFromBigInt(gasPrice), data, sdk.AccAddress(addr.Bytes()))
-	msgs = append(msgs, msg)
+	sender, err := tx.VerifySig(api.chainIDEpoch)
+	if err != nil {
+		return evmtypes.QueryCallParams{}, common.Hash{}, err
+	}
 
-	// convert the pending transactions into ethermint msgs
-	if blockNum == rpctypes.PendingBlockNumber {
-		pendingMsgs, err := api.pendingMsgs()
-		if err != nil {
-			return nil, err
-		}
-		msgs = append(msgs, pendingMsgs...)
+	txEncoder := authclient.GetTxEncoder(api.clientCtx.Codec)
+	txBytes, err := txEncoder(tx)
+	if err != nil {
+		return evmtypes.QueryCallParams{}, common.Hash{}, err
 	}
 
-	// Generate tx to be used to simulate (signature isn't needed)
-	var stdSig authtypes.StdSignature
-	stdSigs := []authtypes.StdSignature{stdSig}
+	nonce := tx.Data.AccountNonce
+	params := evmtypes.QueryCallParams{
+		From:     sender,
+		To:       tx.To(),
+		Nonce:    &nonce,
+		Gas:      tx.Data.GasLimit,
+		GasPrice: tx.Data.Price,
+		Value:    tx.Data.Amount,
+		Data:     tx.Data.Payload,
+	}
 
-	tx := authtypes.NewStdTx(msgs, authtypes.StdFee{}, stdSigs, "")
-	if err := tx.ValidateBasic(); err != nil {
+	return params, common.BytesToHash(tmtypes.Tx(txBytes).Hash()), nil
+}
+
+// queryEVM runs the given EVM query route with the JSON encoded params. The calls
+// are executed directly by the EVM module on the state of the given block, without
+// being wrapped in a transaction.
+func (api *PublicEthereumAPI) queryEVM(route string, params interface{}, blockNum rpctypes.BlockNumber) ([]byte, error) {
+	clientCtx := api.clientCtx
+	// pass the given block height to the context if the height is not pending or latest
+	if !(blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber) {
+		clientCtx = api.clientCtx.WithHeight(blockNum.Int64())
+	}
+
+	bz, err := json.Marshal(params)
+	if err != nil {
 		return nil, err
 	}
 
-	txEncoder := authclient.GetTxEncoder(clientCtx.Codec)
-	txBytes, err := txEncoder(tx)
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, route), bz)
 	if err != nil {
 		return nil, err
 	}
 
-	// Transaction simulation through query
-	res, _, err := clientCtx.QueryWithData("app/simulate", txBytes)
+	return res, nil
+}
+
+// CallBundle executes the given calls or signed raw transactions in order on the
+// state of the given block. Each call sees the state changes of the previous ones,
+// which are discarded once the bundle is executed. A failed call doesn't abort the
+// bundle: its error and revert reason are returned in its result. The call
+// arguments of a raw transaction are ignored. The bundle size and its total gas
+// are capped by the EVM module.
+func (api *PublicEthereumAPI) CallBundle(calls []rpctypes.BundleCallArgs, blockNr rpctypes.BlockNumber) ([]rpctypes.BundleCallResult, error) {
+	api.logger.Debug("eth_callBundle", "calls", len(calls), "block number", blockNr)
+
+	params := evmtypes.QueryCallBundleParams{
+		Calls: make([]evmtypes.QueryCallParams, len(calls)),
+	}
+	txHashes := make([]*common.Hash, len(calls))
+
+	for i, call := range calls {
+		if call.RawTx == nil {
+			params.Calls[i] = api.callParams(call.CallArgs, big.NewInt(ethermint.DefaultRPCGasLimit))
+			continue
+		}
+
+		callParams, txHash, err := api.rawTxCallParams(*call.RawTx)
+		if err != nil {
+			return nil, fmt.Errorf("invalid raw transaction at index %d: %w", i, err)
+		}
+
+		params.Calls[i] = callParams
+		txHashes[i] = &txHash
+	}
+
+	res, err := api.queryEVM(evmtypes.QueryCallBundle, params, blockNr)
 	if err != nil {
 		return nil, err
 	}
 
-	var simResponse sdk.SimulationResponse
-	if err := clientCtx.Codec.UnmarshalBinaryBare(res, &simResponse); err != nil {
+	var out evmtypes.QueryResCallBundle
+	if err := json.Unmarshal(res, &out); err != nil {
 		return nil, err
 	}
 
-	return &simResponse, nil
+	results := make([]rpctypes.BundleCallResult, len(out.Results))
+	for i, callRes := range out.Results {
+		// the logs of the raw transactions are returned with the transaction hash
+		if txHashes[i] != nil {
+			for _, log := range callRes.Logs {
+				log.TxHash = *txHashes[i]
+			}
+		}
+
+		results[i] = rpctypes.BundleCallResult{
+			TxHash:          txHashes[i],
+			ReturnData:      callRes.Ret,
+			ContractAddress: callRes.ContractAddress,
+			Logs:            callRes.Logs,
+			GasUsed:         hexutil.Uint64(callRes.GasUsed),
+			Error:           callRes.Error,
+			Revert:          callRes.Revert,
+			RevertReason:    evmtypes.UnpackRevertReason(callRes.Revert),
+		}
+	}
+
+	return results, nil
 }
 
-// EstimateGas returns an estimate of gas usage for the given smart contract call.
-// It adds 1,000 gas to the returned value instead of using the gas adjustment
-// param from the SDK.
+// EstimateGas returns the lowest gas limit for which the given smart contract
+// call succeeds.
 func (api *PublicEthereumAPI) EstimateGas(args rpctypes.CallArgs) (hexutil.Uint64, error) {
 	api.logger.Debug("eth_estimateGas", "args", args)
-	simResponse, err := api.doCall(args, 0, big.NewInt(ethermint.DefaultRPCGasLimit))
+	params := api.callParams(args, big.NewInt(ethermint.DefaultRPCGasLimit))
+	res, err := api.queryEVM(evmtypes.QueryEstimateGas, params, rpctypes.LatestBlockNumber)
 	if err != nil {
 		return 0, err
 	}
 
-	// TODO: change 1000 buffer for more accurate buffer (eg: SDK's gasAdjusted)
-	estimatedGas := simResponse.GasInfo.GasUsed
-	gas := estimatedGas + 1000
+	var out evmtypes.QueryResEstimateGas
+	if err := json.Unmarshal(res, &out); err != nil {
+		return 0, err
+	}
 
-	return hexutil.Uint64(gas), nil
+	return hexutil.Uint64(out.Gas), nil
 }
 
 // GetBlockByHash returns the block identified by hash.
@@ -708,6 +857,15 @@ func (api *PublicEthereumAPI) GetBlockByNumber(blockNum rpctypes.BlockNumber, fu
 func (api *PublicEthereumAPI) GetTransactionByHash(hash common.Hash) (*rpctypes.Transaction, error) {
 	api.logger.Debug("eth_getTransactionByHash", "hash", hash)
 
+	if record, err := api.backend.GetTxRecord(hash); err == nil {
+		ethTx, err := rpctypes.RawTxToEthTx(api.clientCtx, record.Tx)
+		if err != nil {
+			return nil, err
+		}
+
+		return rpctypes.NewTransaction(ethTx, record.Hash, record.BlockHash, uint64(record.BlockHeight), uint64(record.Index))
+	}
+
 	tx, err := api.clientCtx.Client.Tx(hash.Bytes(), false)
 	if err != nil {
 		// check if the tx is on the mempool
@@ -748,15 +906,12 @@ func (api *PublicEthereumAPI) GetTransactionByHash(hash common.Hash) (*rpctypes.
 // GetTransactionByBlockHashAndIndex returns the transaction identified by block hash and index.
 func (api *PublicEthereumAPI) GetTransactionByBlockHashAndIndex(hash common.Hash, idx hexutil.Uint) (*rpctypes.Transaction, error) {
 	api.logger.Debug("eth_getTransactionByBlockHashAndIndex", "hash", hash, "index", idx)
-	res, _, err := api.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryHashToHeight, hash.Hex()))
+	height, err := api.backend.BlockHeightByHash(hash)
 	if err != nil {
 		return nil, err
 	}
 
-	var out evmtypes.QueryResBlockNumber
-	api.clientCtx.Codec.MustUnmarshalJSON(res, &out)
-
-	resBlock, err := api.clientCtx.Client.Block(&out.Number)
+	resBlock, err := api.clientCtx.Client.Block(&height)
 	if err != nil {
 		return nil, err
 	}
@@ -827,6 +982,11 @@ func (api *PublicEthereumAPI) getTransactionByBlockAndIndex(block *tmtypes.Block
 // GetTransactionReceipt returns the transaction receipt identified by hash.
 func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (map[string]interface{}, error) {
 	api.logger.Debug("eth_getTransactionReceipt", "hash", hash)
+
+	if record, err := api.backend.GetTxRecord(hash); err == nil {
+		return formatReceipt(record), nil
+	}
+
 	tx, err := api.clientCtx.Client.Tx(hash.Bytes(), false)
 	if err != nil {
 		// Return nil for transaction when not found
@@ -857,50 +1017,68 @@ func (api *PublicEthereumAPI) GetTransactionReceipt(hash common.Hash) (map[strin
 		cumulativeGasUsed += rpctypes.GetBlockCumulativeGas(api.clientCtx.Codec, block.Block, int(tx.Index))
 	}
 
-	// Set status codes based on tx result
-	var status hexutil.Uint
-	if tx.TxResult.IsOK() {
-		status = hexutil.Uint(1)
-	} else {
-		status = hexutil.Uint(0)
+	record := &indexer.TxRecord{
+		Hash:              hash,
+		BlockHeight:       tx.Height,
+		BlockHash:         blockHash,
+		Index:             tx.Index,
+		Tx:                tx.Tx,
+		From:              from,
+		To:                ethTx.To(),
+		Failed:            !tx.TxResult.IsOK(),
+		GasUsed:           uint64(tx.TxResult.GasUsed),
+		CumulativeGasUsed: cumulativeGasUsed,
 	}
 
-	txData := tx.TxResult.GetData()
-
-	data, err := evmtypes.DecodeResultData(txData)
+	data, err := evmtypes.DecodeResultData(tx.TxResult.GetData())
 	if err != nil {
-		status = 0 // transaction failed
+		record.Failed = true // transaction failed
+	}
+
+	record.ContractAddress = data.ContractAddress
+	record.Bloom = data.Bloom
+	record.Logs = data.Logs
+
+	return formatReceipt(record), nil
+}
+
+// formatReceipt returns the JSON-RPC representation of the receipt of a
+// transaction record.
+func formatReceipt(record *indexer.TxRecord) map[string]interface{} {
+	// Set status codes based on tx result
+	status := hexutil.Uint(1)
+	if record.Failed {
+		status = hexutil.Uint(0)
 	}
 
-	if len(data.Logs) == 0 {
-		data.Logs = []*ethtypes.Log{}
+	logs := record.Logs
+	if len(logs) == 0 {
+		logs = []*ethtypes.Log{}
 	}
 
-	receipt := map[string]interface{}{
+	return map[string]interface{}{
 		// Consensus fields: These fields are defined by the Yellow Paper
 		"status":            status,
-		"cumulativeGasUsed": hexutil.Uint64(cumulativeGasUsed),
-		"logsBloom":         data.Bloom,
-		"logs":              data.Logs,
+		"cumulativeGasUsed": hexutil.Uint64(record.CumulativeGasUsed),
+		"logsBloom":         record.Bloom,
+		"logs":              logs,
 
 		// Implementation fields: These fields are added by geth when processing a transaction.
 		// They are stored in the chain database.
-		"transactionHash": hash,
-		"contractAddress": data.ContractAddress,
-		"gasUsed":         hexutil.Uint64(tx.TxResult.GasUsed),
+		"transactionHash": record.Hash,
+		"contractAddress": record.ContractAddress,
+		"gasUsed":         hexutil.Uint64(record.GasUsed),
 
 		// Inclusion information: These fields provide information about the inclusion of the
 		// transaction corresponding to this receipt.
-		"blockHash":        blockHash,
-		"blockNumber":      hexutil.Uint64(tx.Height),
-		"transactionIndex": hexutil.Uint64(tx.Index),
+		"blockHash":        record.BlockHash,
+		"blockNumber":      hexutil.Uint64(record.BlockHeight),
+		"transactionIndex": hexutil.Uint64(record.Index),
 
 		// sender and receiver (contract or EOA) addresses
-		"from": from,
-		"to":   ethTx.To(),
+		"from": record.From,
+		"to":   record.To,
 	}
-
-	return receipt, nil
 }
 
 // PendingTransactions returns the transactions that are in the transaction pool
@@ -1059,45 +1237,6 @@ func (api *PublicEthereumAPI) generateFromArgs(args rpctypes.SendTxArgs) (*evmty
 	return &msg, nil
 }
 
-// pendingMsgs constructs an array of sdk.Msg. This method will check pending transactions and convert
-// those transactions into ethermint messages.
-func (api *PublicEthereumAPI) pendingMsgs() ([]sdk.Msg, error) {
-	// nolint: prealloc
-	var msgs []sdk.Msg
-
-	pendingTxs, err := api.PendingTransactions()
-	if err != nil {
-		return nil, err
-	}
-
-	for _, pendingTx := range pendingTxs {
-		// NOTE: we have to construct the EVM transaction instead of just casting from the tendermint
-		// transactions because PendingTransactions only checks for MsgEthereumTx messages.
-
-		pendingTo := sdk.AccAddress(pendingTx.To.Bytes())
-		pendingFrom := sdk.AccAddress(pendingTx.From.Bytes())
-		pendingGas, err := hexutil.DecodeUint64(pendingTx.Gas.String())
-		if err != nil {
-			return nil, err
-		}
-
-		pendingValue := pendingTx.Value.ToInt()
-		pendingGasPrice := new(big.Int).SetUint64(ethermint.DefaultGasPrice)
-		if pendingTx.GasPrice != nil {
-			pendingGasPrice = pendingTx.GasPrice.ToInt()
-		}
-
-		pendingData := pendingTx.Input
-		nonce, _ := api.accountNonce(api.clientCtx, pendingTx.From, true)
-
-		msg := evmtypes.NewMsgEthermint(nonce, &pendingTo, sdk.NewIntFromBigInt(pendingValue), pendingGas,
-			sdk.NewIntFromBigInt(pendingGasPrice), pendingData, pendingFrom)
-
-		msgs = append(msgs, msg)
-	}
-	return msgs, nil
-}
-
 // accountNonce returns looks up the transaction nonce count for a given address. If the pending boolean
 // is set to true, it will add to the counter all the uncommitted EVM transactions sent from the address.
 // NOTE: The function returns no error if the account doesn't exist.
@@ -29,6 +29,7 @@ type Backend interface {
 
 	GetTransactionLogs(txHash common.Hash) ([]*ethtypes.Log, error)
 	BloomStatus() (uint64, uint64)
+	GetFilteredLogs(begin, end int64, addresses []common.Address, topics [][]common.Hash) ([]*ethtypes.Log, error)
 }
 
 // consider a filter inactive if it has not been polled for within deadline
@@ -108,6 +108,14 @@ func (f *Filter) Logs(_ context.Context) ([]*ethtypes.Log, error) {
 		f.criteria.ToBlock = big.NewInt(head)
 	}
 
+	// use the indexed logs if the whole range is indexed
+	indexedLogs, err := f.backend.GetFilteredLogs(
+		f.criteria.FromBlock.Int64(), f.criteria.ToBlock.Int64(), f.criteria.Addresses, f.criteria.Topics,
+	)
+	if err == nil {
+		return indexedLogs, nil
+	}
+
 	for i := f.criteria.FromBlock.Int64(); i <= f.criteria.ToBlock.Int64(); i++ {
 		block, err := f.backend.GetBlockByNumber(rpctypes.BlockNumber(i), true)
 		if err != nil {
@@ -0,0 +1,320 @@
+package trace
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	clientcontext "github.com/cosmos/cosmos-sdk/client/context"
+
+	"github.com/cosmos/ethermint/rpc/backend"
+	rpctypes "github.com/cosmos/ethermint/rpc/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethvm "github.com/ethereum/go-ethereum/core/vm"
+)
+
+// PublicTraceAPI is the trace_ prefixed set of APIs of the Parity/OpenEthereum
+// JSON-RPC spec. The traces are the call frames recorded by the node the client
+// context is connected to, which must enable the evm.tracing option.
+type PublicTraceAPI struct {
+	clientCtx clientcontext.CLIContext
+	logger    log.Logger
+	backend   backend.Backend
+}
+
+// NewAPI creates an instance of the public Trace API.
+func NewAPI(clientCtx clientcontext.CLIContext, backend backend.Backend) *PublicTraceAPI {
+	return &PublicTraceAPI{
+		clientCtx: clientCtx,
+		logger:    log.NewTMLogger(log.NewSyncWriter(os.Stdout)).With("module", "json-rpc", "namespace", "trace"),
+		backend:   backend,
+	}
+}
+
+// Transaction returns the traces of the transaction with the given hash, or nil if
+// the transaction hasn't been traced.
+func (api *PublicTraceAPI) Transaction(hash common.Hash) ([]rpctypes.Trace, error) {
+	api.logger.Debug("trace_transaction", "hash", hash)
+
+	res, _, err := api.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTraceTx, hash.Hex()))
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResTraceTx
+	if err := json.Unmarshal(res, &out); err != nil {
+		return nil, err
+	}
+
+	if out.Trace == nil {
+		return nil, nil
+	}
+
+	blocks := newBlockCache(api.clientCtx)
+
+	traces := []rpctypes.Trace{}
+	for _, frame := range out.Trace.Frames {
+		trace, err := blocks.newTrace(frame, out.Trace.TxHash, out.Trace.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// Block returns the traces of the transactions of the given block.
+func (api *PublicTraceAPI) Block(blockNum rpctypes.BlockNumber) ([]rpctypes.Trace, error) {
+	api.logger.Debug("trace_block", "number", blockNum)
+
+	height, err := api.height(blockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := api.clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryTraceBlock, height))
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResTraceBlock
+	if err := json.Unmarshal(res, &out); err != nil {
+		return nil, err
+	}
+
+	blocks := newBlockCache(api.clientCtx)
+
+	traces := []rpctypes.Trace{}
+	for _, txTrace := range out.Traces {
+		for _, frame := range txTrace.Frames {
+			trace, err := blocks.newTrace(frame, txTrace.TxHash, txTrace.Height)
+			if err != nil {
+				return nil, err
+			}
+
+			traces = append(traces, trace)
+		}
+	}
+
+	return traces, nil
+}
+
+// Filter returns the traces of the given block range that match the from and to
+// addresses, ordered by their position on the chain. The block range defaults to
+// the whole chain. The after and count arguments paginate the matching traces.
+func (api *PublicTraceAPI) Filter(args rpctypes.TraceFilterArgs) ([]rpctypes.Trace, error) {
+	api.logger.Debug("trace_filter", "args", args)
+
+	filter := evmtypes.TraceFilter{
+		FromBlock:     1,
+		FromAddresses: args.FromAddress,
+		ToAddresses:   args.ToAddress,
+	}
+
+	var err error
+	if args.FromBlock != nil {
+		if filter.FromBlock, err = api.height(*args.FromBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	if filter.ToBlock, err = api.height(rpctypes.LatestBlockNumber); err != nil {
+		return nil, err
+	}
+
+	if args.ToBlock != nil {
+		if filter.ToBlock, err = api.height(*args.ToBlock); err != nil {
+			return nil, err
+		}
+	}
+
+	if args.After != nil {
+		filter.After = *args.After
+	}
+
+	if args.Count != nil {
+		filter.Count = *args.Count
+	}
+
+	bz, err := json.Marshal(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	res, _, err := api.clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s", evmtypes.ModuleName, evmtypes.QueryTraceFilter), bz)
+	if err != nil {
+		return nil, err
+	}
+
+	var out evmtypes.QueryResTraceFilter
+	if err := json.Unmarshal(res, &out); err != nil {
+		return nil, err
+	}
+
+	blocks := newBlockCache(api.clientCtx)
+
+	traces := []rpctypes.Trace{}
+	for _, frame := range out.Frames {
+		trace, err := blocks.newTrace(frame.CallFrame, frame.TxHash, frame.Height)
+		if err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// height returns the height of the given block number, where the pending block
+// is the latest one.
+func (api *PublicTraceAPI) height(blockNum rpctypes.BlockNumber) (int64, error) {
+	if blockNum == rpctypes.PendingBlockNumber || blockNum == rpctypes.LatestBlockNumber {
+		return api.backend.LatestBlockNumber()
+	}
+
+	if blockNum < 0 {
+		return 0, errors.New("invalid block number")
+	}
+
+	return blockNum.Int64(), nil
+}
+
+// tracedBlock is the block data of the traces of a height.
+type tracedBlock struct {
+	hash      common.Hash
+	positions map[common.Hash]uint64
+}
+
+// blockCache fetches the hash and the transaction positions of the blocks of the
+// traces once per height.
+type blockCache struct {
+	clientCtx clientcontext.CLIContext
+	blocks    map[int64]tracedBlock
+}
+
+func newBlockCache(clientCtx clientcontext.CLIContext) *blockCache {
+	return &blockCache{
+		clientCtx: clientCtx,
+		blocks:    make(map[int64]tracedBlock),
+	}
+}
+
+func (bc *blockCache) get(height int64) (tracedBlock, error) {
+	if block, ok := bc.blocks[height]; ok {
+		return block, nil
+	}
+
+	h := height
+	resBlock, err := bc.clientCtx.Client.Block(&h)
+	if err != nil {
+		return tracedBlock{}, err
+	}
+
+	block := tracedBlock{
+		hash:      common.BytesToHash(resBlock.Block.Hash()),
+		positions: make(map[common.Hash]uint64, len(resBlock.Block.Txs)),
+	}
+
+	for i, tx := range resBlock.Block.Txs {
+		block.positions[common.BytesToHash(tx.Hash())] = uint64(i)
+	}
+
+	bc.blocks[height] = block
+	return block, nil
+}
+
+// newTrace converts a call frame to the Parity trace format. The transaction
+// position is nil for the EVM executions of the modules, which aren't block
+// transactions.
+func (bc *blockCache) newTrace(frame evmtypes.CallFrame, txHash common.Hash, height int64) (rpctypes.Trace, error) {
+	block, err := bc.get(height)
+	if err != nil {
+		return rpctypes.Trace{}, err
+	}
+
+	trace := rpctypes.Trace{
+		BlockHash:       block.hash,
+		BlockNumber:     uint64(height),
+		Subtraces:       frame.Subtraces,
+		TraceAddress:    frame.TraceAddress,
+		TransactionHash: txHash,
+		Error:           traceError(frame.Error),
+	}
+
+	if position, ok := block.positions[txHash]; ok {
+		trace.TransactionPosition = &position
+	}
+
+	from, to := frame.From, frame.To
+	gas, gasUsed := hexutil.Uint64(frame.Gas), hexutil.Uint64(frame.GasUsed)
+	input, output := hexutil.Bytes(frame.Input), hexutil.Bytes(frame.Output)
+
+	value := (*hexutil.Big)(frame.Value)
+	if value == nil {
+		value = new(hexutil.Big)
+	}
+
+	switch frame.Type {
+	case evmtypes.CallTypeCreate, evmtypes.CallTypeCreate2:
+		trace.Type = "create"
+		trace.Action = rpctypes.TraceAction{
+			From:  &from,
+			Gas:   &gas,
+			Init:  &input,
+			Value: value,
+		}
+
+		if frame.Error == "" {
+			trace.Result = &rpctypes.TraceResult{
+				GasUsed: gasUsed,
+				Address: &to,
+				Code:    &output,
+			}
+		}
+	case evmtypes.CallTypeSelfDestruct:
+		trace.Type = "suicide"
+		trace.Action = rpctypes.TraceAction{
+			Address:       &from,
+			Balance:       value,
+			RefundAddress: &to,
+		}
+	default:
+		trace.Type = "call"
+		trace.Action = rpctypes.TraceAction{
+			CallType: strings.ToLower(frame.Type),
+			From:     &from,
+			To:       &to,
+			Gas:      &gas,
+			Input:    &input,
+			Value:    value,
+		}
+
+		if frame.Error == "" {
+			trace.Result = &rpctypes.TraceResult{
+				GasUsed: gasUsed,
+				Output:  &output,
+			}
+		}
+	}
+
+	return trace, nil
+}
+
+// traceError returns the Parity error of a reverted frame, or else the EVM error.
+func traceError(err string) string {
+	if strings.HasPrefix(err, ethvm.ErrExecutionReverted.Error()) {
+		return "Reverted"
+	}
+
+	return err
+}
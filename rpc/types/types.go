@@ -3,6 +3,7 @@ package types
 import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // Copied the Account and StorageResult types since they are registered under an
@@ -70,6 +71,95 @@ type CallArgs struct {
 	Data     *hexutil.Bytes  `json:"data"`
 }
 
+// BundleCallArgs represents a call of a call bundle. The call is either given by
+// its arguments or as a signed raw transaction, in which case the other arguments
+// must be empty.
+type BundleCallArgs struct {
+	CallArgs
+	RawTx *hexutil.Bytes `json:"rawTx"`
+}
+
+// BundleCallResult is the result of a call of a call bundle. The error, and the
+// revert data and reason of a reverted call, are set if the call failed.
+type BundleCallResult struct {
+	TxHash          *common.Hash    `json:"txHash,omitempty"`
+	ReturnData      hexutil.Bytes   `json:"returnData"`
+	ContractAddress *common.Address `json:"contractAddress,omitempty"`
+	Logs            []*ethtypes.Log `json:"logs"`
+	GasUsed         hexutil.Uint64  `json:"gasUsed"`
+	Error           string          `json:"error,omitempty"`
+	Revert          hexutil.Bytes   `json:"revert,omitempty"`
+	RevertReason    string          `json:"revertReason,omitempty"`
+}
+
+// TraceFilterArgs represents the arguments of the trace_filter query. A trace
+// matches if its sender is one of the from addresses and its recipient one of the
+// to addresses, where a missing list matches any address.
+type TraceFilterArgs struct {
+	FromBlock   *BlockNumber     `json:"fromBlock"`
+	ToBlock     *BlockNumber     `json:"toBlock"`
+	FromAddress []common.Address `json:"fromAddress"`
+	ToAddress   []common.Address `json:"toAddress"`
+	After       *uint64          `json:"after"`
+	Count       *uint64          `json:"count"`
+}
+
+// Trace is a call, contract creation or self-destruct of a transaction, in the
+// flat format of the Parity/OpenEthereum trace_ namespace. The result is nil if
+// the trace failed or is a self-destruct.
+type Trace struct {
+	Action              TraceAction  `json:"action"`
+	BlockHash           common.Hash  `json:"blockHash"`
+	BlockNumber         uint64       `json:"blockNumber"`
+	Result              *TraceResult `json:"result"`
+	Error               string       `json:"error,omitempty"`
+	Subtraces           int          `json:"subtraces"`
+	TraceAddress        []int        `json:"traceAddress"`
+	TransactionHash     common.Hash  `json:"transactionHash"`
+	TransactionPosition *uint64      `json:"transactionPosition"`
+	Type                string       `json:"type"`
+}
+
+// TraceAction is the action of a Trace. The calls set the call type, from, to,
+// gas, input and value fields, the creations the from, gas, init and value
+// fields, and the self-destructs the address, balance and refund address fields.
+type TraceAction struct {
+	CallType      string          `json:"callType,omitempty"`
+	From          *common.Address `json:"from,omitempty"`
+	To            *common.Address `json:"to,omitempty"`
+	Gas           *hexutil.Uint64 `json:"gas,omitempty"`
+	Input         *hexutil.Bytes  `json:"input,omitempty"`
+	Init          *hexutil.Bytes  `json:"init,omitempty"`
+	Value         *hexutil.Big    `json:"value,omitempty"`
+	Address       *common.Address `json:"address,omitempty"`
+	Balance       *hexutil.Big    `json:"balance,omitempty"`
+	RefundAddress *common.Address `json:"refundAddress,omitempty"`
+}
+
+// TraceResult is the result of a successful call or contract creation. The calls
+// set the output field, and the creations the address and code fields.
+type TraceResult struct {
+	GasUsed hexutil.Uint64  `json:"gasUsed"`
+	Output  *hexutil.Bytes  `json:"output,omitempty"`
+	Address *common.Address `json:"address,omitempty"`
+	Code    *hexutil.Bytes  `json:"code,omitempty"`
+}
+
+// StorageRangeResult is the result of the debug_storageRangeAt query. The storage
+// slots are keyed by the hash they're stored under, and the next key is nil if the
+// range reaches the end of the account storage.
+type StorageRangeResult struct {
+	Storage map[common.Hash]StorageEntry `json:"storage"`
+	NextKey *common.Hash                 `json:"nextKey"`
+}
+
+// StorageEntry is a storage slot of a StorageRangeResult. The slot keys aren't
+// stored by the EVM module, so the key is always nil.
+type StorageEntry struct {
+	Key   *common.Hash `json:"key"`
+	Value common.Hash  `json:"value"`
+}
+
 // Account indicates the overriding fields of account during the execution of
 // a message call.
 // NOTE: state and stateDiff can't be specified at the same time. If state is
@@ -80,17 +80,75 @@ func EthBlockFromTendermint(clientCtx clientcontext.CLIContext, block *tmtypes.B
 		return nil, err
 	}
 
-	res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, block.Height))
+	bloom, err := BlockBloom(clientCtx, block.Height)
 	if err != nil {
 		return nil, err
 	}
 
-	var bloomRes evmtypes.QueryBloomFilter
-	clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
+	return FormatBlock(block.Header, block.Size(), block.Hash(), gasLimit, gasUsed, transactions, bloom), nil
+}
 
-	bloom := bloomRes.Bloom
+// BlockBloom returns the bloom filter of the block at the given height. If the
+// bloom was pruned from the EVM module state, it's computed from the results of
+// the block transactions stored by Tendermint.
+func BlockBloom(clientCtx clientcontext.CLIContext, height int64) (ethtypes.Bloom, error) {
+	res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s/%d", evmtypes.ModuleName, evmtypes.QueryBloom, height))
+	if err == nil {
+		var bloomRes evmtypes.QueryBloomFilter
+		clientCtx.Codec.MustUnmarshalJSON(res, &bloomRes)
+		return bloomRes.Bloom, nil
+	}
 
-	return FormatBlock(block.Header, block.Size(), block.Hash(), gasLimit, gasUsed, transactions, bloom), nil
+	blockResults, err := clientCtx.Client.BlockResults(&height)
+	if err != nil {
+		return ethtypes.Bloom{}, err
+	}
+
+	bloomInt := big.NewInt(0)
+	for _, txResult := range blockResults.TxsResults {
+		// ignore the failed and non Ethermint EVM transactions
+		resultData, err := evmtypes.DecodeResultData(txResult.Data)
+		if err != nil {
+			continue
+		}
+
+		bloomInt.Or(bloomInt, resultData.Bloom.Big())
+	}
+
+	return ethtypes.BytesToBloom(bloomInt.Bytes()), nil
+}
+
+// TransactionLogs returns the logs of a transaction. If the logs were pruned from
+// the EVM module state, they are decoded from the transaction result indexed by
+// Tendermint. If no logs are found for the tx hash, the error is nil.
+func TransactionLogs(clientCtx clientcontext.CLIContext, txHash common.Hash) ([]*ethtypes.Log, error) {
+	res, _, err := clientCtx.QueryWithData(fmt.Sprintf("custom/%s/%s/%s", evmtypes.ModuleName, evmtypes.QueryTransactionLogs, txHash.String()), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	out := new(evmtypes.QueryETHLogs)
+	if err := clientCtx.Codec.UnmarshalJSON(res, &out); err != nil {
+		return nil, err
+	}
+
+	if len(out.Logs) != 0 {
+		return out.Logs, nil
+	}
+
+	tx, err := clientCtx.Client.Tx(txHash.Bytes(), false)
+	if err != nil {
+		// the tx is not indexed
+		return out.Logs, nil
+	}
+
+	resultData, err := evmtypes.DecodeResultData(tx.TxResult.Data)
+	if err != nil {
+		// the tx failed or it isn't an Ethermint EVM transaction
+		return out.Logs, nil
+	}
+
+	return resultData.Logs, nil
 }
 
 // EthHeaderFromTendermint is an util function that returns an Ethereum Header
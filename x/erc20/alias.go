@@ -0,0 +1,26 @@
+package erc20
+
+import (
+	"github.com/cosmos/ethermint/x/erc20/keeper"
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+// nolint
+const (
+	ModuleName        = types.ModuleName
+	StoreKey          = types.StoreKey
+	RouterKey         = types.RouterKey
+	QuerierRoute      = types.QuerierRoute
+	DefaultParamspace = types.DefaultParamspace
+)
+
+// nolint
+var (
+	NewKeeper = keeper.NewKeeper
+)
+
+// nolint
+type (
+	Keeper       = keeper.Keeper
+	GenesisState = types.GenesisState
+)
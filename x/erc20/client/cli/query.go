@@ -0,0 +1,95 @@
+package cli
+
+import (
+	"fmt"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+// GetQueryCmd defines erc20 module queries through the cli
+func GetQueryCmd(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	erc20QueryCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "Querying commands for the erc20 module",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+	erc20QueryCmd.AddCommand(flags.GetCommands(
+		GetCmdQueryParams(queryRoute, cdc),
+		GetCmdQueryTokenPairs(queryRoute, cdc),
+		GetCmdQueryTokenPair(queryRoute, cdc),
+	)...)
+	return erc20QueryCmd
+}
+
+// GetCmdQueryParams queries the erc20 module parameters
+func GetCmdQueryParams(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "params",
+		Short: "Gets the erc20 module parameters",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryParams))
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.Params
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryTokenPairs queries all the registered token pairs
+func GetCmdQueryTokenPairs(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "token-pairs",
+		Short: "Gets the registered token pairs",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s", queryRoute, types.QueryTokenPairs))
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out []types.TokenPair
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
+
+// GetCmdQueryTokenPair queries the token pair of an ERC20 contract address or a
+// coin denomination
+func GetCmdQueryTokenPair(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "token-pair [token]",
+		Short: "Gets the token pair of an ERC20 contract hex address or a coin denomination",
+		Args:  cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			res, _, err := clientCtx.Query(fmt.Sprintf("custom/%s/%s/%s", queryRoute, types.QueryTokenPair, args[0]))
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.TokenPair
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+}
@@ -0,0 +1,291 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client"
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/client/flags"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// GetTxCmd returns the transaction commands for the erc20 module
+func GetTxCmd(cdc *codec.Codec) *cobra.Command {
+	erc20TxCmd := &cobra.Command{
+		Use:                        types.ModuleName,
+		Short:                      "erc20 transactions subcommands",
+		DisableFlagParsing:         true,
+		SuggestionsMinimumDistance: 2,
+		RunE:                       client.ValidateCmd,
+	}
+
+	erc20TxCmd.AddCommand(flags.PostCommands(
+		GetCmdConvertCoin(cdc),
+		GetCmdConvertERC20(cdc),
+	)...)
+
+	return erc20TxCmd
+}
+
+// GetCmdConvertCoin returns the command to convert a native Cosmos coin into its
+// ERC20 token representation.
+func GetCmdConvertCoin(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert-coin [coin] [receiver_hex]",
+		Args:  cobra.RangeArgs(1, 2),
+		Short: "Convert a Cosmos coin to ERC20 tokens. When the receiver isn't specified, the tokens are sent to the sender",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Convert a Cosmos coin to ERC20 tokens of the registered token pair.
+
+Example:
+$ %s tx erc20 convert-coin 100acoin 0x7cB61D4117AE31a12E393a1Cfa3BaC666481D02E --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			coin, err := sdk.ParseCoin(args[0])
+			if err != nil {
+				return err
+			}
+
+			sender := cliCtx.GetFromAddress()
+			receiver := ethcmn.BytesToAddress(sender.Bytes())
+			if len(args) == 2 {
+				if !ethcmn.IsHexAddress(args[1]) {
+					return fmt.Errorf("invalid receiver hex address %s", args[1])
+				}
+				receiver = ethcmn.HexToAddress(args[1])
+			}
+
+			msg := types.NewMsgConvertCoin(coin, receiver, sender)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdConvertERC20 returns the command to convert ERC20 tokens into their
+// Cosmos coin representation.
+func GetCmdConvertERC20(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "convert-erc20 [contract_address] [amount] [receiver]",
+		Args:  cobra.RangeArgs(2, 3),
+		Short: "Convert ERC20 tokens to a Cosmos coin. When the receiver isn't specified, the coins are sent to the sender",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Convert ERC20 tokens of the registered token pair to a Cosmos coin.
+
+Example:
+$ %s tx erc20 convert-erc20 0x80b5a32E4F032B2a058b4F29EC95EEfEEB87aDcd 100 --from mykey
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			if !ethcmn.IsHexAddress(args[0]) {
+				return fmt.Errorf("invalid contract hex address %s", args[0])
+			}
+
+			amount, ok := sdk.NewIntFromString(args[1])
+			if !ok {
+				return fmt.Errorf("invalid amount %s", args[1])
+			}
+
+			sender := cliCtx.GetFromAddress()
+			receiver := sender
+			if len(args) == 3 {
+				var err error
+				receiver, err = sdk.AccAddressFromBech32(args[2])
+				if err != nil {
+					return err
+				}
+			}
+
+			msg := types.NewMsgConvertERC20(ethcmn.HexToAddress(args[0]), amount, receiver, sender)
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitRegisterCoinProposal implements the command to submit a
+// register-coin proposal
+func GetCmdSubmitRegisterCoinProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-coin [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to register a Cosmos coin and deploy its ERC20 contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to register a Cosmos coin token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal register-coin <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Register ATOM",
+  "description": "Deploy an ERC20 contract for the uatom coin",
+  "denom": "uatom",
+  "name": "Cosmos Hub Atom",
+  "symbol": "ATOM",
+  "decimals": 6,
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal RegisterCoinProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewRegisterCoinProposal(
+				proposal.Title, proposal.Description, proposal.Denom, proposal.Name, proposal.Symbol, proposal.Decimals,
+			)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+// GetCmdSubmitRegisterERC20Proposal implements the command to submit a
+// register-erc20 proposal
+func GetCmdSubmitRegisterERC20Proposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-erc20 [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to register an ERC20 contract deployed on the EVM",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to register an ERC20 token pair along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal register-erc20 <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Register USDC",
+  "description": "Convert the USDC ERC20 tokens to the erc20usdc coin",
+  "erc20_address": "0x80b5a32E4F032B2a058b4F29EC95EEfEEB87aDcd",
+  "denom": "erc20usdc",
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal RegisterERC20ProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			if !ethcmn.IsHexAddress(proposal.ERC20Address) {
+				return fmt.Errorf("invalid contract hex address %s", proposal.ERC20Address)
+			}
+
+			content := types.NewRegisterERC20Proposal(
+				proposal.Title, proposal.Description, ethcmn.HexToAddress(proposal.ERC20Address), proposal.Denom,
+			)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+// GetCmdSubmitToggleTokenConversionProposal implements the command to submit a
+// toggle-token-conversion proposal
+func GetCmdSubmitToggleTokenConversionProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "toggle-token-conversion [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to enable or disable the conversions of a token pair",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to toggle the conversions of a token pair along with an initial deposit.
+The token can be either the ERC20 contract hex address or the coin denomination of the pair.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal toggle-token-conversion <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Disable ATOM conversions",
+  "description": "Disable the conversions of the uatom token pair",
+  "token": "uatom",
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			var proposal ToggleTokenConversionProposalJSON
+			if err := parseProposalJSON(cdc, args[0], &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewToggleTokenConversionProposal(proposal.Title, proposal.Description, proposal.Token)
+			return submitProposal(cmd, cdc, content, proposal.Deposit)
+		},
+	}
+}
+
+func submitProposal(cmd *cobra.Command, cdc *codec.Codec, content gov.Content, deposit sdk.Coins) error {
+	inBuf := bufio.NewReader(cmd.InOrStdin())
+	txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+	cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, cliCtx.GetFromAddress())
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+
+	return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+}
@@ -0,0 +1,49 @@
+package cli
+
+import (
+	"io/ioutil"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+type (
+	// RegisterCoinProposalJSON defines a RegisterCoinProposal with a deposit
+	RegisterCoinProposalJSON struct {
+		Title       string    `json:"title" yaml:"title"`
+		Description string    `json:"description" yaml:"description"`
+		Denom       string    `json:"denom" yaml:"denom"`
+		Name        string    `json:"name" yaml:"name"`
+		Symbol      string    `json:"symbol" yaml:"symbol"`
+		Decimals    uint8     `json:"decimals" yaml:"decimals"`
+		Deposit     sdk.Coins `json:"deposit" yaml:"deposit"`
+	}
+
+	// RegisterERC20ProposalJSON defines a RegisterERC20Proposal with a deposit
+	RegisterERC20ProposalJSON struct {
+		Title        string    `json:"title" yaml:"title"`
+		Description  string    `json:"description" yaml:"description"`
+		ERC20Address string    `json:"erc20_address" yaml:"erc20_address"`
+		Denom        string    `json:"denom" yaml:"denom"`
+		Deposit      sdk.Coins `json:"deposit" yaml:"deposit"`
+	}
+
+	// ToggleTokenConversionProposalJSON defines a ToggleTokenConversionProposal
+	// with a deposit
+	ToggleTokenConversionProposalJSON struct {
+		Title       string    `json:"title" yaml:"title"`
+		Description string    `json:"description" yaml:"description"`
+		Token       string    `json:"token" yaml:"token"`
+		Deposit     sdk.Coins `json:"deposit" yaml:"deposit"`
+	}
+)
+
+// parseProposalJSON reads and parses a proposal JSON file into the given pointer.
+func parseProposalJSON(cdc *codec.Codec, proposalFile string, proposal interface{}) error {
+	contents, err := ioutil.ReadFile(proposalFile)
+	if err != nil {
+		return err
+	}
+
+	return cdc.UnmarshalJSON(contents, proposal)
+}
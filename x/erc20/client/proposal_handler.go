@@ -0,0 +1,15 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+
+	"github.com/cosmos/ethermint/x/erc20/client/cli"
+	"github.com/cosmos/ethermint/x/erc20/client/rest"
+)
+
+// erc20 module governance proposal handlers
+var (
+	RegisterCoinProposalHandler          = govclient.NewProposalHandler(cli.GetCmdSubmitRegisterCoinProposal, rest.RegisterCoinProposalRESTHandler)
+	RegisterERC20ProposalHandler         = govclient.NewProposalHandler(cli.GetCmdSubmitRegisterERC20Proposal, rest.RegisterERC20ProposalRESTHandler)
+	ToggleTokenConversionProposalHandler = govclient.NewProposalHandler(cli.GetCmdSubmitToggleTokenConversionProposal, rest.ToggleTokenConversionRESTHandler)
+)
@@ -0,0 +1,147 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/gorilla/mux"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// RegisterRoutes registers the erc20 module REST query routes
+func RegisterRoutes(cliCtx context.CLIContext, r *mux.Router) {
+	r.HandleFunc(
+		fmt.Sprintf("/%s/parameters", types.ModuleName),
+		queryHandlerFn(cliCtx, types.QueryParams),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/%s/token_pairs", types.ModuleName),
+		queryHandlerFn(cliCtx, types.QueryTokenPairs),
+	).Methods("GET")
+
+	r.HandleFunc(
+		fmt.Sprintf("/%s/token_pairs/{token}", types.ModuleName),
+		tokenPairHandlerFn(cliCtx),
+	).Methods("GET")
+}
+
+// RegisterCoinProposalRESTHandler returns the register coin proposal REST handler
+func RegisterCoinProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "register_coin",
+		Handler:  postRegisterCoinProposalHandlerFn(cliCtx),
+	}
+}
+
+// RegisterERC20ProposalRESTHandler returns the register ERC20 proposal REST handler
+func RegisterERC20ProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "register_erc20",
+		Handler:  postRegisterERC20ProposalHandlerFn(cliCtx),
+	}
+}
+
+// ToggleTokenConversionRESTHandler returns the toggle token conversion proposal
+// REST handler
+func ToggleTokenConversionRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "toggle_token_conversion",
+		Handler:  postToggleTokenConversionProposalHandlerFn(cliCtx),
+	}
+}
+
+func queryHandlerFn(cliCtx context.CLIContext, path string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		cliCtx, ok := rest.ParseQueryHeightOrReturnBadRequest(w, cliCtx, r)
+		if !ok {
+			return
+		}
+
+		route := fmt.Sprintf("custom/%s/%s", types.QuerierRoute, path)
+		res, height, err := cliCtx.QueryWithData(route, nil)
+		if err != nil {
+			rest.WriteErrorResponse(w, http.StatusInternalServerError, err.Error())
+			return
+		}
+
+		cliCtx = cliCtx.WithHeight(height)
+		rest.PostProcessResponse(w, cliCtx, res)
+	}
+}
+
+func tokenPairHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		token := mux.Vars(r)["token"]
+		queryHandlerFn(cliCtx, fmt.Sprintf("%s/%s", types.QueryTokenPair, token))(w, r)
+	}
+}
+
+func postRegisterCoinProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RegisterCoinProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewRegisterCoinProposal(req.Title, req.Description, req.Denom, req.Name, req.Symbol, req.Decimals)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Proposer, req.Deposit)
+	}
+}
+
+func postRegisterERC20ProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RegisterERC20ProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		if !ethcmn.IsHexAddress(req.ERC20Address) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid contract hex address %s", req.ERC20Address))
+			return
+		}
+
+		content := types.NewRegisterERC20Proposal(req.Title, req.Description, ethcmn.HexToAddress(req.ERC20Address), req.Denom)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Proposer, req.Deposit)
+	}
+}
+
+func postToggleTokenConversionProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ToggleTokenConversionProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		content := types.NewToggleTokenConversionProposal(req.Title, req.Description, req.Token)
+		writeProposalResponse(w, cliCtx, req.BaseReq, content, req.Proposer, req.Deposit)
+	}
+}
+
+func writeProposalResponse(
+	w http.ResponseWriter, cliCtx context.CLIContext, baseReq rest.BaseReq,
+	content gov.Content, proposer sdk.AccAddress, deposit sdk.Coins,
+) {
+	baseReq = baseReq.Sanitize()
+	if !baseReq.ValidateBasic(w) {
+		return
+	}
+
+	msg := gov.NewMsgSubmitProposal(content, deposit, proposer)
+	if err := msg.ValidateBasic(); err != nil {
+		rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	utils.WriteGenerateStdTxResponse(w, cliCtx, baseReq, []sdk.Msg{msg})
+}
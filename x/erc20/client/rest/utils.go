@@ -0,0 +1,46 @@
+package rest
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+)
+
+type (
+	// RegisterCoinProposalReq defines a register coin proposal request body.
+	RegisterCoinProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Denom       string         `json:"denom" yaml:"denom"`
+		Name        string         `json:"name" yaml:"name"`
+		Symbol      string         `json:"symbol" yaml:"symbol"`
+		Decimals    uint8          `json:"decimals" yaml:"decimals"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// RegisterERC20ProposalReq defines a register ERC20 proposal request body.
+	RegisterERC20ProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title        string         `json:"title" yaml:"title"`
+		Description  string         `json:"description" yaml:"description"`
+		ERC20Address string         `json:"erc20_address" yaml:"erc20_address"`
+		Denom        string         `json:"denom" yaml:"denom"`
+		Proposer     sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit      sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+
+	// ToggleTokenConversionProposalReq defines a toggle token conversion proposal
+	// request body.
+	ToggleTokenConversionProposalReq struct {
+		BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+		Title       string         `json:"title" yaml:"title"`
+		Description string         `json:"description" yaml:"description"`
+		Token       string         `json:"token" yaml:"token"`
+		Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+		Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+	}
+)
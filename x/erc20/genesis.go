@@ -0,0 +1,43 @@
+package erc20
+
+import (
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// InitGenesis initializes genesis state based on exported genesis. The ERC20
+// contracts of the token pairs must be part of the EVM genesis state, with the
+// exception of the native Cosmos coin pairs that don't have a contract yet. The
+// module deploys them using the denomination as token name and symbol.
+func InitGenesis(ctx sdk.Context, k Keeper, data GenesisState) []abci.ValidatorUpdate {
+	k.SetParams(ctx, data.Params)
+
+	for _, pair := range data.TokenPairs {
+		contract := pair.GetERC20Contract()
+
+		if pair.IsNativeCoin() && contract == types.ERC20ContractAddress(pair.Denom) && !k.IsContract(ctx, contract) {
+			if _, err := k.DeployERC20(ctx, pair.Denom, pair.Denom, strings.ToUpper(pair.Denom), 0); err != nil {
+				panic(err)
+			}
+		}
+
+		if !k.IsContract(ctx, contract) {
+			panic(fmt.Errorf("ERC20 contract %s of token pair %s not found", pair.ERC20Address, pair.Denom))
+		}
+
+		k.SetTokenPair(ctx, pair)
+	}
+
+	return []abci.ValidatorUpdate{}
+}
+
+// ExportGenesis exports genesis state of the erc20 module
+func ExportGenesis(ctx sdk.Context, k Keeper) GenesisState {
+	return types.NewGenesisState(k.GetParams(ctx), k.GetAllTokenPairs(ctx))
+}
@@ -0,0 +1,159 @@
+package erc20
+
+import (
+	"strconv"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+// NewHandler returns a handler for the erc20 module messages. The EVM state changes
+// of a failed message are rolled back together with the Cosmos ones.
+func NewHandler(k Keeper) sdk.Handler {
+	return func(ctx sdk.Context, msg sdk.Msg) (result *sdk.Result, err error) {
+		ctx = ctx.WithEventManager(sdk.NewEventManager())
+
+		err = k.ExecuteAtomic(ctx, func(ctx sdk.Context) error {
+			switch msg := msg.(type) {
+			case types.MsgConvertCoin:
+				result, err = handleMsgConvertCoin(ctx, k, msg)
+			case types.MsgConvertERC20:
+				result, err = handleMsgConvertERC20(ctx, k, msg)
+			default:
+				err = sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
+			}
+
+			return err
+		})
+
+		if err != nil {
+			return nil, err
+		}
+
+		return result, nil
+	}
+}
+
+func handleMsgConvertCoin(ctx sdk.Context, k Keeper, msg types.MsgConvertCoin) (*sdk.Result, error) {
+	pair, err := k.ConvertCoin(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeConvertCoin,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyReceiver, msg.Receiver),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Coin.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyCosmosCoin, msg.Coin.Denom),
+			sdk.NewAttribute(types.AttributeKeyERC20Token, pair.ERC20Address),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+func handleMsgConvertERC20(ctx sdk.Context, k Keeper, msg types.MsgConvertERC20) (*sdk.Result, error) {
+	pair, err := k.ConvertERC20(ctx, msg)
+	if err != nil {
+		return nil, err
+	}
+
+	ctx.EventManager().EmitEvents(sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeConvertERC20,
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+			sdk.NewAttribute(types.AttributeKeyReceiver, msg.Receiver.String()),
+			sdk.NewAttribute(sdk.AttributeKeyAmount, msg.Amount.String()),
+			sdk.NewAttribute(types.AttributeKeyCosmosCoin, pair.Denom),
+			sdk.NewAttribute(types.AttributeKeyERC20Token, pair.ERC20Address),
+		),
+		sdk.NewEvent(
+			sdk.EventTypeMessage,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(sdk.AttributeKeySender, msg.Sender.String()),
+		),
+	})
+
+	return &sdk.Result{Events: ctx.EventManager().Events()}, nil
+}
+
+// NewTokenPairProposalHandler returns a handler for the erc20 module governance
+// proposals.
+func NewTokenPairProposalHandler(k Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		return k.ExecuteAtomic(ctx, func(ctx sdk.Context) error {
+			switch c := content.(type) {
+			case types.RegisterCoinProposal:
+				return handleRegisterCoinProposal(ctx, k, c)
+			case types.RegisterERC20Proposal:
+				return handleRegisterERC20Proposal(ctx, k, c)
+			case types.ToggleTokenConversionProposal:
+				return handleToggleTokenConversionProposal(ctx, k, c)
+			default:
+				return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s proposal content type: %T", ModuleName, c)
+			}
+		})
+	}
+}
+
+func handleRegisterCoinProposal(ctx sdk.Context, k Keeper, p types.RegisterCoinProposal) error {
+	pair, err := k.RegisterCoin(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterCoin,
+			sdk.NewAttribute(types.AttributeKeyCosmosCoin, pair.Denom),
+			sdk.NewAttribute(types.AttributeKeyERC20Token, pair.ERC20Address),
+		),
+	)
+
+	return nil
+}
+
+func handleRegisterERC20Proposal(ctx sdk.Context, k Keeper, p types.RegisterERC20Proposal) error {
+	pair, err := k.RegisterERC20(ctx, p)
+	if err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterERC20,
+			sdk.NewAttribute(types.AttributeKeyCosmosCoin, pair.Denom),
+			sdk.NewAttribute(types.AttributeKeyERC20Token, pair.ERC20Address),
+		),
+	)
+
+	return nil
+}
+
+func handleToggleTokenConversionProposal(ctx sdk.Context, k Keeper, p types.ToggleTokenConversionProposal) error {
+	pair, err := k.ToggleTokenConversion(ctx, p.Token)
+	if err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeTogglePair,
+			sdk.NewAttribute(types.AttributeKeyCosmosCoin, pair.Denom),
+			sdk.NewAttribute(types.AttributeKeyERC20Token, pair.ERC20Address),
+			sdk.NewAttribute(types.AttributeKeyEnabled, strconv.FormatBool(pair.Enabled)),
+		),
+	)
+
+	return nil
+}
@@ -0,0 +1,197 @@
+package erc20_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/x/erc20"
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const testDenom = "acoin"
+
+type Erc20TestSuite struct {
+	suite.Suite
+
+	ctx             sdk.Context
+	handler         sdk.Handler
+	proposalHandler govtypes.Handler
+	app             *app.EthermintApp
+	address         ethcmn.Address
+}
+
+func TestErc20TestSuite(t *testing.T) {
+	suite.Run(t, new(Erc20TestSuite))
+}
+
+func (suite *Erc20TestSuite) SetupTest() {
+	checkTx := false
+
+	suite.app = app.Setup(checkTx)
+	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	suite.handler = erc20.NewHandler(suite.app.Erc20Keeper)
+	suite.proposalHandler = erc20.NewTokenPairProposalHandler(suite.app.Erc20Keeper)
+
+	privkey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	suite.address = ethcmn.BytesToAddress(privkey.PubKey().Address().Bytes())
+
+	coins := sdk.NewCoins(sdk.NewCoin(testDenom, sdk.NewInt(100)))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, types.ModuleName, coins))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, suite.address.Bytes(), coins))
+}
+
+func (suite *Erc20TestSuite) registerCoin() {
+	err := suite.proposalHandler(
+		suite.ctx, types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+	)
+	suite.Require().NoError(err)
+}
+
+func hasEvent(events sdk.Events, eventType string) bool {
+	for _, event := range events {
+		if event.Type == eventType {
+			return true
+		}
+	}
+	return false
+}
+
+func (suite *Erc20TestSuite) TestHandleMsgConvert() {
+	suite.registerCoin()
+	contract := types.ERC20ContractAddress(testDenom)
+
+	res, err := suite.handler(
+		suite.ctx, types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(30)), suite.address, suite.address.Bytes()),
+	)
+	suite.Require().NoError(err)
+	suite.Require().True(hasEvent(res.Events, types.EventTypeConvertCoin))
+
+	res, err = suite.handler(
+		suite.ctx, types.NewMsgConvertERC20(contract, sdk.NewInt(10), suite.address.Bytes(), suite.address.Bytes()),
+	)
+	suite.Require().NoError(err)
+	suite.Require().True(hasEvent(res.Events, types.EventTypeConvertERC20))
+
+	balance, err := suite.app.Erc20Keeper.BalanceOf(suite.ctx, contract, suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(20), balance)
+	suite.Require().Equal(sdk.NewInt(80), suite.app.BankKeeper.GetCoins(suite.ctx, suite.address.Bytes()).AmountOf(testDenom))
+}
+
+func (suite *Erc20TestSuite) TestHandlerRollback() {
+	suite.registerCoin()
+	contract := types.ERC20ContractAddress(testDenom)
+
+	// fails on the burn call, after the escrowed coins have been released
+	_, err := suite.handler(
+		suite.ctx, types.NewMsgConvertERC20(contract, sdk.NewInt(10), suite.address.Bytes(), suite.address.Bytes()),
+	)
+	suite.Require().Error(err)
+
+	// the EVM state is still usable after the rollback
+	balance, err := suite.app.Erc20Keeper.BalanceOf(suite.ctx, contract, suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Zero(balance.Sign())
+
+	_, err = suite.handler(suite.ctx, types.MsgConvertCoin{})
+	suite.Require().Error(err)
+}
+
+func (suite *Erc20TestSuite) TestHandleProposals() {
+	suite.registerCoin()
+
+	// duplicated registration
+	err := suite.proposalHandler(
+		suite.ctx, types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+	)
+	suite.Require().Error(err)
+
+	err = suite.proposalHandler(suite.ctx, types.NewToggleTokenConversionProposal("title", "description", testDenom))
+	suite.Require().NoError(err)
+
+	pair, found := suite.app.Erc20Keeper.GetTokenPairByDenom(suite.ctx, testDenom)
+	suite.Require().True(found)
+	suite.Require().False(pair.Enabled)
+
+	err = suite.proposalHandler(suite.ctx, types.NewRegisterERC20Proposal("title", "description", suite.address, "erc20token"))
+	suite.Require().Error(err)
+
+	err = suite.proposalHandler(suite.ctx, govtypes.NewTextProposal("title", "description"))
+	suite.Require().Error(err)
+}
+
+func (suite *Erc20TestSuite) TestExportImport() {
+	suite.registerCoin()
+
+	var genState types.GenesisState
+	suite.Require().NotPanics(func() {
+		genState = erc20.ExportGenesis(suite.ctx, suite.app.Erc20Keeper)
+	})
+	suite.Require().Len(genState.TokenPairs, 1)
+
+	suite.Require().NotPanics(func() {
+		_ = erc20.InitGenesis(suite.ctx, suite.app.Erc20Keeper, genState)
+	})
+}
+
+func (suite *Erc20TestSuite) TestInitGenesis() {
+	testCases := []struct {
+		name     string
+		genState types.GenesisState
+		expPanic bool
+	}{
+		{
+			"default",
+			types.DefaultGenesisState(),
+			false,
+		},
+		{
+			"native coin pair without contract",
+			types.NewGenesisState(types.DefaultParams(), []types.TokenPair{
+				types.NewTokenPair(types.ERC20ContractAddress(testDenom), testDenom, types.OwnerModule),
+			}),
+			false,
+		},
+		{
+			"external pair without contract",
+			types.NewGenesisState(types.DefaultParams(), []types.TokenPair{
+				types.NewTokenPair(suite.address, "erc20token", types.OwnerExternal),
+			}),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			if tc.expPanic {
+				suite.Require().Panics(func() {
+					_ = erc20.InitGenesis(suite.ctx, suite.app.Erc20Keeper, tc.genState)
+				})
+				return
+			}
+
+			suite.Require().NotPanics(func() {
+				_ = erc20.InitGenesis(suite.ctx, suite.app.Erc20Keeper, tc.genState)
+			})
+
+			for _, pair := range tc.genState.TokenPairs {
+				suite.Require().True(suite.app.Erc20Keeper.IsContract(suite.ctx, pair.GetERC20Contract()))
+			}
+		})
+	}
+}
@@ -0,0 +1,148 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// ConvertCoin converts the sender coins into ERC20 tokens of the token pair, which
+// are received by the given hex address:
+//   - the coins of a native Cosmos coin pair are escrowed by the module account and
+//     the same amount of tokens are minted by the module ERC20 contract.
+//   - the coins of a native ERC20 pair are burned and the same amount of tokens are
+//     released from the module escrow.
+//
+// NOTE: the ERC20 contract calls are done at last, as they modify the EVM state.
+func (k Keeper) ConvertCoin(ctx sdk.Context, msg types.MsgConvertCoin) (types.TokenPair, error) {
+	pair, err := k.getConvertiblePair(ctx, msg.Coin.Denom)
+	if err != nil {
+		return types.TokenPair{}, err
+	}
+
+	contract := pair.GetERC20Contract()
+	receiver := ethcmn.HexToAddress(msg.Receiver)
+	coins := sdk.NewCoins(msg.Coin)
+
+	if err := k.supplyKeeper.SendCoinsFromAccountToModule(ctx, msg.Sender, types.ModuleName, coins); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	switch {
+	case pair.IsNativeCoin():
+		err = k.callERC20Method(ctx, types.ModuleEVMAddress, contract, "mint", receiver, msg.Coin.Amount.BigInt())
+
+	case pair.IsNativeERC20():
+		if err := k.supplyKeeper.BurnCoins(ctx, types.ModuleName, coins); err != nil {
+			return types.TokenPair{}, err
+		}
+
+		err = k.callERC20Method(ctx, types.ModuleEVMAddress, contract, "transfer", receiver, msg.Coin.Amount.BigInt())
+
+	default:
+		err = sdkerrors.Wrapf(types.ErrInvalidTokenPair, "invalid contract owner %s", pair.ContractOwner)
+	}
+
+	return pair, err
+}
+
+// ConvertERC20 converts the ERC20 tokens of the sender into coins of the token pair,
+// which are received by the given address:
+//   - the tokens of a native Cosmos coin pair are burned by the module ERC20 contract
+//     and the same amount of coins are released from the module account escrow.
+//   - the tokens of a native ERC20 pair are escrowed by the module, which the sender
+//     must have approved to spend them, and the same amount of coins are minted.
+//
+// NOTE: the ERC20 contract calls are done at last, as they modify the EVM state.
+func (k Keeper) ConvertERC20(ctx sdk.Context, msg types.MsgConvertERC20) (types.TokenPair, error) {
+	pair, found := k.GetTokenPair(ctx, ethcmn.HexToAddress(msg.ContractAddress))
+	if !found {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "ERC20 contract %s", msg.ContractAddress)
+	}
+
+	if _, err := k.getConvertiblePair(ctx, pair.Denom); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	contract := pair.GetERC20Contract()
+	sender := ethcmn.BytesToAddress(msg.Sender.Bytes())
+	coins := sdk.NewCoins(sdk.NewCoin(pair.Denom, msg.Amount))
+
+	switch {
+	case pair.IsNativeCoin():
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, msg.Receiver, coins); err != nil {
+			return types.TokenPair{}, err
+		}
+
+		return pair, k.callERC20Method(ctx, types.ModuleEVMAddress, contract, "burn", sender, msg.Amount.BigInt())
+
+	case pair.IsNativeERC20():
+		if err := k.supplyKeeper.MintCoins(ctx, types.ModuleName, coins); err != nil {
+			return types.TokenPair{}, err
+		}
+
+		if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, types.ModuleName, msg.Receiver, coins); err != nil {
+			return types.TokenPair{}, err
+		}
+
+		return pair, k.escrowERC20(ctx, contract, sender, msg.Amount.BigInt())
+
+	default:
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrInvalidTokenPair, "invalid contract owner %s", pair.ContractOwner)
+	}
+}
+
+// escrowERC20 transfers the tokens of an external ERC20 contract from the sender
+// to the module with transferFrom, so the sender must have approved the module
+// address (ModuleEVMAddress) to spend the amount beforehand. It checks the escrow
+// balance, as the amount received by the module can differ from the transferred
+// one on some contracts (eg: fee on transfer).
+func (k Keeper) escrowERC20(ctx sdk.Context, contract, sender ethcmn.Address, amount *big.Int) error {
+	balanceBefore, err := k.BalanceOf(ctx, contract, types.ModuleEVMAddress)
+	if err != nil {
+		return err
+	}
+
+	if err := k.callERC20Method(
+		ctx, types.ModuleEVMAddress, contract, "transferFrom", sender, types.ModuleEVMAddress, amount,
+	); err != nil {
+		return err
+	}
+
+	balanceAfter, err := k.BalanceOf(ctx, contract, types.ModuleEVMAddress)
+	if err != nil {
+		return err
+	}
+
+	if received := new(big.Int).Sub(balanceAfter, balanceBefore); received.Cmp(amount) != 0 {
+		return sdkerrors.Wrapf(
+			types.ErrInvalidConversion, "escrowed amount %s doesn't match the converted amount %s", received, amount,
+		)
+	}
+
+	return nil
+}
+
+// getConvertiblePair returns the token pair of the coin denomination if the
+// conversions are enabled for it.
+func (k Keeper) getConvertiblePair(ctx sdk.Context, denom string) (types.TokenPair, error) {
+	if !k.GetParams(ctx).EnableConversions {
+		return types.TokenPair{}, types.ErrConversionsDisabled
+	}
+
+	pair, found := k.GetTokenPairByDenom(ctx, denom)
+	if !found {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "denomination %s", denom)
+	}
+
+	if !pair.Enabled {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairDisabled, "denomination %s", denom)
+	}
+
+	return pair, nil
+}
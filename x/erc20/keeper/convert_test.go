@@ -0,0 +1,117 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+func (suite *KeeperTestSuite) TestConvertNativeCoin() {
+	pair := suite.registerCoin()
+	contract := pair.GetERC20Contract()
+	receiver := suite.newAddress()
+
+	// coins -> tokens
+	msgCoin := types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(40)), receiver, suite.address.Bytes())
+	_, err := suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msgCoin)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(sdk.NewInt(60), suite.balance(suite.address, testDenom))
+	suite.Require().Equal(sdk.NewInt(40), suite.escrowBalance(testDenom))
+	suite.Require().Equal(big.NewInt(40), suite.tokenBalance(contract, receiver))
+
+	values, err := suite.app.Erc20Keeper.CallERC20(suite.ctx, types.ModuleEVMAddress, contract, "totalSupply")
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(40), values[0])
+
+	// tokens -> coins
+	msgERC20 := types.NewMsgConvertERC20(contract, sdk.NewInt(15), suite.address.Bytes(), receiver.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertERC20(suite.ctx, msgERC20)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(sdk.NewInt(75), suite.balance(suite.address, testDenom))
+	suite.Require().Equal(sdk.NewInt(25), suite.escrowBalance(testDenom))
+	suite.Require().Equal(big.NewInt(25), suite.tokenBalance(contract, receiver))
+
+	values, err = suite.app.Erc20Keeper.CallERC20(suite.ctx, types.ModuleEVMAddress, contract, "totalSupply")
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(25), values[0])
+
+	// insufficient token balance
+	msgERC20 = types.NewMsgConvertERC20(contract, sdk.NewInt(26), suite.address.Bytes(), receiver.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertERC20(suite.ctx, msgERC20)
+	suite.Require().Error(err)
+
+	// insufficient coin balance
+	msgCoin = types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(76)), receiver, suite.address.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msgCoin)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestConvertNativeERC20() {
+	contract := suite.deployExternalERC20(100)
+	denom := "erc20token"
+	_, err := suite.app.Erc20Keeper.RegisterERC20(
+		suite.ctx, types.NewRegisterERC20Proposal("title", "description", contract, denom),
+	)
+	suite.Require().NoError(err)
+
+	receiver := suite.newAddress()
+
+	// tokens -> coins, which requires the module allowance
+	msgERC20 := types.NewMsgConvertERC20(contract, sdk.NewInt(30), receiver.Bytes(), suite.address.Bytes())
+	cacheCtx, _ := suite.ctx.CacheContext()
+	_, err = suite.app.Erc20Keeper.ConvertERC20(cacheCtx, msgERC20)
+	suite.Require().Error(err)
+
+	suite.approve(contract, 30)
+	_, err = suite.app.Erc20Keeper.ConvertERC20(suite.ctx, msgERC20)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(sdk.NewInt(30), suite.balance(receiver, denom))
+	suite.Require().Equal(big.NewInt(70), suite.tokenBalance(contract, suite.address))
+	suite.Require().Equal(big.NewInt(30), suite.tokenBalance(contract, types.ModuleEVMAddress))
+	suite.Require().Equal(sdk.NewInt(30), suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(denom))
+
+	// coins -> tokens
+	msgCoin := types.NewMsgConvertCoin(sdk.NewCoin(denom, sdk.NewInt(10)), suite.address, receiver.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msgCoin)
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(sdk.NewInt(20), suite.balance(receiver, denom))
+	suite.Require().Equal(big.NewInt(80), suite.tokenBalance(contract, suite.address))
+	suite.Require().Equal(big.NewInt(20), suite.tokenBalance(contract, types.ModuleEVMAddress))
+	suite.Require().Equal(sdk.NewInt(20), suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(denom))
+
+	// insufficient token balance
+	suite.approve(contract, 81)
+	msgERC20 = types.NewMsgConvertERC20(contract, sdk.NewInt(81), receiver.Bytes(), suite.address.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertERC20(suite.ctx, msgERC20)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestConvertDisabled() {
+	pair := suite.registerCoin()
+
+	params := suite.app.Erc20Keeper.GetParams(suite.ctx)
+	params.EnableConversions = false
+	suite.app.Erc20Keeper.SetParams(suite.ctx, params)
+
+	msgCoin := types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(10)), suite.address, suite.address.Bytes())
+	_, err := suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msgCoin)
+	suite.Require().Error(err)
+
+	msgERC20 := types.NewMsgConvertERC20(pair.GetERC20Contract(), sdk.NewInt(10), suite.address.Bytes(), suite.address.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertERC20(suite.ctx, msgERC20)
+	suite.Require().Error(err)
+
+	// unregistered pair
+	params.EnableConversions = true
+	suite.app.Erc20Keeper.SetParams(suite.ctx, params)
+
+	msgCoin = types.NewMsgConvertCoin(sdk.NewCoin("other", sdk.NewInt(10)), suite.address, suite.address.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msgCoin)
+	suite.Require().Error(err)
+}
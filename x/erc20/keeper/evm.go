@@ -0,0 +1,123 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// DeployERC20 deploys the module ERC20 contract for the given coin denomination at
+// its deterministic address. The contract is created by the denomination deployer
+// (see types.ERC20DeployerAddress), which executes the contract constructor.
+func (k Keeper) DeployERC20(ctx sdk.Context, denom, name, symbol string, decimals uint8) (ethcmn.Address, error) {
+	contract := types.ERC20ContractAddress(denom)
+	deployer := types.ERC20DeployerAddress(denom)
+
+	if k.IsContract(ctx, contract) || k.evmKeeper.GetNonce(ctx, deployer) != 0 {
+		return ethcmn.Address{}, sdkerrors.Wrapf(
+			types.ErrTokenPairAlreadyExists, "ERC20 contract %s is already deployed", contract.String(),
+		)
+	}
+
+	bytecode, err := types.ERC20CreationCode(name, symbol, decimals)
+	if err != nil {
+		return ethcmn.Address{}, sdkerrors.Wrapf(types.ErrEVMCall, "failed to pack ERC20 constructor: %s", err)
+	}
+
+	deployed, err := k.evmKeeper.DeployContract(ctx, deployer, bytecode)
+	if err != nil {
+		return ethcmn.Address{}, sdkerrors.Wrapf(types.ErrEVMCall, "ERC20 deployment: %s", err)
+	}
+
+	if deployed != contract {
+		return ethcmn.Address{}, sdkerrors.Wrapf(
+			types.ErrEVMCall, "ERC20 contract deployed at %s instead of %s", deployed.String(), contract.String(),
+		)
+	}
+
+	return contract, nil
+}
+
+// IsContract returns true if the account has contract code on the EVM.
+func (k Keeper) IsContract(ctx sdk.Context, address ethcmn.Address) bool {
+	return len(k.evmKeeper.GetCode(ctx, address)) != 0
+}
+
+// CallERC20 calls a method of the ERC20 contract from the given address and
+// returns its unpacked outputs.
+func (k Keeper) CallERC20(
+	ctx sdk.Context, from, contract ethcmn.Address, method string, args ...interface{},
+) ([]interface{}, error) {
+	data, err := types.ERC20ABI.Pack(method, args...)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrEVMCall, "failed to pack %s: %s", method, err)
+	}
+
+	res, err := k.evmKeeper.CallContract(ctx, from, contract, data, types.ERC20CallGasLimit)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrEVMCall, "%s on contract %s: %s", method, contract.String(), err)
+	}
+
+	resultData, err := evmtypes.DecodeResultData(res.Result.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// NOTE: some ERC20 contracts don't return any value on the state changing
+	// methods
+	if len(resultData.Ret) == 0 {
+		return nil, nil
+	}
+
+	values, err := types.ERC20ABI.Unpack(method, resultData.Ret)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrEVMCall, "failed to unpack %s: %s", method, err)
+	}
+
+	return values, nil
+}
+
+// BalanceOf returns the ERC20 token balance of the given account.
+func (k Keeper) BalanceOf(ctx sdk.Context, contract, account ethcmn.Address) (*big.Int, error) {
+	values, err := k.CallERC20(ctx, types.ModuleEVMAddress, contract, "balanceOf", account)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(values) == 0 {
+		return nil, sdkerrors.Wrapf(types.ErrEVMCall, "balanceOf on contract %s returned no value", contract.String())
+	}
+
+	balance, ok := values[0].(*big.Int)
+	if !ok {
+		return nil, sdkerrors.Wrapf(types.ErrEVMCall, "invalid balanceOf value type %T", values[0])
+	}
+
+	return balance, nil
+}
+
+// callERC20Method calls a state changing method of the ERC20 contract, returning
+// an error if the method returns false. The methods that don't return any value
+// (eg: mint and burn) revert on failure.
+func (k Keeper) callERC20Method(
+	ctx sdk.Context, from, contract ethcmn.Address, method string, args ...interface{},
+) error {
+	values, err := k.CallERC20(ctx, from, contract, method, args...)
+	if err != nil {
+		return err
+	}
+
+	if len(values) != 0 {
+		if success, ok := values[0].(bool); !ok || !success {
+			return sdkerrors.Wrapf(types.ErrEVMCall, "%s on contract %s returned false", method, contract.String())
+		}
+	}
+
+	return nil
+}
@@ -0,0 +1,132 @@
+package keeper_test
+
+import (
+	"bytes"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	tmtypes "github.com/tendermint/tendermint/types"
+)
+
+func (suite *KeeperTestSuite) TestDeployERC20() {
+	contract, err := suite.app.Erc20Keeper.DeployERC20(suite.ctx, testDenom, "Test Coin", "COIN", 6)
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.ERC20ContractAddress(testDenom), contract)
+	suite.Require().Equal(uint64(1), suite.app.EvmKeeper.GetNonce(suite.ctx, contract))
+	suite.Require().Equal(uint64(1), suite.app.EvmKeeper.GetNonce(suite.ctx, types.ERC20DeployerAddress(testDenom)))
+
+	// the runtime code ends with the solc 0.8.15 metadata, without metadata hash
+	code := suite.app.EvmKeeper.GetCode(suite.ctx, contract)
+	suite.Require().True(bytes.HasSuffix(code, ethcmn.FromHex("0xa164736f6c634300080f000a")))
+
+	for method, expected := range map[string]interface{}{
+		"name":        "Test Coin",
+		"symbol":      "COIN",
+		"decimals":    uint8(6),
+		"version":     "1.3.0",
+		"bridge":      types.ModuleEVMAddress,
+		"remoteToken": ethcmn.Address{},
+	} {
+		values, err := suite.app.Erc20Keeper.CallERC20(suite.ctx, suite.address, contract, method)
+		suite.Require().NoError(err, method)
+		suite.Require().Equal(expected, values[0], method)
+	}
+
+	suite.Require().Zero(suite.tokenBalance(contract, types.ModuleEVMAddress).Sign())
+
+	_, err = suite.app.Erc20Keeper.DeployERC20(suite.ctx, testDenom, "Test Coin", "COIN", 6)
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestERC20Contract() {
+	k := suite.app.Erc20Keeper
+	contract, err := k.DeployERC20(suite.ctx, testDenom, "Test Coin", "COIN", 6)
+	suite.Require().NoError(err)
+
+	spender := suite.newAddress()
+	recipient := suite.newAddress()
+
+	call := func(from ethcmn.Address, method string, args ...interface{}) ([]interface{}, error) {
+		return k.CallERC20(suite.ctx, from, contract, method, args...)
+	}
+
+	// only the bridge (i.e the module) can mint and burn
+	_, err = call(suite.address, "mint", suite.address, big.NewInt(100))
+	suite.Require().Error(err)
+
+	_, err = call(types.ModuleEVMAddress, "mint", suite.address, big.NewInt(100))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(100), suite.tokenBalance(contract, suite.address))
+
+	_, err = call(suite.address, "burn", suite.address, big.NewInt(10))
+	suite.Require().Error(err)
+
+	_, err = call(types.ModuleEVMAddress, "burn", suite.address, big.NewInt(101))
+	suite.Require().Error(err)
+
+	_, err = call(types.ModuleEVMAddress, "burn", suite.address, big.NewInt(10))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(90), suite.tokenBalance(contract, suite.address))
+
+	// transfer
+	_, err = call(suite.address, "transfer", recipient, big.NewInt(91))
+	suite.Require().Error(err)
+
+	values, err := call(suite.address, "transfer", recipient, big.NewInt(20))
+	suite.Require().NoError(err)
+	suite.Require().Equal(true, values[0])
+	suite.Require().Equal(big.NewInt(70), suite.tokenBalance(contract, suite.address))
+	suite.Require().Equal(big.NewInt(20), suite.tokenBalance(contract, recipient))
+
+	// approve and transferFrom
+	_, err = call(suite.address, "approve", spender, big.NewInt(30))
+	suite.Require().NoError(err)
+
+	values, err = call(suite.address, "allowance", suite.address, spender)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(30), values[0])
+
+	_, err = call(spender, "transferFrom", suite.address, recipient, big.NewInt(31))
+	suite.Require().Error(err)
+
+	_, err = call(spender, "transferFrom", suite.address, recipient, big.NewInt(25))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(45), suite.tokenBalance(contract, suite.address))
+	suite.Require().Equal(big.NewInt(45), suite.tokenBalance(contract, recipient))
+
+	values, err = call(suite.address, "allowance", suite.address, spender)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(5), values[0])
+
+	values, err = call(suite.address, "totalSupply")
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(90), values[0])
+}
+
+func (suite *KeeperTestSuite) TestERC20TransferLogs() {
+	txBytes := []byte("erc20 transfer")
+	suite.ctx = suite.ctx.WithTxBytes(txBytes)
+
+	pair := suite.registerCoin()
+	msg := types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(10)), suite.address, suite.address.Bytes())
+	_, err := suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msg)
+	suite.Require().NoError(err)
+
+	logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, evmtypes.ModuleTxHash(ethcmn.BytesToHash(tmtypes.Tx(txBytes).Hash())))
+	suite.Require().NoError(err)
+	// the contract emits the Transfer and the Mint events
+	suite.Require().Len(logs, 2)
+
+	log := logs[0]
+	suite.Require().Equal(pair.GetERC20Contract(), log.Address)
+	suite.Require().Equal(types.ERC20ABI.Events["Transfer"].ID, log.Topics[0])
+	suite.Require().Equal(ethcmn.Hash{}, log.Topics[1])
+	suite.Require().Equal(suite.address.Hash(), log.Topics[2])
+	suite.Require().Equal(ethcmn.BigToHash(big.NewInt(10)).Bytes(), log.Data)
+}
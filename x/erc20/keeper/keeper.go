@@ -0,0 +1,73 @@
+package keeper
+
+import (
+	"fmt"
+
+	"github.com/tendermint/tendermint/libs/log"
+
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/params"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+// Keeper of the erc20 module. It stores the token pairs and converts the coins
+// and the ERC20 tokens of each pair into each other.
+type Keeper struct {
+	// Amino codec
+	cdc *codec.Codec
+	// Store key required for the token pairs
+	storeKey   sdk.StoreKey
+	paramSpace params.Subspace
+	// Supply Keeper for minting, burning and escrowing the coins
+	supplyKeeper types.SupplyKeeper
+	// EVM Keeper for calling and deploying the ERC20 contracts
+	evmKeeper types.EVMKeeper
+}
+
+// NewKeeper generates new erc20 module keeper
+func NewKeeper(
+	cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace,
+	sk types.SupplyKeeper, evmKeeper types.EVMKeeper,
+) Keeper {
+	// ensure erc20 module account is set
+	if addr := sk.GetModuleAddress(types.ModuleName); addr == nil {
+		panic(fmt.Sprintf("the %s module account has not been set", types.ModuleName))
+	}
+
+	// set KeyTable if it has not already been set
+	if !paramSpace.HasKeyTable() {
+		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
+	}
+
+	return Keeper{
+		cdc:          cdc,
+		storeKey:     storeKey,
+		paramSpace:   paramSpace,
+		supplyKeeper: sk,
+		evmKeeper:    evmKeeper,
+	}
+}
+
+// Logger returns a module-specific logger.
+func (k Keeper) Logger(ctx sdk.Context) log.Logger {
+	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
+}
+
+// ExecuteAtomic executes fn on a cached context, so that the EVM and Cosmos state
+// changes are only written if fn succeeds.
+func (k Keeper) ExecuteAtomic(ctx sdk.Context, fn func(ctx sdk.Context) error) error {
+	return k.evmKeeper.ExecuteAtomic(ctx, fn)
+}
+
+// GetParams returns the total set of erc20 parameters.
+func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
+	k.paramSpace.GetParamSet(ctx, &params)
+	return params
+}
+
+// SetParams sets the erc20 parameters to the param space.
+func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
+	k.paramSpace.SetParamSet(ctx, &params)
+}
@@ -0,0 +1,107 @@
+package keeper_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	"github.com/cosmos/ethermint/x/erc20/keeper"
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+const testDenom = "acoin"
+
+type KeeperTestSuite struct {
+	suite.Suite
+
+	ctx     sdk.Context
+	app     *app.EthermintApp
+	querier sdk.Querier
+	address ethcmn.Address
+}
+
+func TestKeeperTestSuite(t *testing.T) {
+	suite.Run(t, new(KeeperTestSuite))
+}
+
+func (suite *KeeperTestSuite) SetupTest() {
+	checkTx := false
+
+	suite.app = app.Setup(checkTx)
+	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	suite.querier = keeper.NewQuerier(suite.app.Erc20Keeper)
+	suite.address = suite.newAddress()
+}
+
+func (suite *KeeperTestSuite) newAddress() ethcmn.Address {
+	privkey, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	return ethcmn.BytesToAddress(privkey.PubKey().Address().Bytes())
+}
+
+// fund mints the coins and sends them to the given address
+func (suite *KeeperTestSuite) fund(address ethcmn.Address, coins sdk.Coins) {
+	err := suite.app.SupplyKeeper.MintCoins(suite.ctx, types.ModuleName, coins)
+	suite.Require().NoError(err)
+	err = suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, types.ModuleName, address.Bytes(), coins)
+	suite.Require().NoError(err)
+}
+
+func (suite *KeeperTestSuite) balance(address ethcmn.Address, denom string) sdk.Int {
+	return suite.app.BankKeeper.GetCoins(suite.ctx, address.Bytes()).AmountOf(denom)
+}
+
+func (suite *KeeperTestSuite) escrowBalance(denom string) sdk.Int {
+	return suite.balance(ethcmn.BytesToAddress(suite.app.SupplyKeeper.GetModuleAddress(types.ModuleName)), denom)
+}
+
+func (suite *KeeperTestSuite) tokenBalance(contract, account ethcmn.Address) *big.Int {
+	balance, err := suite.app.Erc20Keeper.BalanceOf(suite.ctx, contract, account)
+	suite.Require().NoError(err)
+	return balance
+}
+
+// registerCoin funds the test address and registers the token pair of the test
+// denomination.
+func (suite *KeeperTestSuite) registerCoin() types.TokenPair {
+	suite.fund(suite.address, sdk.NewCoins(sdk.NewCoin(testDenom, sdk.NewInt(100))))
+
+	pair, err := suite.app.Erc20Keeper.RegisterCoin(
+		suite.ctx, types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+	)
+	suite.Require().NoError(err)
+	return pair
+}
+
+// deployExternalERC20 deploys an ERC20 contract that isn't owned by the module, but
+// by the test address, and mints the given token balance to the test address.
+func (suite *KeeperTestSuite) deployExternalERC20(balance int64) ethcmn.Address {
+	args, err := types.ERC20ABI.Pack("", suite.address, ethcmn.Address{}, "External Token", "EXT", uint8(6))
+	suite.Require().NoError(err)
+
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, append(append([]byte{}, types.ERC20Bytecode...), args...))
+	suite.Require().NoError(err)
+
+	_, err = suite.app.Erc20Keeper.CallERC20(suite.ctx, suite.address, contract, "mint", suite.address, big.NewInt(balance))
+	suite.Require().NoError(err)
+
+	return contract
+}
+
+// approve approves the module to spend the test address tokens of the contract.
+func (suite *KeeperTestSuite) approve(contract ethcmn.Address, amount int64) {
+	_, err := suite.app.Erc20Keeper.CallERC20(
+		suite.ctx, suite.address, contract, "approve", types.ModuleEVMAddress, big.NewInt(amount),
+	)
+	suite.Require().NoError(err)
+}
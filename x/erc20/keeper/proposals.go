@@ -0,0 +1,89 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// RegisterCoin deploys a new ERC20 contract for the native Cosmos coin and
+// registers the token pair.
+func (k Keeper) RegisterCoin(ctx sdk.Context, p types.RegisterCoinProposal) (types.TokenPair, error) {
+	contract := types.ERC20ContractAddress(p.Denom)
+	if err := k.validateNewPair(ctx, contract, p.Denom); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	if k.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(p.Denom).IsZero() {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrInvalidTokenPair, "denomination %s has no supply", p.Denom)
+	}
+
+	if _, err := k.DeployERC20(ctx, p.Denom, p.Name, p.Symbol, p.Decimals); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	pair := types.NewTokenPair(contract, p.Denom, types.OwnerModule)
+	k.SetTokenPair(ctx, pair)
+	return pair, nil
+}
+
+// RegisterERC20 registers the token pair of an ERC20 contract deployed on the EVM.
+// The denomination of the pair must not have any supply, as its coins are minted
+// by the module.
+func (k Keeper) RegisterERC20(ctx sdk.Context, p types.RegisterERC20Proposal) (types.TokenPair, error) {
+	contract := ethcmn.HexToAddress(p.ERC20Address)
+	if err := k.validateNewPair(ctx, contract, p.Denom); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	if !k.supplyKeeper.GetSupply(ctx).GetTotal().AmountOf(p.Denom).IsZero() {
+		return types.TokenPair{}, sdkerrors.Wrapf(
+			types.ErrInvalidTokenPair, "denomination %s already has supply", p.Denom,
+		)
+	}
+
+	if !k.IsContract(ctx, contract) {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrInvalidTokenPair, "no contract code at %s", p.ERC20Address)
+	}
+
+	// check that the contract implements the ERC20 balances
+	if _, err := k.BalanceOf(ctx, contract, types.ModuleEVMAddress); err != nil {
+		return types.TokenPair{}, err
+	}
+
+	pair := types.NewTokenPair(contract, p.Denom, types.OwnerExternal)
+	k.SetTokenPair(ctx, pair)
+	return pair, nil
+}
+
+// ToggleTokenConversion enables or disables the conversions of the token pair of
+// the given ERC20 contract hex address or coin denomination.
+func (k Keeper) ToggleTokenConversion(ctx sdk.Context, token string) (types.TokenPair, error) {
+	pair, found := k.GetTokenPairByToken(ctx, token)
+	if !found {
+		return types.TokenPair{}, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "token %s", token)
+	}
+
+	pair.Enabled = !pair.Enabled
+	k.SetTokenPair(ctx, pair)
+	return pair, nil
+}
+
+// validateNewPair checks that neither the contract nor the denomination are
+// registered and that the denomination isn't the EVM one.
+func (k Keeper) validateNewPair(ctx sdk.Context, contract ethcmn.Address, denom string) error {
+	if denom == k.evmKeeper.GetParams(ctx).EvmDenom {
+		return sdkerrors.Wrapf(types.ErrInvalidTokenPair, "cannot register the EVM denomination %s", denom)
+	}
+
+	if k.IsTokenPairRegistered(ctx, contract, denom) {
+		return sdkerrors.Wrapf(
+			types.ErrTokenPairAlreadyExists, "contract %s or denomination %s", contract.String(), denom,
+		)
+	}
+
+	return nil
+}
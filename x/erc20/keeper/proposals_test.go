@@ -0,0 +1,175 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/erc20/types"
+)
+
+func (suite *KeeperTestSuite) TestRegisterCoin() {
+	testCases := []struct {
+		msg      string
+		malleate func()
+		proposal types.RegisterCoinProposal
+		expPass  bool
+	}{
+		{
+			"coin without supply",
+			func() {},
+			types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+			false,
+		},
+		{
+			"evm denomination",
+			func() {},
+			types.NewRegisterCoinProposal("title", "description", ethermint.AttoPhoton, "Photon", "PHOTON", 18),
+			false,
+		},
+		{
+			"already registered",
+			func() {
+				suite.registerCoin()
+			},
+			types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+			false,
+		},
+		{
+			"pass",
+			func() {
+				suite.fund(suite.address, sdk.NewCoins(sdk.NewCoin(testDenom, sdk.NewInt(100))))
+			},
+			types.NewRegisterCoinProposal("title", "description", testDenom, "Test Coin", "COIN", 18),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest() // reset
+
+			tc.malleate()
+
+			pair, err := suite.app.Erc20Keeper.RegisterCoin(suite.ctx, tc.proposal)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().Equal(types.ERC20ContractAddress(testDenom), pair.GetERC20Contract())
+			suite.Require().True(pair.Enabled)
+			suite.Require().True(pair.IsNativeCoin())
+			suite.Require().True(suite.app.Erc20Keeper.IsContract(suite.ctx, pair.GetERC20Contract()))
+
+			stored, found := suite.app.Erc20Keeper.GetTokenPairByDenom(suite.ctx, testDenom)
+			suite.Require().True(found)
+			suite.Require().Equal(pair, stored)
+
+			values, err := suite.app.Erc20Keeper.CallERC20(suite.ctx, types.ModuleEVMAddress, pair.GetERC20Contract(), "name")
+			suite.Require().NoError(err)
+			suite.Require().Equal("Test Coin", values[0])
+
+			values, err = suite.app.Erc20Keeper.CallERC20(suite.ctx, types.ModuleEVMAddress, pair.GetERC20Contract(), "symbol")
+			suite.Require().NoError(err)
+			suite.Require().Equal("COIN", values[0])
+
+			values, err = suite.app.Erc20Keeper.CallERC20(suite.ctx, types.ModuleEVMAddress, pair.GetERC20Contract(), "decimals")
+			suite.Require().NoError(err)
+			suite.Require().Equal(uint8(18), values[0])
+
+			suite.Require().Equal(int64(0), suite.tokenBalance(pair.GetERC20Contract(), suite.address).Int64())
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestRegisterERC20() {
+	testCases := []struct {
+		msg      string
+		malleate func() types.RegisterERC20Proposal
+		expPass  bool
+	}{
+		{
+			"no contract code",
+			func() types.RegisterERC20Proposal {
+				return types.NewRegisterERC20Proposal("title", "description", suite.newAddress(), "erc20token")
+			},
+			false,
+		},
+		{
+			"denomination with supply",
+			func() types.RegisterERC20Proposal {
+				suite.fund(suite.address, sdk.NewCoins(sdk.NewCoin("erc20token", sdk.NewInt(1))))
+				contract := suite.deployExternalERC20(100)
+				return types.NewRegisterERC20Proposal("title", "description", contract, "erc20token")
+			},
+			false,
+		},
+		{
+			"contract already registered",
+			func() types.RegisterERC20Proposal {
+				contract := suite.deployExternalERC20(100)
+				_, err := suite.app.Erc20Keeper.RegisterERC20(
+					suite.ctx, types.NewRegisterERC20Proposal("title", "description", contract, "erc20other"),
+				)
+				suite.Require().NoError(err)
+				return types.NewRegisterERC20Proposal("title", "description", contract, "erc20token")
+			},
+			false,
+		},
+		{
+			"pass",
+			func() types.RegisterERC20Proposal {
+				contract := suite.deployExternalERC20(100)
+				return types.NewRegisterERC20Proposal("title", "description", contract, "erc20token")
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest() // reset
+
+			proposal := tc.malleate()
+
+			pair, err := suite.app.Erc20Keeper.RegisterERC20(suite.ctx, proposal)
+			if !tc.expPass {
+				suite.Require().Error(err)
+				return
+			}
+
+			suite.Require().NoError(err)
+			suite.Require().True(pair.IsNativeERC20())
+
+			stored, found := suite.app.Erc20Keeper.GetTokenPairByToken(suite.ctx, proposal.ERC20Address)
+			suite.Require().True(found)
+			suite.Require().Equal(pair, stored)
+		})
+	}
+}
+
+func (suite *KeeperTestSuite) TestToggleTokenConversion() {
+	pair := suite.registerCoin()
+
+	_, err := suite.app.Erc20Keeper.ToggleTokenConversion(suite.ctx, "unknown")
+	suite.Require().Error(err)
+
+	pair, err = suite.app.Erc20Keeper.ToggleTokenConversion(suite.ctx, testDenom)
+	suite.Require().NoError(err)
+	suite.Require().False(pair.Enabled)
+
+	msg := types.NewMsgConvertCoin(sdk.NewCoin(testDenom, sdk.NewInt(10)), suite.address, suite.address.Bytes())
+	_, err = suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msg)
+	suite.Require().Error(err)
+
+	pair, err = suite.app.Erc20Keeper.ToggleTokenConversion(suite.ctx, pair.ERC20Address)
+	suite.Require().NoError(err)
+	suite.Require().True(pair.Enabled)
+
+	_, err = suite.app.Erc20Keeper.ConvertCoin(suite.ctx, msg)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(10), suite.tokenBalance(pair.GetERC20Contract(), suite.address))
+}
@@ -0,0 +1,69 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// NewQuerier is the module level router for state queries
+func NewQuerier(keeper Keeper) sdk.Querier {
+	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+		if len(path) < 1 {
+			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+				"Insufficient parameters, at least 1 parameter is required")
+		}
+
+		switch path[0] {
+		case types.QueryParams:
+			return queryParams(ctx, keeper)
+		case types.QueryTokenPairs:
+			return queryTokenPairs(ctx, keeper)
+		case types.QueryTokenPair:
+			return queryTokenPair(ctx, path, keeper)
+		default:
+			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
+		}
+	}
+}
+
+func queryParams(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetParams(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryTokenPairs(ctx sdk.Context, keeper Keeper) ([]byte, error) {
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, keeper.GetAllTokenPairs(ctx))
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+func queryTokenPair(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	pair, found := keeper.GetTokenPairByToken(ctx, path[1])
+	if !found {
+		return nil, sdkerrors.Wrapf(types.ErrTokenPairNotFound, "token %s", path[1])
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, pair)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
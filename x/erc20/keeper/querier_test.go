@@ -0,0 +1,45 @@
+package keeper_test
+
+import (
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+func (suite *KeeperTestSuite) TestQuerier() {
+	testCases := []struct {
+		msg      string
+		path     []string
+		malleate func()
+		expPass  bool
+	}{
+		{"params", []string{types.QueryParams}, func() {}, true},
+		{"token pairs", []string{types.QueryTokenPairs}, func() {
+			suite.registerCoin()
+		}, true},
+		{"token pair by denom", []string{types.QueryTokenPair, testDenom}, func() {
+			suite.registerCoin()
+		}, true},
+		{"token pair by contract", []string{types.QueryTokenPair, types.ERC20ContractAddress(testDenom).String()}, func() {
+			suite.registerCoin()
+		}, true},
+		{"token pair not found", []string{types.QueryTokenPair, testDenom}, func() {}, false},
+		{"token pair without token", []string{types.QueryTokenPair}, func() {}, false},
+		{"unknown request", []string{"other"}, func() {}, false},
+	}
+
+	for i, tc := range testCases {
+		suite.Run(tc.msg, func() {
+			suite.SetupTest() // reset
+			tc.malleate()
+
+			bz, err := suite.querier(suite.ctx, tc.path, abci.RequestQuery{})
+			if tc.expPass {
+				suite.Require().NoError(err, "valid test %d failed: %s", i, tc.msg)
+				suite.Require().NotZero(len(bz))
+			} else {
+				suite.Require().Error(err, "invalid test %d passed: %s", i, tc.msg)
+			}
+		})
+	}
+}
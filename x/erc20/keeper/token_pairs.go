@@ -0,0 +1,91 @@
+package keeper
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/erc20/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// GetTokenPair returns the token pair of the given ERC20 contract.
+func (k Keeper) GetTokenPair(ctx sdk.Context, contract ethcmn.Address) (types.TokenPair, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPair)
+	bz := store.Get(contract.Bytes())
+	if len(bz) == 0 {
+		return types.TokenPair{}, false
+	}
+
+	var pair types.TokenPair
+	k.cdc.MustUnmarshalBinaryBare(bz, &pair)
+	return pair, true
+}
+
+// GetTokenPairByDenom returns the token pair of the given coin denomination.
+func (k Keeper) GetTokenPairByDenom(ctx sdk.Context, denom string) (types.TokenPair, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPairByDenom)
+	bz := store.Get([]byte(denom))
+	if len(bz) == 0 {
+		return types.TokenPair{}, false
+	}
+
+	return k.GetTokenPair(ctx, ethcmn.BytesToAddress(bz))
+}
+
+// GetTokenPairByToken returns the token pair of the given token, which is either
+// the hex address of the ERC20 contract or the coin denomination.
+func (k Keeper) GetTokenPairByToken(ctx sdk.Context, token string) (types.TokenPair, bool) {
+	if ethcmn.IsHexAddress(token) {
+		return k.GetTokenPair(ctx, ethcmn.HexToAddress(token))
+	}
+
+	return k.GetTokenPairByDenom(ctx, token)
+}
+
+// SetTokenPair stores the token pair and indexes it by its coin denomination.
+func (k Keeper) SetTokenPair(ctx sdk.Context, pair types.TokenPair) {
+	contract := pair.GetERC20Contract()
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPair)
+	store.Set(contract.Bytes(), k.cdc.MustMarshalBinaryBare(pair))
+
+	denomStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPairByDenom)
+	denomStore.Set([]byte(pair.Denom), contract.Bytes())
+}
+
+// IsTokenPairRegistered returns true if either the ERC20 contract or the coin
+// denomination are registered on a token pair.
+func (k Keeper) IsTokenPairRegistered(ctx sdk.Context, contract ethcmn.Address, denom string) bool {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPair)
+	denomStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPairByDenom)
+
+	return store.Has(contract.Bytes()) || denomStore.Has([]byte(denom))
+}
+
+// IterateTokenPairs iterates over the token pairs and performs a callback
+// function. The iteration stops if the callback returns true.
+func (k Keeper) IterateTokenPairs(ctx sdk.Context, cb func(pair types.TokenPair) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixTokenPair)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var pair types.TokenPair
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &pair)
+
+		if cb(pair) {
+			break
+		}
+	}
+}
+
+// GetAllTokenPairs returns all the registered token pairs.
+func (k Keeper) GetAllTokenPairs(ctx sdk.Context) []types.TokenPair {
+	pairs := []types.TokenPair{}
+	k.IterateTokenPairs(ctx, func(pair types.TokenPair) bool {
+		pairs = append(pairs, pair)
+		return false
+	})
+
+	return pairs
+}
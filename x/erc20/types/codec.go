@@ -0,0 +1,24 @@
+package types
+
+import (
+	"github.com/cosmos/cosmos-sdk/codec"
+)
+
+// ModuleCdc defines the erc20 module's codec
+var ModuleCdc = codec.New()
+
+// RegisterCodec registers all the necessary types and interfaces for the
+// erc20 module
+func RegisterCodec(cdc *codec.Codec) {
+	cdc.RegisterConcrete(MsgConvertCoin{}, "ethermint/MsgConvertCoin", nil)
+	cdc.RegisterConcrete(MsgConvertERC20{}, "ethermint/MsgConvertERC20", nil)
+	cdc.RegisterConcrete(RegisterCoinProposal{}, "ethermint/RegisterCoinProposal", nil)
+	cdc.RegisterConcrete(RegisterERC20Proposal{}, "ethermint/RegisterERC20Proposal", nil)
+	cdc.RegisterConcrete(ToggleTokenConversionProposal{}, "ethermint/ToggleTokenConversionProposal", nil)
+}
+
+func init() {
+	RegisterCodec(ModuleCdc)
+	codec.RegisterCrypto(ModuleCdc)
+	ModuleCdc.Seal()
+}
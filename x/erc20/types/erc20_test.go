@@ -0,0 +1,43 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+func TestERC20Bytecode(t *testing.T) {
+	// keccak256 of the OptimismMintableERC20 creation bytecode of the Optimism
+	// monorepo v1.19.6 bindings
+	require.Equal(t,
+		ethcmn.HexToHash("0x7c6e1cf86cf8622d8beceafa3610ff88eceb3b0fafff0491bfa26a7b876c4d9a"),
+		ethcrypto.Keccak256Hash(ERC20Bytecode),
+	)
+
+	// the bytecode ends with the solc 0.8.15 metadata, without metadata hash
+	require.Equal(t, ethcmn.FromHex("0xa164736f6c634300080f000a"), ERC20Bytecode[len(ERC20Bytecode)-12:])
+
+	for _, method := range []string{"mint", "burn", "bridge", "transferFrom", "approve"} {
+		require.Contains(t, ERC20ABI.Methods, method)
+	}
+}
+
+func TestERC20CreationCode(t *testing.T) {
+	code, err := ERC20CreationCode("Test Coin", "COIN", 6)
+	require.NoError(t, err)
+	require.Equal(t, ERC20Bytecode, code[:len(ERC20Bytecode)])
+
+	args, err := ERC20ABI.Constructor.Inputs.Unpack(code[len(ERC20Bytecode):])
+	require.NoError(t, err)
+	require.Equal(t, []interface{}{ModuleEVMAddress, ethcmn.Address{}, "Test Coin", "COIN", uint8(6)}, args)
+}
+
+func TestERC20ContractAddress(t *testing.T) {
+	require.Equal(t, ERC20ContractAddress("acoin"), ERC20ContractAddress("acoin"))
+	require.NotEqual(t, ERC20ContractAddress("acoin"), ERC20ContractAddress("bcoin"))
+	require.NotEqual(t, ModuleEVMAddress, ERC20ContractAddress(""))
+	require.Equal(t, ethcrypto.CreateAddress(ERC20DeployerAddress("acoin"), 0), ERC20ContractAddress("acoin"))
+}
@@ -0,0 +1,32 @@
+package types
+
+import (
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+)
+
+// NOTE: We can't use 1 since that error code is reserved for internal errors.
+
+var (
+	// ErrConversionsDisabled returns an error if the EnableConversions parameter is false.
+	ErrConversionsDisabled = sdkerrors.Register(ModuleName, 2, "token conversions are disabled")
+
+	// ErrTokenPairNotFound returns an error if the token pair isn't registered.
+	ErrTokenPairNotFound = sdkerrors.Register(ModuleName, 3, "token pair not found")
+
+	// ErrTokenPairAlreadyExists returns an error if the denomination or the ERC20
+	// contract are already registered.
+	ErrTokenPairAlreadyExists = sdkerrors.Register(ModuleName, 4, "token pair already exists")
+
+	// ErrTokenPairDisabled returns an error if the conversions of the token pair are disabled.
+	ErrTokenPairDisabled = sdkerrors.Register(ModuleName, 5, "token pair conversions are disabled")
+
+	// ErrInvalidTokenPair returns an error if a token pair field is invalid.
+	ErrInvalidTokenPair = sdkerrors.Register(ModuleName, 6, "invalid token pair")
+
+	// ErrEVMCall returns an error if the call to the ERC20 contract fails.
+	ErrEVMCall = sdkerrors.Register(ModuleName, 7, "ERC20 contract call failed")
+
+	// ErrInvalidConversion returns an error if the ERC20 balances don't match the
+	// converted amount.
+	ErrInvalidConversion = sdkerrors.Register(ModuleName, 8, "invalid token conversion")
+)
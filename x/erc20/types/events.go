@@ -0,0 +1,16 @@
+package types
+
+// erc20 module events
+const (
+	EventTypeConvertCoin   = TypeMsgConvertCoin
+	EventTypeConvertERC20  = TypeMsgConvertERC20
+	EventTypeRegisterCoin  = "register_coin"
+	EventTypeRegisterERC20 = "register_erc20"
+	EventTypeTogglePair    = "toggle_token_pair"
+
+	AttributeKeyCosmosCoin = "cosmos_coin"
+	AttributeKeyERC20Token = "erc20_token"
+	AttributeKeyReceiver   = "receiver"
+	AttributeKeyEnabled    = "enabled"
+	AttributeValueCategory = ModuleName
+)
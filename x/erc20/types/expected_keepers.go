@@ -0,0 +1,30 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	supplyexported "github.com/cosmos/cosmos-sdk/x/supply/exported"
+
+	evmtypes "github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// SupplyKeeper defines the expected supply keeper interface
+type SupplyKeeper interface {
+	GetModuleAddress(moduleName string) sdk.AccAddress
+	GetSupply(ctx sdk.Context) supplyexported.SupplyI
+	MintCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	BurnCoins(ctx sdk.Context, moduleName string, amt sdk.Coins) error
+	SendCoinsFromAccountToModule(ctx sdk.Context, senderAddr sdk.AccAddress, recipientModule string, amt sdk.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// EVMKeeper defines the expected evm keeper interface
+type EVMKeeper interface {
+	GetParams(ctx sdk.Context) evmtypes.Params
+	GetCode(ctx sdk.Context, addr ethcmn.Address) []byte
+	GetNonce(ctx sdk.Context, addr ethcmn.Address) uint64
+	ExecuteAtomic(ctx sdk.Context, fn func(ctx sdk.Context) error) error
+	CallContract(ctx sdk.Context, from, contract ethcmn.Address, data []byte, gasLimit uint64) (*evmtypes.ExecutionResult, error)
+	DeployContract(ctx sdk.Context, from ethcmn.Address, bytecode []byte) (ethcmn.Address, error)
+}
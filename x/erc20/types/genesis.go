@@ -0,0 +1,56 @@
+package types
+
+import (
+	"fmt"
+	"strings"
+)
+
+// GenesisState defines the erc20 module genesis state
+type GenesisState struct {
+	Params     Params      `json:"params" yaml:"params"`
+	TokenPairs []TokenPair `json:"token_pairs" yaml:"token_pairs"`
+}
+
+// NewGenesisState creates a new GenesisState instance
+func NewGenesisState(params Params, pairs []TokenPair) GenesisState {
+	return GenesisState{
+		Params:     params,
+		TokenPairs: pairs,
+	}
+}
+
+// DefaultGenesisState sets default erc20 genesis state with no token pairs and
+// default params.
+func DefaultGenesisState() GenesisState {
+	return GenesisState{
+		Params:     DefaultParams(),
+		TokenPairs: []TokenPair{},
+	}
+}
+
+// Validate performs basic genesis state validation returning an error upon any
+// failure.
+func (gs GenesisState) Validate() error {
+	seenContracts := make(map[string]bool)
+	seenDenoms := make(map[string]bool)
+
+	for _, pair := range gs.TokenPairs {
+		if err := pair.Validate(); err != nil {
+			return fmt.Errorf("invalid token pair %s: %w", pair.Denom, err)
+		}
+
+		contract := strings.ToLower(pair.ERC20Address)
+		if seenContracts[contract] {
+			return fmt.Errorf("duplicated token pair ERC20 contract %s", pair.ERC20Address)
+		}
+
+		if seenDenoms[pair.Denom] {
+			return fmt.Errorf("duplicated token pair denomination %s", pair.Denom)
+		}
+
+		seenContracts[contract] = true
+		seenDenoms[pair.Denom] = true
+	}
+
+	return gs.Params.Validate()
+}
@@ -0,0 +1,94 @@
+package types
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestValidateGenesis(t *testing.T) {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+
+	testCases := []struct {
+		name     string
+		genState GenesisState
+		expPass  bool
+	}{
+		{"default", DefaultGenesisState(), true},
+		{
+			"valid",
+			NewGenesisState(DefaultParams(), []TokenPair{
+				NewTokenPair(ERC20ContractAddress("acoin"), "acoin", OwnerModule),
+				NewTokenPair(contract, "erc20coin", OwnerExternal),
+			}),
+			true,
+		},
+		{
+			"invalid pair",
+			NewGenesisState(DefaultParams(), []TokenPair{
+				NewTokenPair(contract, "erc20coin", Owner("other")),
+			}),
+			false,
+		},
+		{
+			"duplicated contract",
+			NewGenesisState(DefaultParams(), []TokenPair{
+				NewTokenPair(contract, "erc20coin", OwnerExternal),
+				{ERC20Address: strings.ToLower(contract.String()), Denom: "erc20other", Enabled: true, ContractOwner: OwnerExternal},
+			}),
+			false,
+		},
+		{
+			"duplicated denom",
+			NewGenesisState(DefaultParams(), []TokenPair{
+				NewTokenPair(ERC20ContractAddress("acoin"), "acoin", OwnerModule),
+				NewTokenPair(contract, "acoin", OwnerExternal),
+			}),
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.genState.Validate()
+		if tc.expPass {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+}
+
+func TestTokenPairValidate(t *testing.T) {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+
+	testCases := []struct {
+		name    string
+		pair    TokenPair
+		expPass bool
+	}{
+		{"module owned", NewTokenPair(contract, "acoin", OwnerModule), true},
+		{"externally owned", NewTokenPair(contract, "acoin", OwnerExternal), true},
+		{"invalid address", TokenPair{ERC20Address: "contract", Denom: "acoin", ContractOwner: OwnerModule}, false},
+		{"zero address", NewTokenPair(ethcmn.Address{}, "acoin", OwnerModule), false},
+		{"invalid denom", NewTokenPair(contract, "a", OwnerModule), false},
+		{"invalid owner", NewTokenPair(contract, "acoin", Owner("")), false},
+	}
+
+	for _, tc := range testCases {
+		err := tc.pair.Validate()
+		if tc.expPass {
+			require.NoError(t, err, tc.name)
+		} else {
+			require.Error(t, err, tc.name)
+		}
+	}
+
+	pair := NewTokenPair(contract, "acoin", OwnerModule)
+	require.True(t, pair.Enabled)
+	require.True(t, pair.IsNativeCoin())
+	require.False(t, pair.IsNativeERC20())
+	require.Equal(t, contract, pair.GetERC20Contract())
+}
@@ -0,0 +1,34 @@
+package types
+
+import (
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+const (
+	// ModuleName string name of module
+	ModuleName = "erc20"
+
+	// StoreKey key for the token pairs
+	StoreKey = ModuleName
+
+	// RouterKey uses module name for routing
+	RouterKey = ModuleName
+
+	// QuerierRoute uses module name for queries
+	QuerierRoute = ModuleName
+)
+
+// ModuleEVMAddress is the address used by the module on the EVM. It owns the ERC20
+// contracts deployed by the module and escrows the ERC20 tokens converted into
+// coins.
+//
+// NOTE: the module account address can't be used as the EVM state objects are
+// EthAccounts.
+var ModuleEVMAddress = ethcmn.BytesToAddress(ethcrypto.Keccak256([]byte(ModuleName))[12:])
+
+// KVStore key prefixes
+var (
+	KeyPrefixTokenPair        = []byte{0x01}
+	KeyPrefixTokenPairByDenom = []byte{0x02}
+)
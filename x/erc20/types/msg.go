@@ -0,0 +1,150 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	_ sdk.Msg = MsgConvertCoin{}
+	_ sdk.Msg = MsgConvertERC20{}
+)
+
+// message type and route constants
+const (
+	// TypeMsgConvertCoin defines the type string of a coin to ERC20 conversion
+	TypeMsgConvertCoin = "convert_coin"
+	// TypeMsgConvertERC20 defines the type string of an ERC20 to coin conversion
+	TypeMsgConvertERC20 = "convert_erc20"
+)
+
+// MsgConvertCoin converts a Cosmos coin into ERC20 tokens of its token pair
+type MsgConvertCoin struct {
+	// Coin to be converted
+	Coin sdk.Coin `json:"coin" yaml:"coin"`
+	// Receiver is the hex address that receives the ERC20 tokens
+	Receiver string `json:"receiver" yaml:"receiver"`
+	// Sender is the address of the coin owner
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+}
+
+// NewMsgConvertCoin returns a new MsgConvertCoin instance
+func NewMsgConvertCoin(coin sdk.Coin, receiver ethcmn.Address, sender sdk.AccAddress) MsgConvertCoin {
+	return MsgConvertCoin{
+		Coin:     coin,
+		Receiver: receiver.String(),
+		Sender:   sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgConvertCoin) Route() string { return RouterKey }
+
+// Type returns the action of the message
+func (msg MsgConvertCoin) Type() string { return TypeMsgConvertCoin }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgConvertCoin) ValidateBasic() error {
+	if !msg.Coin.IsValid() || !msg.Coin.IsPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "invalid coin %s", msg.Coin)
+	}
+
+	if err := validateHexAddress(msg.Receiver); err != nil {
+		return sdkerrors.Wrap(err, "invalid receiver address")
+	}
+
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgConvertCoin) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgConvertCoin) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// MsgConvertERC20 converts ERC20 tokens into the Cosmos coin of their token pair
+type MsgConvertERC20 struct {
+	// ContractAddress is the hex address of the ERC20 contract
+	ContractAddress string `json:"contract_address" yaml:"contract_address"`
+	// Amount of ERC20 tokens to be converted
+	Amount sdk.Int `json:"amount" yaml:"amount"`
+	// Receiver is the address that receives the coins
+	Receiver sdk.AccAddress `json:"receiver" yaml:"receiver"`
+	// Sender is the address of the ERC20 tokens owner
+	Sender sdk.AccAddress `json:"sender" yaml:"sender"`
+}
+
+// NewMsgConvertERC20 returns a new MsgConvertERC20 instance
+func NewMsgConvertERC20(
+	contract ethcmn.Address, amount sdk.Int, receiver, sender sdk.AccAddress,
+) MsgConvertERC20 {
+	return MsgConvertERC20{
+		ContractAddress: contract.String(),
+		Amount:          amount,
+		Receiver:        receiver,
+		Sender:          sender,
+	}
+}
+
+// Route should return the name of the module
+func (msg MsgConvertERC20) Route() string { return RouterKey }
+
+// Type returns the action of the message
+func (msg MsgConvertERC20) Type() string { return TypeMsgConvertERC20 }
+
+// ValidateBasic runs stateless checks on the message
+func (msg MsgConvertERC20) ValidateBasic() error {
+	if err := validateHexAddress(msg.ContractAddress); err != nil {
+		return sdkerrors.Wrap(err, "invalid contract address")
+	}
+
+	if msg.Amount.IsNil() || !msg.Amount.IsPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount must be positive %s", msg.Amount)
+	}
+
+	if msg.Receiver.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "receiver address cannot be empty")
+	}
+
+	if msg.Sender.Empty() {
+		return sdkerrors.Wrap(sdkerrors.ErrInvalidAddress, "sender address cannot be empty")
+	}
+
+	return nil
+}
+
+// GetSignBytes encodes the message for signing
+func (msg MsgConvertERC20) GetSignBytes() []byte {
+	return sdk.MustSortJSON(ModuleCdc.MustMarshalJSON(msg))
+}
+
+// GetSigners defines whose signature is required
+func (msg MsgConvertERC20) GetSigners() []sdk.AccAddress {
+	return []sdk.AccAddress{msg.Sender}
+}
+
+// validateHexAddress returns an error if the address isn't a valid non-zero hex
+// address.
+func validateHexAddress(address string) error {
+	if !ethcmn.IsHexAddress(address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "invalid hex address %s", address)
+	}
+
+	if ethermint.IsZeroAddress(address) {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidAddress, "address cannot be the zero address %s", address)
+	}
+
+	return nil
+}
@@ -0,0 +1,82 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestMsgConvertCoinValidateBasic(t *testing.T) {
+	receiver := ethcmn.BytesToAddress([]byte("receiver"))
+	sender := sdk.AccAddress(ethcmn.BytesToAddress([]byte("sender")).Bytes())
+
+	testCases := []struct {
+		msg     string
+		coin    sdk.Coin
+		msgFn   func(msg *MsgConvertCoin)
+		expPass bool
+	}{
+		{"valid", sdk.NewCoin("acoin", sdk.NewInt(10)), func(*MsgConvertCoin) {}, true},
+		{"zero amount", sdk.NewCoin("acoin", sdk.ZeroInt()), func(*MsgConvertCoin) {}, false},
+		{"invalid denom", sdk.Coin{Denom: "@coin", Amount: sdk.NewInt(10)}, func(*MsgConvertCoin) {}, false},
+		{"invalid receiver", sdk.NewCoin("acoin", sdk.NewInt(10)), func(msg *MsgConvertCoin) { msg.Receiver = "receiver" }, false},
+		{"zero receiver", sdk.NewCoin("acoin", sdk.NewInt(10)), func(msg *MsgConvertCoin) { msg.Receiver = ethcmn.Address{}.String() }, false},
+		{"empty sender", sdk.NewCoin("acoin", sdk.NewInt(10)), func(msg *MsgConvertCoin) { msg.Sender = nil }, false},
+	}
+
+	for _, tc := range testCases {
+		msg := NewMsgConvertCoin(tc.coin, receiver, sender)
+		tc.msgFn(&msg)
+
+		require.Equal(t, RouterKey, msg.Route())
+		require.Equal(t, TypeMsgConvertCoin, msg.Type())
+		require.Equal(t, []sdk.AccAddress{sender}, NewMsgConvertCoin(tc.coin, receiver, sender).GetSigners())
+		require.NotPanics(t, func() { _ = msg.GetSignBytes() })
+
+		if tc.expPass {
+			require.NoError(t, msg.ValidateBasic(), tc.msg)
+		} else {
+			require.Error(t, msg.ValidateBasic(), tc.msg)
+		}
+	}
+}
+
+func TestMsgConvertERC20ValidateBasic(t *testing.T) {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	account := sdk.AccAddress(ethcmn.BytesToAddress([]byte("account")).Bytes())
+
+	testCases := []struct {
+		msg     string
+		amount  sdk.Int
+		msgFn   func(msg *MsgConvertERC20)
+		expPass bool
+	}{
+		{"valid", sdk.NewInt(10), func(*MsgConvertERC20) {}, true},
+		{"zero amount", sdk.ZeroInt(), func(*MsgConvertERC20) {}, false},
+		{"nil amount", sdk.Int{}, func(*MsgConvertERC20) {}, false},
+		{"invalid contract", sdk.NewInt(10), func(msg *MsgConvertERC20) { msg.ContractAddress = "contract" }, false},
+		{"zero contract", sdk.NewInt(10), func(msg *MsgConvertERC20) { msg.ContractAddress = ethcmn.Address{}.String() }, false},
+		{"empty receiver", sdk.NewInt(10), func(msg *MsgConvertERC20) { msg.Receiver = nil }, false},
+		{"empty sender", sdk.NewInt(10), func(msg *MsgConvertERC20) { msg.Sender = nil }, false},
+	}
+
+	for _, tc := range testCases {
+		msg := NewMsgConvertERC20(contract, tc.amount, account, account)
+		tc.msgFn(&msg)
+
+		require.Equal(t, RouterKey, msg.Route())
+		require.Equal(t, TypeMsgConvertERC20, msg.Type())
+
+		if tc.expPass {
+			require.NoError(t, msg.ValidateBasic(), tc.msg)
+			require.Equal(t, []sdk.AccAddress{account}, msg.GetSigners())
+			require.NotPanics(t, func() { _ = msg.GetSignBytes() })
+		} else {
+			require.Error(t, msg.ValidateBasic(), tc.msg)
+		}
+	}
+}
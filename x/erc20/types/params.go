@@ -0,0 +1,70 @@
+package types
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	"github.com/cosmos/cosmos-sdk/x/params"
+)
+
+const (
+	// DefaultParamspace for params keeper
+	DefaultParamspace = ModuleName
+)
+
+// Parameter keys
+var (
+	ParamStoreKeyEnableConversions = []byte("EnableConversions")
+)
+
+// ParamKeyTable returns the parameter key table.
+func ParamKeyTable() params.KeyTable {
+	return params.NewKeyTable().RegisterParamSet(&Params{})
+}
+
+// Params defines the erc20 module parameters
+type Params struct {
+	// EnableConversions toggles the conversions between coins and ERC20 tokens
+	EnableConversions bool `json:"enable_conversions" yaml:"enable_conversions"`
+}
+
+// NewParams creates a new Params instance
+func NewParams(enableConversions bool) Params {
+	return Params{
+		EnableConversions: enableConversions,
+	}
+}
+
+// DefaultParams returns default erc20 parameters
+func DefaultParams() Params {
+	return Params{
+		EnableConversions: true,
+	}
+}
+
+// String implements the fmt.Stringer interface
+func (p Params) String() string {
+	out, _ := yaml.Marshal(p)
+	return string(out)
+}
+
+// ParamSetPairs returns the parameter set pairs.
+func (p *Params) ParamSetPairs() params.ParamSetPairs {
+	return params.ParamSetPairs{
+		params.NewParamSetPair(ParamStoreKeyEnableConversions, &p.EnableConversions, validateBool),
+	}
+}
+
+// Validate performs basic validation on erc20 parameters.
+func (p Params) Validate() error {
+	return validateBool(p.EnableConversions)
+}
+
+func validateBool(i interface{}) error {
+	_, ok := i.(bool)
+	if !ok {
+		return fmt.Errorf("invalid parameter type: %T", i)
+	}
+	return nil
+}
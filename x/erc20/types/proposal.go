@@ -0,0 +1,214 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// ProposalTypeRegisterCoin defines the type for a RegisterCoinProposal
+	ProposalTypeRegisterCoin = "RegisterCoin"
+	// ProposalTypeRegisterERC20 defines the type for a RegisterERC20Proposal
+	ProposalTypeRegisterERC20 = "RegisterERC20"
+	// ProposalTypeToggleTokenConversion defines the type for a ToggleTokenConversionProposal
+	ProposalTypeToggleTokenConversion = "ToggleTokenConversion"
+)
+
+// Assert the proposals implement govtypes.Content at compile-time
+var (
+	_ govtypes.Content = RegisterCoinProposal{}
+	_ govtypes.Content = RegisterERC20Proposal{}
+	_ govtypes.Content = ToggleTokenConversionProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeRegisterCoin)
+	govtypes.RegisterProposalType(ProposalTypeRegisterERC20)
+	govtypes.RegisterProposalType(ProposalTypeToggleTokenConversion)
+	govtypes.RegisterProposalTypeCodec(RegisterCoinProposal{}, "ethermint/RegisterCoinProposal")
+	govtypes.RegisterProposalTypeCodec(RegisterERC20Proposal{}, "ethermint/RegisterERC20Proposal")
+	govtypes.RegisterProposalTypeCodec(ToggleTokenConversionProposal{}, "ethermint/ToggleTokenConversionProposal")
+}
+
+// RegisterCoinProposal registers a token pair for a native Cosmos coin. The module
+// deploys a new ERC20 contract with the given metadata for the coin.
+type RegisterCoinProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	Denom       string `json:"denom" yaml:"denom"`
+	Name        string `json:"name" yaml:"name"`
+	Symbol      string `json:"symbol" yaml:"symbol"`
+	Decimals    uint8  `json:"decimals" yaml:"decimals"`
+}
+
+// NewRegisterCoinProposal creates a new RegisterCoinProposal instance.
+func NewRegisterCoinProposal(title, description, denom, name, symbol string, decimals uint8) RegisterCoinProposal {
+	return RegisterCoinProposal{
+		Title:       title,
+		Description: description,
+		Denom:       denom,
+		Name:        name,
+		Symbol:      symbol,
+		Decimals:    decimals,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (rcp RegisterCoinProposal) GetTitle() string { return rcp.Title }
+
+// GetDescription returns the description of the proposal.
+func (rcp RegisterCoinProposal) GetDescription() string { return rcp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (rcp RegisterCoinProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (rcp RegisterCoinProposal) ProposalType() string { return ProposalTypeRegisterCoin }
+
+// ValidateBasic runs basic stateless validity checks
+func (rcp RegisterCoinProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(rcp); err != nil {
+		return err
+	}
+
+	if err := sdk.ValidateDenom(rcp.Denom); err != nil {
+		return err
+	}
+
+	if strings.TrimSpace(rcp.Name) == "" {
+		return errors.New("token name cannot be blank")
+	}
+
+	if strings.TrimSpace(rcp.Symbol) == "" {
+		return errors.New("token symbol cannot be blank")
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface.
+func (rcp RegisterCoinProposal) String() string {
+	return fmt.Sprintf(`Register Coin Proposal:
+  Title:       %s
+  Description: %s
+  Denom:       %s
+  Name:        %s
+  Symbol:      %s
+  Decimals:    %d
+`, rcp.Title, rcp.Description, rcp.Denom, rcp.Name, rcp.Symbol, rcp.Decimals)
+}
+
+// RegisterERC20Proposal registers a token pair for an ERC20 contract deployed on
+// the EVM. The tokens are converted into coins of the given denomination.
+type RegisterERC20Proposal struct {
+	Title        string `json:"title" yaml:"title"`
+	Description  string `json:"description" yaml:"description"`
+	ERC20Address string `json:"erc20_address" yaml:"erc20_address"`
+	Denom        string `json:"denom" yaml:"denom"`
+}
+
+// NewRegisterERC20Proposal creates a new RegisterERC20Proposal instance.
+func NewRegisterERC20Proposal(title, description string, erc20Address ethcmn.Address, denom string) RegisterERC20Proposal {
+	return RegisterERC20Proposal{
+		Title:        title,
+		Description:  description,
+		ERC20Address: erc20Address.String(),
+		Denom:        denom,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (rep RegisterERC20Proposal) GetTitle() string { return rep.Title }
+
+// GetDescription returns the description of the proposal.
+func (rep RegisterERC20Proposal) GetDescription() string { return rep.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (rep RegisterERC20Proposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (rep RegisterERC20Proposal) ProposalType() string { return ProposalTypeRegisterERC20 }
+
+// ValidateBasic runs basic stateless validity checks
+func (rep RegisterERC20Proposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(rep); err != nil {
+		return err
+	}
+
+	if err := validateHexAddress(rep.ERC20Address); err != nil {
+		return err
+	}
+
+	return sdk.ValidateDenom(rep.Denom)
+}
+
+// String implements the Stringer interface.
+func (rep RegisterERC20Proposal) String() string {
+	return fmt.Sprintf(`Register ERC20 Proposal:
+  Title:         %s
+  Description:   %s
+  ERC20 Address: %s
+  Denom:         %s
+`, rep.Title, rep.Description, rep.ERC20Address, rep.Denom)
+}
+
+// ToggleTokenConversionProposal enables or disables the conversions of a token
+// pair.
+type ToggleTokenConversionProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	// Token is either the coin denomination or the ERC20 contract hex address of
+	// the token pair
+	Token string `json:"token" yaml:"token"`
+}
+
+// NewToggleTokenConversionProposal creates a new ToggleTokenConversionProposal instance.
+func NewToggleTokenConversionProposal(title, description, token string) ToggleTokenConversionProposal {
+	return ToggleTokenConversionProposal{
+		Title:       title,
+		Description: description,
+		Token:       token,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (ttp ToggleTokenConversionProposal) GetTitle() string { return ttp.Title }
+
+// GetDescription returns the description of the proposal.
+func (ttp ToggleTokenConversionProposal) GetDescription() string { return ttp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (ttp ToggleTokenConversionProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (ttp ToggleTokenConversionProposal) ProposalType() string {
+	return ProposalTypeToggleTokenConversion
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (ttp ToggleTokenConversionProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(ttp); err != nil {
+		return err
+	}
+
+	if ethcmn.IsHexAddress(ttp.Token) {
+		return validateHexAddress(ttp.Token)
+	}
+
+	return sdk.ValidateDenom(ttp.Token)
+}
+
+// String implements the Stringer interface.
+func (ttp ToggleTokenConversionProposal) String() string {
+	return fmt.Sprintf(`Toggle Token Conversion Proposal:
+  Title:       %s
+  Description: %s
+  Token:       %s
+`, ttp.Title, ttp.Description, ttp.Token)
+}
@@ -0,0 +1,84 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestRegisterCoinProposalValidateBasic(t *testing.T) {
+	testCases := []struct {
+		msg      string
+		proposal RegisterCoinProposal
+		expPass  bool
+	}{
+		{"valid", NewRegisterCoinProposal("title", "description", "acoin", "Coin", "COIN", 18), true},
+		{"empty title", NewRegisterCoinProposal("", "description", "acoin", "Coin", "COIN", 18), false},
+		{"invalid denom", NewRegisterCoinProposal("title", "description", "@coin", "Coin", "COIN", 18), false},
+		{"blank name", NewRegisterCoinProposal("title", "description", "acoin", " ", "COIN", 18), false},
+		{"blank symbol", NewRegisterCoinProposal("title", "description", "acoin", "Coin", "", 18), false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, RouterKey, tc.proposal.ProposalRoute())
+		require.Equal(t, ProposalTypeRegisterCoin, tc.proposal.ProposalType())
+
+		if tc.expPass {
+			require.NoError(t, tc.proposal.ValidateBasic(), tc.msg)
+		} else {
+			require.Error(t, tc.proposal.ValidateBasic(), tc.msg)
+		}
+	}
+}
+
+func TestRegisterERC20ProposalValidateBasic(t *testing.T) {
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+
+	testCases := []struct {
+		msg      string
+		proposal RegisterERC20Proposal
+		expPass  bool
+	}{
+		{"valid", NewRegisterERC20Proposal("title", "description", contract, "erc20coin"), true},
+		{"empty description", NewRegisterERC20Proposal("title", "", contract, "erc20coin"), false},
+		{"zero contract", NewRegisterERC20Proposal("title", "description", ethcmn.Address{}, "erc20coin"), false},
+		{"invalid denom", NewRegisterERC20Proposal("title", "description", contract, "erc20/coin"), false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, RouterKey, tc.proposal.ProposalRoute())
+		require.Equal(t, ProposalTypeRegisterERC20, tc.proposal.ProposalType())
+
+		if tc.expPass {
+			require.NoError(t, tc.proposal.ValidateBasic(), tc.msg)
+		} else {
+			require.Error(t, tc.proposal.ValidateBasic(), tc.msg)
+		}
+	}
+}
+
+func TestToggleTokenConversionProposalValidateBasic(t *testing.T) {
+	testCases := []struct {
+		msg      string
+		proposal ToggleTokenConversionProposal
+		expPass  bool
+	}{
+		{"valid denom", NewToggleTokenConversionProposal("title", "description", "acoin"), true},
+		{"valid contract", NewToggleTokenConversionProposal("title", "description", ethcmn.BytesToAddress([]byte("contract")).String()), true},
+		{"empty token", NewToggleTokenConversionProposal("title", "description", ""), false},
+		{"empty title", NewToggleTokenConversionProposal("", "description", "acoin"), false},
+	}
+
+	for _, tc := range testCases {
+		require.Equal(t, RouterKey, tc.proposal.ProposalRoute())
+		require.Equal(t, ProposalTypeToggleTokenConversion, tc.proposal.ProposalType())
+
+		if tc.expPass {
+			require.NoError(t, tc.proposal.ValidateBasic(), tc.msg)
+		} else {
+			require.Error(t, tc.proposal.ValidateBasic(), tc.msg)
+		}
+	}
+}
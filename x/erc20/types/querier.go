@@ -0,0 +1,8 @@
+package types
+
+// Supported endpoints
+const (
+	QueryParams     = "params"
+	QueryTokenPairs = "tokenPairs"
+	QueryTokenPair  = "tokenPair"
+)
@@ -0,0 +1,98 @@
+package types
+
+import (
+	"fmt"
+
+	"gopkg.in/yaml.v2"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// Owner defines the owner of the ERC20 contract of a token pair
+type Owner string
+
+const (
+	// OwnerModule defines the ERC20 contracts deployed by the module for a native
+	// Cosmos coin
+	OwnerModule Owner = "module"
+	// OwnerExternal defines the ERC20 contracts deployed on the EVM by an external
+	// account and adopted by the module
+	OwnerExternal Owner = "external"
+)
+
+// Validate checks that the owner is a known value.
+func (o Owner) Validate() error {
+	switch o {
+	case OwnerModule, OwnerExternal:
+		return nil
+	default:
+		return fmt.Errorf("invalid token pair owner %q", o)
+	}
+}
+
+// TokenPair defines the relation between a Cosmos coin denomination and an ERC20
+// contract on the EVM, which can be converted into each other.
+type TokenPair struct {
+	// ERC20Address is the hex address of the ERC20 contract
+	ERC20Address string `json:"erc20_address" yaml:"erc20_address"`
+	// Denom is the Cosmos coin denomination
+	Denom string `json:"denom" yaml:"denom"`
+	// Enabled toggles the conversions of the token pair
+	Enabled bool `json:"enabled" yaml:"enabled"`
+	// ContractOwner defines if the ERC20 contract is owned by the module
+	ContractOwner Owner `json:"contract_owner" yaml:"contract_owner"`
+}
+
+// NewTokenPair creates a new enabled TokenPair instance.
+func NewTokenPair(erc20Address ethcmn.Address, denom string, owner Owner) TokenPair {
+	return TokenPair{
+		ERC20Address:  erc20Address.String(),
+		Denom:         denom,
+		Enabled:       true,
+		ContractOwner: owner,
+	}
+}
+
+// GetERC20Contract returns the address of the ERC20 contract.
+func (tp TokenPair) GetERC20Contract() ethcmn.Address {
+	return ethcmn.HexToAddress(tp.ERC20Address)
+}
+
+// IsNativeCoin returns true if the pair was registered for a native Cosmos coin,
+// i.e the ERC20 contract is owned by the module.
+func (tp TokenPair) IsNativeCoin() bool {
+	return tp.ContractOwner == OwnerModule
+}
+
+// IsNativeERC20 returns true if the pair was registered for an ERC20 contract
+// deployed on the EVM.
+func (tp TokenPair) IsNativeERC20() bool {
+	return tp.ContractOwner == OwnerExternal
+}
+
+// Validate performs a basic validation of the TokenPair fields.
+func (tp TokenPair) Validate() error {
+	if !ethcmn.IsHexAddress(tp.ERC20Address) {
+		return fmt.Errorf("invalid ERC20 contract address %s", tp.ERC20Address)
+	}
+
+	if ethermint.IsZeroAddress(tp.ERC20Address) {
+		return fmt.Errorf("ERC20 contract address cannot be the zero address %s", tp.ERC20Address)
+	}
+
+	if err := sdk.ValidateDenom(tp.Denom); err != nil {
+		return err
+	}
+
+	return tp.ContractOwner.Validate()
+}
+
+// String implements the fmt.Stringer interface
+func (tp TokenPair) String() string {
+	out, _ := yaml.Marshal(tp)
+	return string(out)
+}
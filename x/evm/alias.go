@@ -11,16 +11,19 @@ const (
 	StoreKey          = types.StoreKey
 	RouterKey         = types.RouterKey
 	DefaultParamspace = types.DefaultParamspace
+	ReserveName       = types.ReserveName
 )
 
 // nolint
 var (
-	NewKeeper = keeper.NewKeeper
-	TxDecoder = types.TxDecoder
+	NewKeeper        = keeper.NewKeeper
+	TxDecoder        = types.TxDecoder
+	NewMultiEvmHooks = types.NewMultiEvmHooks
 )
 
 //nolint
 type (
 	Keeper       = keeper.Keeper
 	GenesisState = types.GenesisState
+	EvmHooks     = types.EvmHooks
 )
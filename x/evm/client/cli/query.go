@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
 
 	"github.com/cosmos/cosmos-sdk/client"
 	"github.com/cosmos/cosmos-sdk/client/context"
@@ -14,6 +15,11 @@ import (
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
+const (
+	flagStartKey = "start-key"
+	flagLimit    = "limit"
+)
+
 // GetQueryCmd defines evm module queries through the cli
 func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 	evmQueryCmd := &cobra.Command{
@@ -25,6 +31,7 @@ func GetQueryCmd(moduleName string, cdc *codec.Codec) *cobra.Command {
 	}
 	evmQueryCmd.AddCommand(flags.GetCommands(
 		GetCmdGetStorageAt(moduleName, cdc),
+		GetCmdGetStorageRange(moduleName, cdc),
 		GetCmdGetCode(moduleName, cdc),
 	)...)
 	return evmQueryCmd
@@ -59,6 +66,43 @@ func GetCmdGetStorageAt(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	}
 }
 
+// GetCmdGetStorageRange queries a range of the storage slots of an account
+func GetCmdGetStorageRange(queryRoute string, cdc *codec.Codec) *cobra.Command {
+	cmd := &cobra.Command{
+		Use:   "storage-range [account]",
+		Short: "Gets a range of the storage slots of an account",
+		Long: `Gets at most --limit storage slots of an account, starting from the slot stored under --start-key.
+The slots are keyed and ordered by the hash of the address and the slot key they're stored under. The
+next_key of the output is the --start-key of the next range, and is empty at the end of the storage.`,
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			clientCtx := context.NewCLIContext().WithCodec(cdc)
+
+			account, err := accountToHex(args[0])
+			if err != nil {
+				return errors.Wrap(err, "could not parse account address")
+			}
+
+			startKey := formatKeyToHash(viper.GetString(flagStartKey))
+
+			res, _, err := clientCtx.Query(
+				fmt.Sprintf("custom/%s/%s/%s/%s/%d", queryRoute, types.QueryStorageRange, account, startKey, viper.GetUint(flagLimit)))
+
+			if err != nil {
+				return fmt.Errorf("could not resolve: %s", err)
+			}
+
+			var out types.QueryResStorageRange
+			cdc.MustUnmarshalJSON(res, &out)
+			return clientCtx.PrintOutput(out)
+		},
+	}
+
+	cmd.Flags().String(flagStartKey, "0x0", "Key of the first storage slot of the range")
+	cmd.Flags().Uint(flagLimit, types.MaxStorageRangeLimit, fmt.Sprintf("Maximum number of storage slots to return (at most %d)", types.MaxStorageRangeLimit))
+	return cmd
+}
+
 // GetCmdGetCode queries the code field of a given address
 func GetCmdGetCode(queryRoute string, cdc *codec.Codec) *cobra.Command {
 	return &cobra.Command{
@@ -0,0 +1,422 @@
+package cli
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/spf13/cobra"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	"github.com/cosmos/cosmos-sdk/codec"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/version"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// ChainConfigUpgradeProposalJSON defines a ChainConfigUpgradeProposal with a deposit
+type ChainConfigUpgradeProposalJSON struct {
+	Title       string            `json:"title" yaml:"title"`
+	Description string            `json:"description" yaml:"description"`
+	ChainConfig types.ChainConfig `json:"chain_config" yaml:"chain_config"`
+	Deposit     sdk.Coins         `json:"deposit" yaml:"deposit"`
+}
+
+// RegisterSystemContractProposalJSON defines a RegisterSystemContractProposal with a deposit
+type RegisterSystemContractProposalJSON struct {
+	Title          string               `json:"title" yaml:"title"`
+	Description    string               `json:"description" yaml:"description"`
+	SystemContract types.SystemContract `json:"system_contract" yaml:"system_contract"`
+	Deposit        sdk.Coins            `json:"deposit" yaml:"deposit"`
+}
+
+// RemoveSystemContractProposalJSON defines a RemoveSystemContractProposal with a deposit
+type RemoveSystemContractProposalJSON struct {
+	Title       string    `json:"title" yaml:"title"`
+	Description string    `json:"description" yaml:"description"`
+	Address     string    `json:"address" yaml:"address"`
+	Deposit     sdk.Coins `json:"deposit" yaml:"deposit"`
+}
+
+// DeployContractProposalJSON defines a DeployContractProposal with a deposit
+type DeployContractProposalJSON struct {
+	Title       string        `json:"title" yaml:"title"`
+	Description string        `json:"description" yaml:"description"`
+	Salt        string        `json:"salt" yaml:"salt"`
+	Code        string        `json:"code" yaml:"code"`
+	Storage     types.Storage `json:"storage" yaml:"storage"`
+	Deposit     sdk.Coins     `json:"deposit" yaml:"deposit"`
+}
+
+// PatchContractProposalJSON defines a PatchContractProposal with a deposit
+type PatchContractProposalJSON struct {
+	Title       string        `json:"title" yaml:"title"`
+	Description string        `json:"description" yaml:"description"`
+	Address     string        `json:"address" yaml:"address"`
+	Code        string        `json:"code" yaml:"code"`
+	Storage     types.Storage `json:"storage" yaml:"storage"`
+	Deposit     sdk.Coins     `json:"deposit" yaml:"deposit"`
+}
+
+// GetCmdSubmitChainConfigUpgradeProposal implements the command to submit a
+// chain-config-upgrade proposal
+func GetCmdSubmitChainConfigUpgradeProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "chain-config-upgrade [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to schedule EVM hard forks",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to replace the EVM chain config along with an initial deposit.
+The forks that are already active can't be modified and the updated fork blocks must be
+future block heights. A negative block disables the fork. The proposal details must be
+supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal chain-config-upgrade <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Enable London",
+  "description": "Activate the London EIPs at block 1000000",
+  "chain_config": {
+    "homestead_block": "0",
+    "dao_fork_block": "0",
+    "dao_fork_support": true,
+    "eip150_block": "0",
+    "eip150_hash": "0x0000000000000000000000000000000000000000000000000000000000000000",
+    "eip155_block": "0",
+    "eip158_block": "0",
+    "byzantium_block": "0",
+    "constantinople_block": "0",
+    "petersburg_block": "0",
+    "istanbul_block": "0",
+    "muir_glacier_block": "0",
+    "yoloV2_block": "-1",
+    "ewasm_block": "-1",
+    "berlin_block": "0",
+    "london_block": "1000000"
+  },
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal ChainConfigUpgradeProposalJSON
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewChainConfigUpgradeProposal(proposal.Title, proposal.Description, proposal.ChainConfig)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitRegisterSystemContractProposal implements the command to submit a
+// register-system-contract proposal
+func GetCmdSubmitRegisterSystemContractProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "register-system-contract [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to call a contract on every block",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to register a system contract along with an initial deposit.
+The contract is called by the EVM module on the begin_block or end_block hook of the
+heights that are a multiple of the interval, with the given input and gas limit. The
+caller is the %s address. Registering an existing system contract replaces
+its settings. The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal register-system-contract <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Register the rewards distributor",
+  "description": "Call distribute() at the end of every 100 blocks",
+  "system_contract": {
+    "address": "0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0",
+    "hook": "end_block",
+    "interval": "100",
+    "input": "0xe4fc6b6d",
+    "gas_limit": "500000"
+  },
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				types.SystemContractCaller.String(), version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal RegisterSystemContractProposalJSON
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			content := types.NewRegisterSystemContractProposal(proposal.Title, proposal.Description, proposal.SystemContract)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitRemoveSystemContractProposal implements the command to submit a
+// remove-system-contract proposal
+func GetCmdSubmitRemoveSystemContractProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "remove-system-contract [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to stop calling a system contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to remove a system contract along with an initial deposit.
+The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal remove-system-contract <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Remove the rewards distributor",
+  "description": "Stop calling the rewards distributor",
+  "address": "0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0",
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal RemoveSystemContractProposalJSON
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			if !ethcmn.IsHexAddress(proposal.Address) {
+				return fmt.Errorf("invalid system contract address %q", proposal.Address)
+			}
+
+			content := types.NewRemoveSystemContractProposal(
+				proposal.Title, proposal.Description, ethcmn.HexToAddress(proposal.Address),
+			)
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitDeployContractProposal implements the command to submit a
+// deploy-contract proposal
+func GetCmdSubmitDeployContractProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "deploy-contract [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to deploy a contract from the EVM module",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to deploy a contract along with an initial deposit.
+The runtime code and the initial storage are set directly, without executing a
+constructor. As for CREATE2, the contract address is derived from the %s
+module address, the salt and the code hash, and it's printed before the proposal is
+submitted. The proposal details must be supplied via a JSON file.
+
+Example:
+$ %s tx gov submit-proposal deploy-contract <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Deploy the fee collector",
+  "description": "Deploy the fee collector contract owned by the community pool",
+  "salt": "0x0000000000000000000000000000000000000000000000000000000000000001",
+  "code": "0x6080604052...",
+  "storage": [
+    {
+      "key": "0x0000000000000000000000000000000000000000000000000000000000000000",
+      "value": "0x000000000000000000000000756f45e3fa69347a9a973a725e3c98bc4db0b5a0"
+    }
+  ],
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				types.ModuleAddress.String(), version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal DeployContractProposalJSON
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			content := types.DeployContractProposal{
+				Title:       proposal.Title,
+				Description: proposal.Description,
+				Salt:        proposal.Salt,
+				Code:        proposal.Code,
+				Storage:     proposal.Storage,
+			}
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			cmd.PrintErrf("contract address: %s\n", content.ContractAddress().String())
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
+
+// GetCmdSubmitPatchContractProposal implements the command to submit a
+// patch-contract proposal
+func GetCmdSubmitPatchContractProposal(cdc *codec.Codec) *cobra.Command {
+	return &cobra.Command{
+		Use:   "patch-contract [proposal-file]",
+		Args:  cobra.ExactArgs(1),
+		Short: "Submit a proposal to patch the code or storage of a contract",
+		Long: strings.TrimSpace(
+			fmt.Sprintf(`Submit a proposal to patch an existing contract along with an initial deposit.
+The runtime code is replaced if the code field isn't empty, and the given storage slots
+are set. A zero value deletes the slot. The proposal details must be supplied via a
+JSON file.
+
+Example:
+$ %s tx gov submit-proposal patch-contract <path/to/proposal.json> --from=<key_or_address>
+
+Where proposal.json contains:
+
+{
+  "title": "Unpause the bridge",
+  "description": "Reset the paused flag of the bridge contract",
+  "address": "0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0",
+  "code": "",
+  "storage": [
+    {
+      "key": "0x0000000000000000000000000000000000000000000000000000000000000003",
+      "value": "0x0000000000000000000000000000000000000000000000000000000000000000"
+    }
+  ],
+  "deposit": [
+    {
+      "denom": "aphoton",
+      "amount": "10000"
+    }
+  ]
+}
+`,
+				version.ClientName,
+			),
+		),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			inBuf := bufio.NewReader(cmd.InOrStdin())
+			txBldr := auth.NewTxBuilderFromCLI(inBuf).WithTxEncoder(utils.GetTxEncoder(cdc))
+			cliCtx := context.NewCLIContextWithInput(inBuf).WithCodec(cdc)
+
+			contents, err := ioutil.ReadFile(args[0])
+			if err != nil {
+				return err
+			}
+
+			var proposal PatchContractProposalJSON
+			if err := cdc.UnmarshalJSON(contents, &proposal); err != nil {
+				return err
+			}
+
+			content := types.PatchContractProposal{
+				Title:       proposal.Title,
+				Description: proposal.Description,
+				Address:     proposal.Address,
+				Code:        proposal.Code,
+				Storage:     proposal.Storage,
+			}
+
+			msg := gov.NewMsgSubmitProposal(content, proposal.Deposit, cliCtx.GetFromAddress())
+			if err := msg.ValidateBasic(); err != nil {
+				return err
+			}
+
+			return utils.GenerateOrBroadcastMsgs(cliCtx, txBldr, []sdk.Msg{msg})
+		},
+	}
+}
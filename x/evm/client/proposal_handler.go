@@ -0,0 +1,27 @@
+package client
+
+import (
+	govclient "github.com/cosmos/cosmos-sdk/x/gov/client"
+
+	"github.com/cosmos/ethermint/x/evm/client/cli"
+	"github.com/cosmos/ethermint/x/evm/client/rest"
+)
+
+// evm module governance proposal handlers
+var (
+	ChainConfigUpgradeProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitChainConfigUpgradeProposal, rest.ChainConfigUpgradeProposalRESTHandler,
+	)
+	RegisterSystemContractProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitRegisterSystemContractProposal, rest.RegisterSystemContractProposalRESTHandler,
+	)
+	RemoveSystemContractProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitRemoveSystemContractProposal, rest.RemoveSystemContractProposalRESTHandler,
+	)
+	DeployContractProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitDeployContractProposal, rest.DeployContractProposalRESTHandler,
+	)
+	PatchContractProposalHandler = govclient.NewProposalHandler(
+		cli.GetCmdSubmitPatchContractProposal, rest.PatchContractProposalRESTHandler,
+	)
+)
@@ -0,0 +1,258 @@
+package rest
+
+import (
+	"fmt"
+	"net/http"
+
+	"github.com/cosmos/cosmos-sdk/client/context"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/types/rest"
+	"github.com/cosmos/cosmos-sdk/x/auth/client/utils"
+	"github.com/cosmos/cosmos-sdk/x/gov"
+	govrest "github.com/cosmos/cosmos-sdk/x/gov/client/rest"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// ChainConfigUpgradeProposalReq defines a chain config upgrade proposal request body.
+type ChainConfigUpgradeProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string            `json:"title" yaml:"title"`
+	Description string            `json:"description" yaml:"description"`
+	ChainConfig types.ChainConfig `json:"chain_config" yaml:"chain_config"`
+	Proposer    sdk.AccAddress    `json:"proposer" yaml:"proposer"`
+	Deposit     sdk.Coins         `json:"deposit" yaml:"deposit"`
+}
+
+// RegisterSystemContractProposalReq defines a register system contract proposal request body.
+type RegisterSystemContractProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title          string               `json:"title" yaml:"title"`
+	Description    string               `json:"description" yaml:"description"`
+	SystemContract types.SystemContract `json:"system_contract" yaml:"system_contract"`
+	Proposer       sdk.AccAddress       `json:"proposer" yaml:"proposer"`
+	Deposit        sdk.Coins            `json:"deposit" yaml:"deposit"`
+}
+
+// RemoveSystemContractProposalReq defines a remove system contract proposal request body.
+type RemoveSystemContractProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Address     string         `json:"address" yaml:"address"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+}
+
+// DeployContractProposalReq defines a deploy contract proposal request body.
+type DeployContractProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Salt        string         `json:"salt" yaml:"salt"`
+	Code        string         `json:"code" yaml:"code"`
+	Storage     types.Storage  `json:"storage" yaml:"storage"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+}
+
+// PatchContractProposalReq defines a patch contract proposal request body.
+type PatchContractProposalReq struct {
+	BaseReq rest.BaseReq `json:"base_req" yaml:"base_req"`
+
+	Title       string         `json:"title" yaml:"title"`
+	Description string         `json:"description" yaml:"description"`
+	Address     string         `json:"address" yaml:"address"`
+	Code        string         `json:"code" yaml:"code"`
+	Storage     types.Storage  `json:"storage" yaml:"storage"`
+	Proposer    sdk.AccAddress `json:"proposer" yaml:"proposer"`
+	Deposit     sdk.Coins      `json:"deposit" yaml:"deposit"`
+}
+
+// ChainConfigUpgradeProposalRESTHandler returns the chain config upgrade proposal
+// REST handler
+func ChainConfigUpgradeProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "chain_config_upgrade",
+		Handler:  postChainConfigUpgradeProposalHandlerFn(cliCtx),
+	}
+}
+
+func postChainConfigUpgradeProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req ChainConfigUpgradeProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewChainConfigUpgradeProposal(req.Title, req.Description, req.ChainConfig)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// RegisterSystemContractProposalRESTHandler returns the register system contract
+// proposal REST handler
+func RegisterSystemContractProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "register_system_contract",
+		Handler:  postRegisterSystemContractProposalHandlerFn(cliCtx),
+	}
+}
+
+func postRegisterSystemContractProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RegisterSystemContractProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.NewRegisterSystemContractProposal(req.Title, req.Description, req.SystemContract)
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// RemoveSystemContractProposalRESTHandler returns the remove system contract
+// proposal REST handler
+func RemoveSystemContractProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "remove_system_contract",
+		Handler:  postRemoveSystemContractProposalHandlerFn(cliCtx),
+	}
+}
+
+func postRemoveSystemContractProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req RemoveSystemContractProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		if !ethcmn.IsHexAddress(req.Address) {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, fmt.Sprintf("invalid system contract address %q", req.Address))
+			return
+		}
+
+		content := types.NewRemoveSystemContractProposal(req.Title, req.Description, ethcmn.HexToAddress(req.Address))
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// DeployContractProposalRESTHandler returns the deploy contract proposal REST
+// handler
+func DeployContractProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "deploy_contract",
+		Handler:  postDeployContractProposalHandlerFn(cliCtx),
+	}
+}
+
+func postDeployContractProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req DeployContractProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.DeployContractProposal{
+			Title:       req.Title,
+			Description: req.Description,
+			Salt:        req.Salt,
+			Code:        req.Code,
+			Storage:     req.Storage,
+		}
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
+
+// PatchContractProposalRESTHandler returns the patch contract proposal REST
+// handler
+func PatchContractProposalRESTHandler(cliCtx context.CLIContext) govrest.ProposalRESTHandler {
+	return govrest.ProposalRESTHandler{
+		SubRoute: "patch_contract",
+		Handler:  postPatchContractProposalHandlerFn(cliCtx),
+	}
+}
+
+func postPatchContractProposalHandlerFn(cliCtx context.CLIContext) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var req PatchContractProposalReq
+		if !rest.ReadRESTReq(w, r, cliCtx.Codec, &req) {
+			return
+		}
+
+		req.BaseReq = req.BaseReq.Sanitize()
+		if !req.BaseReq.ValidateBasic(w) {
+			return
+		}
+
+		content := types.PatchContractProposal{
+			Title:       req.Title,
+			Description: req.Description,
+			Address:     req.Address,
+			Code:        req.Code,
+			Storage:     req.Storage,
+		}
+
+		msg := gov.NewMsgSubmitProposal(content, req.Deposit, req.Proposer)
+		if err := msg.ValidateBasic(); err != nil {
+			rest.WriteErrorResponse(w, http.StatusBadRequest, err.Error())
+			return
+		}
+
+		utils.WriteGenerateStdTxResponse(w, cliCtx, req.BaseReq, []sdk.Msg{msg})
+	}
+}
@@ -2,9 +2,11 @@ package evm
 
 import (
 	"fmt"
+	"math/big"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 
@@ -24,7 +26,14 @@ func InitGenesis(
 ) []abci.ValidatorUpdate {
 
 	k.SetParams(ctx, data.Params)
-	evmDenom := data.Params.EvmDenom
+
+	// the reserve must hold the whole coins backing the dust of the genesis accounts
+	reserveBalance := sdk.ZeroInt()
+	if reserve := accountKeeper.GetAccount(ctx, supply.NewModuleAddress(types.ReserveName)); reserve != nil {
+		reserveBalance = reserve.GetCoins().AmountOf(data.Params.EvmDenom)
+	}
+
+	totalDust := new(big.Int)
 
 	for _, account := range data.Accounts {
 		address := ethcmn.HexToAddress(account.Address)
@@ -44,10 +53,16 @@ func InitGenesis(
 			)
 		}
 
-		evmBalance := acc.GetCoins().AmountOf(evmDenom)
+		dust, err := account.GetDust()
+		if err != nil {
+			panic(err)
+		}
+
+		totalDust.Add(totalDust, dust)
+		evmBalance := data.Params.ToEVMAmount(acc.GetCoins().AmountOf(data.Params.EvmDenom))
 
 		k.SetNonce(ctx, address, acc.GetSequence())
-		k.SetBalance(ctx, address, evmBalance.BigInt())
+		k.SetBalance(ctx, address, evmBalance.Add(evmBalance, dust))
 		k.SetCode(ctx, address, ethcmn.Hex2Bytes(account.Code))
 
 		for _, storage := range account.Storage {
@@ -55,26 +70,25 @@ func InitGenesis(
 		}
 	}
 
-	var err error
+	if expected := data.Params.FromEVMAmountCeil(totalDust); !reserveBalance.Equal(expected) {
+		panic(
+			fmt.Errorf("%s account must hold %s%s to back the dust of the genesis accounts, got %s%s",
+				types.ReserveName, expected, data.Params.EvmDenom, reserveBalance, data.Params.EvmDenom,
+			),
+		)
+	}
+
 	for _, txLog := range data.TxsLogs {
-		if err = k.SetLogs(ctx, ethcmn.HexToHash(txLog.Hash), txLog.Logs); err != nil {
+		if err := k.SetLogs(ctx, ethcmn.HexToHash(txLog.Hash), txLog.Logs); err != nil {
 			panic(err)
 		}
 	}
 
-	k.SetChainConfig(ctx, data.ChainConfig)
-
-	// set state objects and code to store
-	_, err = k.Commit(ctx, false)
-	if err != nil {
-		panic(err)
-	}
+	// disable the forks that are missing on genesis files exported by previous versions
+	k.SetChainConfig(ctx, data.ChainConfig.Migrate())
 
-	// set storage to store
-	// NOTE: don't delete empty object to prevent import-export simulation failure
-	err = k.Finalise(ctx, false)
-	if err != nil {
-		panic(err)
+	for _, sc := range data.SystemContracts {
+		k.SetSystemContract(ctx, sc)
 	}
 
 	return []abci.ValidatorUpdate{}
@@ -84,6 +98,7 @@ func InitGenesis(
 func ExportGenesis(ctx sdk.Context, k keeper.Keeper, ak types.AccountKeeper) GenesisState {
 	// nolint: prealloc
 	var ethGenAccounts []types.GenesisAccount
+	params := k.GetParams(ctx)
 	ak.IterateAccounts(ctx, func(account authexported.Account) bool {
 		ethAccount, ok := account.(*ethermint.EthAccount)
 		if !ok {
@@ -104,6 +119,10 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper, ak types.AccountKeeper) Gen
 			Storage: storage,
 		}
 
+		if _, dust := params.FromEVMAmount(k.GetBalance(ctx, addr)); dust.Sign() != 0 {
+			genAccount.Dust = dust.String()
+		}
+
 		ethGenAccounts = append(ethGenAccounts, genAccount)
 		return false
 	})
@@ -111,9 +130,10 @@ func ExportGenesis(ctx sdk.Context, k keeper.Keeper, ak types.AccountKeeper) Gen
 	config, _ := k.GetChainConfig(ctx)
 
 	return GenesisState{
-		Accounts:    ethGenAccounts,
-		TxsLogs:     k.GetAllTxLogs(ctx),
-		ChainConfig: config,
-		Params:      k.GetParams(ctx),
+		Accounts:        ethGenAccounts,
+		TxsLogs:         k.GetAllTxLogs(ctx),
+		ChainConfig:     config,
+		Params:          params,
+		SystemContracts: k.GetSystemContracts(ctx),
 	}
 }
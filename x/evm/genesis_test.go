@@ -1,6 +1,9 @@
 package evm_test
 
 import (
+	"bytes"
+	"math/big"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
 
@@ -22,12 +25,28 @@ func (suite *EvmTestSuite) TestExportImport() {
 	_ = evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, suite.app.AccountKeeper, genState)
 }
 
+func (suite *EvmTestSuite) TestExportImportSystemContracts() {
+	sc := types.NewSystemContract(ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0"), types.SystemContractHookBeginBlock, 5, []byte{0x01}, 100000)
+	suite.app.EvmKeeper.SetSystemContract(suite.ctx, sc)
+
+	genState := evm.ExportGenesis(suite.ctx, *suite.app.EvmKeeper, suite.app.AccountKeeper)
+	suite.Require().Equal([]types.SystemContract{sc}, genState.SystemContracts)
+
+	suite.SetupTest() // reset
+
+	_ = evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, suite.app.AccountKeeper, genState)
+	suite.Require().Equal([]types.SystemContract{sc}, suite.app.EvmKeeper.GetSystemContracts(suite.ctx))
+}
+
 func (suite *EvmTestSuite) TestInitGenesis() {
 	privkey, err := ethsecp256k1.GenerateKey()
 	suite.Require().NoError(err)
 
 	address := ethcmn.HexToAddress(privkey.PubKey().Address().String())
 
+	dustParams := types.DefaultParams()
+	dustParams.EvmDenomDecimals = 6
+
 	testCases := []struct {
 		name     string
 		malleate func()
@@ -91,6 +110,47 @@ func (suite *EvmTestSuite) TestInitGenesis() {
 			},
 			true,
 		},
+		{
+			"dust backed by the reserve",
+			func() {
+				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, address.Bytes())
+				suite.Require().NotNil(acc)
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+				reserve := suite.app.SupplyKeeper.GetModuleAccount(suite.ctx, types.ReserveName)
+				err := reserve.SetCoins(sdk.NewCoins(ethermint.NewPhotonCoinInt64(1)))
+				suite.Require().NoError(err)
+				suite.app.SupplyKeeper.SetModuleAccount(suite.ctx, reserve)
+			},
+			types.GenesisState{
+				Params: dustParams,
+				Accounts: []types.GenesisAccount{
+					{
+						Address: address.String(),
+						Dust:    "5",
+					},
+				},
+			},
+			false,
+		},
+		{
+			"dust without reserve",
+			func() {
+				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, address.Bytes())
+				suite.Require().NotNil(acc)
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+			},
+			types.GenesisState{
+				Params: dustParams,
+				Accounts: []types.GenesisAccount{
+					{
+						Address: address.String(),
+						Dust:    "5",
+					},
+				},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -115,3 +175,49 @@ func (suite *EvmTestSuite) TestInitGenesis() {
 		})
 	}
 }
+
+func (suite *EvmTestSuite) TestInitGenesisPreinstalls() {
+	genState := types.DefaultGenesisState()
+	suite.Require().NoError(genState.AddPreinstalls(types.DefaultPreinstalls()...))
+
+	for _, p := range types.DefaultPreinstalls() {
+		acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, p.Address.Bytes())
+		suite.Require().NoError(acc.SetSequence(1))
+		suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+	}
+
+	_ = evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, suite.app.AccountKeeper, genState)
+
+	for _, p := range types.DefaultPreinstalls() {
+		suite.Require().Equal(p.Code, suite.app.EvmKeeper.GetCode(suite.ctx, p.Address))
+		suite.Require().Equal(uint64(1), suite.app.EvmKeeper.GetNonce(suite.ctx, p.Address))
+	}
+
+	res, err := suite.app.EvmKeeper.CallEVM(suite.ctx, types.ModuleAddress, types.Multicall3Address, types.Multicall3ABI, "getBlockNumber")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]interface{}{big.NewInt(suite.ctx.BlockHeight())}, res)
+
+	res, err = suite.app.EvmKeeper.CallEVM(suite.ctx, types.ModuleAddress, types.WrappedTokenAddress(), types.WrappedTokenABI, "symbol")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]interface{}{types.DefaultWrappedTokenSymbol}, res)
+}
+
+func (suite *EvmTestSuite) TestInitGenesisMigrateChainConfig() {
+	// chain config exported by a previous version without the Berlin and London forks
+	genState := types.DefaultGenesisState()
+	genState.ChainConfig.BerlinBlock = sdk.Int{}
+	genState.ChainConfig.LondonBlock = sdk.Int{}
+
+	bz := types.ModuleCdc.MustMarshalJSON(genState)
+	bz = bytes.Replace(bz, []byte(`,"berlin_block":"0","london_block":"0"`), nil, 1)
+	suite.Require().NotContains(string(bz), "berlin_block")
+	suite.Require().NoError(evm.AppModuleBasic{}.ValidateGenesis(bz))
+
+	_ = evm.InitGenesis(suite.ctx, *suite.app.EvmKeeper, suite.app.AccountKeeper, genState)
+
+	config, found := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().NoError(config.Validate())
+	suite.Require().Equal(sdk.NewInt(-1), config.BerlinBlock)
+	suite.Require().Equal(sdk.NewInt(-1), config.LondonBlock)
+}
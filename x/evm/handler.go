@@ -2,49 +2,23 @@ package evm
 
 import (
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 
 	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
 
 	tmtypes "github.com/tendermint/tendermint/types"
 )
 
-// NewHandler returns a handler for Ethermint type messages.
+// NewHandler returns a handler for Ethermint type messages. The state transition
+// of each message is applied on its own CommitStateDB, so a failed message doesn't
+// leave any EVM state behind.
 func NewHandler(k *Keeper) sdk.Handler {
 	return func(ctx sdk.Context, msg sdk.Msg) (result *sdk.Result, err error) {
-		snapshotStateDB := k.CommitStateDB.Copy()
-
-		// The "recover" code here is used to solve the problem of dirty data
-		// in CommitStateDB due to insufficient gas.
-
-		// The following is a detailed description:
-		// If the gas is insufficient during the execution of the "handler",
-		// panic will be thrown from the function "ConsumeGas" and finally
-		// caught by the function "runTx" from Cosmos. The function "runTx"
-		// will think that the execution of Msg has failed and the modified
-		// data in the Store will not take effect.
-
-		// Stacktrace：runTx->runMsgs->handler->...->gaskv.Store.Set->ConsumeGas
-
-		// The problem is that when the modified data in the Store does not take
-		// effect, the data in the modified CommitStateDB is not rolled back,
-		// they take effect, and dirty data is generated.
-		// Therefore, the code here specifically deals with this situation.
-		// See https://github.com/cosmos/ethermint/issues/668 for more information.
-		defer func() {
-			if r := recover(); r != nil {
-				// We first used "k.CommitStateDB = snapshotStateDB" to roll back
-				// CommitStateDB, but this can only change the CommitStateDB in the
-				// current Keeper object, but the Keeper object will be destroyed
-				// soon, it is not a global variable, so the content pointed to by
-				// the CommitStateDB pointer can be modified to take effect.
-				types.CopyCommitStateDB(snapshotStateDB, k.CommitStateDB)
-				panic(r)
-			}
-		}()
 		ctx = ctx.WithEventManager(sdk.NewEventManager())
 		switch msg := msg.(type) {
 		case types.MsgEthereumTx:
@@ -54,9 +28,6 @@ func NewHandler(k *Keeper) sdk.Handler {
 		default:
 			return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s message type: %T", ModuleName, msg)
 		}
-		if err != nil {
-			types.CopyCommitStateDB(snapshotStateDB, k.CommitStateDB)
-		}
 		return result, err
 	}
 }
@@ -92,7 +63,6 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 		GasLimit:     msg.GasLimit,
 		Amount:       msg.Amount.BigInt(),
 		Payload:      msg.Payload,
-		Csdb:         k.CommitStateDB.WithContext(ctx),
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
 		Sender:       common.BytesToAddress(msg.From.Bytes()),
@@ -104,33 +74,16 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 		st.Recipient = &to
 	}
 
-	if !st.Simulate {
-		// Prepare db for logs
-		k.CommitStateDB.Prepare(ethHash, k.TxCount)
-		k.TxCount++
-	}
-
 	config, found := k.GetChainConfig(ctx)
 	if !found {
 		return nil, types.ErrChainConfigNotFound
 	}
 
-	executionResult, err := st.TransitionDb(ctx, config)
+	executionResult, err := k.ApplyTransition(ctx, st, config, true)
 	if err != nil {
 		return nil, err
 	}
 
-	// update block bloom filter
-	if !st.Simulate {
-		k.Bloom.Or(k.Bloom, executionResult.Bloom)
-
-		// update transaction logs in KVStore
-		err = k.SetLogs(ctx, common.BytesToHash(txHash), executionResult.Logs)
-		if err != nil {
-			panic(err)
-		}
-	}
-
 	// log successful execution
 	k.Logger(ctx).Info(executionResult.Result.Log)
 
@@ -159,3 +112,146 @@ func handleMsgEthermint(ctx sdk.Context, k *Keeper, msg types.MsgEthermint) (*sd
 	executionResult.Result.Events = ctx.EventManager().Events()
 	return executionResult.Result, nil
 }
+
+// NewProposalHandler returns a handler for the evm module governance proposals.
+func NewProposalHandler(k *Keeper) govtypes.Handler {
+	return func(ctx sdk.Context, content govtypes.Content) error {
+		switch c := content.(type) {
+		case types.ChainConfigUpgradeProposal:
+			return handleChainConfigUpgradeProposal(ctx, k, c)
+		case types.RegisterSystemContractProposal:
+			return handleRegisterSystemContractProposal(ctx, k, c)
+		case types.RemoveSystemContractProposal:
+			return handleRemoveSystemContractProposal(ctx, k, c)
+		case types.DeployContractProposal:
+			return handleDeployContractProposal(ctx, k, c)
+		case types.PatchContractProposal:
+			return handlePatchContractProposal(ctx, k, c)
+		default:
+			return sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unrecognized %s proposal content type: %T", ModuleName, c)
+		}
+	}
+}
+
+func handleChainConfigUpgradeProposal(ctx sdk.Context, k *Keeper, p types.ChainConfigUpgradeProposal) error {
+	if err := k.UpgradeChainConfig(ctx, p.ChainConfig); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeChainConfigUpgrade,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		),
+	)
+
+	return nil
+}
+
+func handleRegisterSystemContractProposal(ctx sdk.Context, k *Keeper, p types.RegisterSystemContractProposal) error {
+	if err := k.RegisterSystemContract(ctx, p.SystemContract); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRegisterSystemContract,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, p.SystemContract.Address),
+			sdk.NewAttribute(types.AttributeKeyHook, p.SystemContract.Hook),
+		),
+	)
+
+	return nil
+}
+
+func handleRemoveSystemContractProposal(ctx sdk.Context, k *Keeper, p types.RemoveSystemContractProposal) error {
+	if err := k.RemoveSystemContract(ctx, common.HexToAddress(p.Address)); err != nil {
+		return err
+	}
+
+	ctx.EventManager().EmitEvent(
+		sdk.NewEvent(
+			types.EventTypeRemoveSystemContract,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, p.Address),
+		),
+	)
+
+	return nil
+}
+
+func handleDeployContractProposal(ctx sdk.Context, k *Keeper, p types.DeployContractProposal) error {
+	code := p.GetCode()
+
+	contract, err := k.DeployModuleContract(ctx, common.HexToHash(p.Salt), code, p.Storage)
+	if err != nil {
+		return err
+	}
+
+	events := sdk.Events{
+		sdk.NewEvent(
+			types.EventTypeDeployContract,
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, contract.String()),
+			sdk.NewAttribute(types.AttributeKeySalt, common.HexToHash(p.Salt).String()),
+			sdk.NewAttribute(types.AttributeKeyCodeHash, crypto.Keccak256Hash(code).String()),
+		),
+	}
+
+	// a new contract has an empty storage
+	for _, state := range p.Storage {
+		events = append(events, newSetContractStorageEvent(contract, state, common.Hash{}))
+	}
+
+	ctx.EventManager().EmitEvents(events)
+	return nil
+}
+
+func handlePatchContractProposal(ctx sdk.Context, k *Keeper, p types.PatchContractProposal) error {
+	contract := common.HexToAddress(p.Address)
+	code := p.GetCode()
+
+	// record the replaced values for the events
+	prevCodeHash := k.GetCodeHash(ctx, contract)
+	prevValues := make([]common.Hash, len(p.Storage))
+	for i, state := range p.Storage {
+		prevValues[i] = k.GetState(ctx, contract, common.HexToHash(state.Key))
+	}
+
+	if err := k.PatchContract(ctx, contract, code, p.Storage); err != nil {
+		return err
+	}
+
+	patchEvent := sdk.NewEvent(
+		types.EventTypePatchContract,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(types.AttributeKeyContractAddress, contract.String()),
+	)
+
+	if len(code) != 0 {
+		patchEvent = patchEvent.AppendAttributes(
+			sdk.NewAttribute(types.AttributeKeyPrevCodeHash, prevCodeHash.String()),
+			sdk.NewAttribute(types.AttributeKeyCodeHash, crypto.Keccak256Hash(code).String()),
+		)
+	}
+
+	events := sdk.Events{patchEvent}
+	for i, state := range p.Storage {
+		events = append(events, newSetContractStorageEvent(contract, state, prevValues[i]))
+	}
+
+	ctx.EventManager().EmitEvents(events)
+	return nil
+}
+
+func newSetContractStorageEvent(contract common.Address, state types.State, prevValue common.Hash) sdk.Event {
+	return sdk.NewEvent(
+		types.EventTypeSetContractStorage,
+		sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+		sdk.NewAttribute(types.AttributeKeyContractAddress, contract.String()),
+		sdk.NewAttribute(types.AttributeKeyStorageKey, common.HexToHash(state.Key).String()),
+		sdk.NewAttribute(types.AttributeKeyPrevValue, prevValue.String()),
+		sdk.NewAttribute(types.AttributeKeyValue, common.HexToHash(state.Value).String()),
+	)
+}
@@ -2,7 +2,7 @@ package evm_test
 
 import (
 	"crypto/ecdsa"
-	"encoding/json"
+	"errors"
 	"fmt"
 	"math/big"
 	"strings"
@@ -15,10 +15,14 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	"github.com/cosmos/cosmos-sdk/codec"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/mint"
 
 	"github.com/cosmos/ethermint/app"
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
@@ -49,6 +53,12 @@ func (suite *EvmTestSuite) SetupTest() {
 	suite.handler = evm.NewHandler(suite.app.EvmKeeper)
 	suite.querier = keeper.NewQuerier(*suite.app.EvmKeeper)
 	suite.codec = codec.New()
+
+	// the handler refunds the unused gas from the fee collector, which receives the
+	// fees deducted by the AnteHandler
+	fees := sdk.NewCoins(ethermint.NewPhotonCoinInt64(1000000000000000000))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, fees))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToModule(suite.ctx, mint.ModuleName, auth.FeeCollectorName, fees))
 }
 
 func TestEvmTestSuite(t *testing.T) {
@@ -71,7 +81,7 @@ func (suite *EvmTestSuite) TestHandleMsgEthereumTx() {
 			"passed",
 			func() {
 				suite.app.EvmKeeper.SetBalance(suite.ctx, sender, big.NewInt(100))
-				tx = types.NewMsgEthereumTx(0, &sender, big.NewInt(100), 0, big.NewInt(10000), nil)
+				tx = types.NewMsgEthereumTx(0, &sender, big.NewInt(100), 21000, big.NewInt(10000), nil)
 
 				// parse context chain ID to big.Int
 				chainID, err := ethermint.ParseChainID(suite.ctx.ChainID())
@@ -230,6 +240,7 @@ func (suite *EvmTestSuite) TestHandlerLogs() {
 
 	result, err := suite.handler(suite.ctx, tx)
 	suite.Require().NoError(err, "failed to handle eth tx msg")
+	suite.Require().Equal(1, suite.app.EvmKeeper.TxCount)
 
 	resultData, err := types.DecodeResultData(result.Data)
 	suite.Require().NoError(err, "failed to decode result data")
@@ -291,6 +302,52 @@ func (suite *EvmTestSuite) TestQueryTxLogs() {
 	suite.Require().Equal(txLogs.Logs[0], resultData.Logs[0])
 }
 
+func (suite *EvmTestSuite) TestSystemContractLogs() {
+	// CODECOPY(0, 12, 6), RETURN(0, 6) followed by the runtime code LOG0(0, 0), STOP
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, types.ModuleAddress, ethcmn.Hex2Bytes("6006600c60003960066000f360006000a000"))
+	suite.Require().NoError(err)
+	suite.app.EvmKeeper.SetSystemContract(suite.ctx, types.NewSystemContract(contract, types.SystemContractHookBeginBlock, 1, nil, 100000))
+
+	height := suite.ctx.BlockHeight()
+	suite.app.EvmKeeper.BeginBlock(suite.ctx, abci.RequestBeginBlock{
+		Hash:   []byte{2},
+		Header: abci.Header{Height: height, LastBlockId: abci.BlockID{Hash: []byte{1}}},
+	})
+	// the system contract call isn't an Ethereum transaction
+	suite.Require().Zero(suite.app.EvmKeeper.TxCount)
+
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+
+	tx := types.NewMsgEthereumTx(0, &contract, big.NewInt(0), 100000, big.NewInt(1), nil)
+	suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+	result, err := suite.handler(suite.ctx, tx)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, suite.app.EvmKeeper.TxCount)
+
+	resultData, err := types.DecodeResultData(result.Data)
+	suite.Require().NoError(err)
+
+	// the logs of the transaction don't include the ones of the system contract call
+	logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, resultData.TxHash)
+	suite.Require().NoError(err)
+	suite.Require().Len(logs, 1)
+	suite.Require().Equal(resultData.TxHash, logs[0].TxHash)
+	suite.Require().Equal(uint(0), logs[0].TxIndex)
+	suite.Require().Equal(uint(0), logs[0].Index)
+
+	moduleTxHash := types.ModuleTxHash(ethcrypto.Keccak256Hash(
+		sdk.Uint64ToBigEndian(uint64(height)),
+		sdk.Uint64ToBigEndian(0),
+	))
+	logs, err = suite.app.EvmKeeper.GetLogs(suite.ctx, moduleTxHash)
+	suite.Require().NoError(err)
+	suite.Require().Len(logs, 1)
+	suite.Require().Equal(moduleTxHash, logs[0].TxHash)
+	suite.Require().Equal(contract, logs[0].Address)
+}
+
 func (suite *EvmTestSuite) TestDeployAndCallContract() {
 	// Test contract:
 	//http://remix.ethereum.org/#optimize=false&evmVersion=istanbul&version=soljson-v0.5.15+commit.6a57276f.js
@@ -485,21 +542,17 @@ func (suite *EvmTestSuite) TestOutOfGasWhenDeployContract() {
 	tx.Sign(big.NewInt(3), priv.ToECDSA())
 	suite.Require().NoError(err)
 
-	snapshotCommitStateDBJson, err := json.Marshal(suite.app.EvmKeeper.CommitStateDB)
-	suite.Require().Nil(err)
-
-	defer func() {
-		if r := recover(); r != nil {
-			currentCommitStateDBJson, err := json.Marshal(suite.app.EvmKeeper.CommitStateDB)
-			suite.Require().Nil(err)
-			suite.Require().Equal(snapshotCommitStateDBJson, currentCommitStateDBJson)
-		} else {
-			suite.Require().Fail("panic did not happen")
-		}
-	}()
-
-	suite.handler(suite.ctx, tx)
-	suite.Require().Fail("panic did not happen")
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+	contract := ethcrypto.CreateAddress(sender, 1)
+
+	// the gas limit doesn't cover the intrinsic gas
+	_, err = suite.handler(suite.ctx, tx)
+	suite.Require().True(errors.Is(err, sdkerrors.ErrOutOfGas), err)
+
+	// the transition is applied on a cached context, so no state is left behind
+	ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	suite.Require().Zero(suite.app.EvmKeeper.GetNonce(ctx, sender))
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(ctx, contract))
 }
 
 func (suite *EvmTestSuite) TestErrorWhenDeployContract() {
@@ -515,13 +568,327 @@ func (suite *EvmTestSuite) TestErrorWhenDeployContract() {
 	tx.Sign(big.NewInt(3), priv.ToECDSA())
 	suite.Require().NoError(err)
 
-	snapshotCommitStateDBJson, err := json.Marshal(suite.app.EvmKeeper.CommitStateDB)
-	suite.Require().Nil(err)
+	sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
 
 	_, sdkErr := suite.handler(suite.ctx, tx)
 	suite.Require().NotNil(sdkErr)
 
-	currentCommitStateDBJson, err := json.Marshal(suite.app.EvmKeeper.CommitStateDB)
-	suite.Require().Nil(err)
-	suite.Require().Equal(snapshotCommitStateDBJson, currentCommitStateDBJson)
+	// the failed transition doesn't leave any state behind
+	suite.Require().Zero(suite.app.EvmKeeper.GetNonce(suite.ctx, sender))
+	suite.Require().Zero(suite.app.EvmKeeper.GetBalance(suite.ctx, sender).Sign())
+	suite.Require().Empty(suite.app.EvmKeeper.AllLogs(suite.ctx))
+}
+
+func (suite *EvmTestSuite) TestGasRefund() {
+	gasLimit := uint64(100000)
+	gasPrice := big.NewInt(10)
+	initialBalance := big.NewInt(10000000)
+	reverter := ethcmn.BytesToAddress([]byte("reverter"))
+	clearer := ethcmn.BytesToAddress([]byte("clearer"))
+
+	// REVERT(0, 0)
+	suite.app.EvmKeeper.SetCode(suite.ctx, reverter, common.Hex2Bytes("60006000fd"))
+	// SSTORE(0, 0)
+	suite.app.EvmKeeper.SetCode(suite.ctx, clearer, common.Hex2Bytes("600060005500"))
+
+	berlin := types.DefaultChainConfig()
+	berlin.LondonBlock = sdk.NewInt(-1)
+
+	london := types.DefaultChainConfig()
+
+	// the gas used matches the one of geth for the same transactions
+	testCases := []struct {
+		name       string
+		config     types.ChainConfig
+		recipient  ethcmn.Address
+		expGasUsed uint64
+		expPass    bool
+	}{
+		{"value transfer", london, ethcmn.Address{0x1}, 21000, true},
+		// 21000 + 3 + 3 + 2100 (cold slot) + 2900 - 13003 (capped to half of the gas used)
+		{"berlin, clear storage", berlin, clearer, 13003, true},
+		// 21000 + 3 + 3 + 2100 (cold slot) + 2900 - 4800 (EIP-3529 clear refund)
+		{"london, clear storage", london, clearer, 21206, true},
+		// 21000 + 3 + 3
+		{"reverted call", london, reverter, 21006, false},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.app.EvmKeeper.SetChainConfig(suite.ctx, tc.config)
+			suite.app.EvmKeeper.SetState(suite.ctx, clearer, ethcmn.Hash{}, ethcmn.BigToHash(big.NewInt(1)))
+
+			priv, err := ethsecp256k1.GenerateKey()
+			suite.Require().NoError(err)
+			sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+			suite.app.EvmKeeper.SetBalance(suite.ctx, sender, initialBalance)
+
+			tx := types.NewMsgEthereumTx(0, &tc.recipient, big.NewInt(1), gasLimit, gasPrice, nil)
+			suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+			// the AnteHandler deducts the fee of the gas limit
+			fee := sdk.NewCoins(ethermint.NewPhotonCoinInt64(int64(gasLimit) * gasPrice.Int64()))
+			suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromAccountToModule(suite.ctx, sender.Bytes(), auth.FeeCollectorName, fee))
+			feeCollectorBalance := suite.app.SupplyKeeper.GetModuleAccount(suite.ctx, auth.FeeCollectorName).GetCoins()
+
+			// as on the BaseApp, the message is executed on a cached context that is
+			// only written if it succeeds
+			ctx, write := suite.ctx.WithGasMeter(sdk.NewGasMeter(gasLimit)).CacheContext()
+			_, err = suite.handler(ctx, tx)
+			suite.Require().Equal(tc.expGasUsed, ctx.GasMeter().GasConsumed())
+
+			// the sender pays the fee of the gas used, as on Ethereum
+			gasFee := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(tc.expGasUsed))
+			expBalance := new(big.Int).Sub(initialBalance, gasFee)
+
+			if tc.expPass {
+				suite.Require().NoError(err)
+				write()
+				expBalance.Sub(expBalance, big.NewInt(1))
+			} else {
+				suite.Require().Error(err)
+
+				// the refund of a failed transaction is paid at the end of DeliverTx,
+				// on the store of the block
+				suite.Require().Equal(
+					new(big.Int).Sub(initialBalance, new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))),
+					suite.app.EvmKeeper.GetBalance(suite.ctx, sender),
+				)
+			}
+
+			suite.app.EvmKeeper.PayFailedTxRefund(suite.ctx.MultiStore())
+			suite.Require().Equal(expBalance, suite.app.EvmKeeper.GetBalance(suite.ctx, sender))
+
+			// the fee collector keeps the fee of the gas used
+			refund := fee.Sub(sdk.NewCoins(ethermint.NewPhotonCoin(sdk.NewIntFromBigInt(gasFee))))
+			suite.Require().Equal(
+				feeCollectorBalance.Sub(refund),
+				suite.app.SupplyKeeper.GetModuleAccount(suite.ctx, auth.FeeCollectorName).GetCoins(),
+			)
+		})
+	}
+}
+
+func (suite *EvmTestSuite) TestChainConfigUpgradeProposalHandler() {
+	handler := evm.NewProposalHandler(suite.app.EvmKeeper)
+
+	// London isn't scheduled
+	current := types.DefaultChainConfig()
+	current.LondonBlock = sdk.NewInt(-1)
+	suite.app.EvmKeeper.SetChainConfig(suite.ctx, current)
+
+	config := current
+	config.LondonBlock = sdk.NewInt(suite.ctx.BlockHeight() + 10)
+
+	err := handler(suite.ctx, types.NewChainConfigUpgradeProposal("title", "description", config))
+	suite.Require().NoError(err)
+
+	stored, found := suite.app.EvmKeeper.GetChainConfig(suite.ctx)
+	suite.Require().True(found)
+	suite.Require().Equal(config, stored)
+
+	// the scheduled fork can't be moved once it's active
+	ctx := suite.ctx.WithBlockHeight(suite.ctx.BlockHeight() + 10)
+	config.LondonBlock = sdk.NewInt(ctx.BlockHeight() + 10)
+	err = handler(ctx, types.NewChainConfigUpgradeProposal("title", "description", config))
+	suite.Require().Error(err)
+
+	// unknown proposal
+	err = handler(suite.ctx, nil)
+	suite.Require().Error(err)
+}
+
+func (suite *EvmTestSuite) TestSystemContractProposalHandler() {
+	handler := evm.NewProposalHandler(suite.app.EvmKeeper)
+	deployer := ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
+
+	// CODECOPY(0, 12, 1), RETURN(0, 1) followed by the runtime code STOP
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, deployer, ethcmn.Hex2Bytes("6001600c60003960016000f300"))
+	suite.Require().NoError(err)
+
+	sc := types.NewSystemContract(contract, types.SystemContractHookEndBlock, 10, nil, 100000)
+	err = handler(suite.ctx, types.NewRegisterSystemContractProposal("title", "description", sc))
+	suite.Require().NoError(err)
+
+	stored, found := suite.app.EvmKeeper.GetSystemContract(suite.ctx, contract)
+	suite.Require().True(found)
+	suite.Require().Equal(sc, stored)
+
+	// accounts without code can't be registered
+	err = handler(suite.ctx, types.NewRegisterSystemContractProposal("title", "description",
+		types.NewSystemContract(deployer, types.SystemContractHookEndBlock, 10, nil, 100000)),
+	)
+	suite.Require().Error(err)
+
+	err = handler(suite.ctx, types.NewRemoveSystemContractProposal("title", "description", contract))
+	suite.Require().NoError(err)
+
+	_, found = suite.app.EvmKeeper.GetSystemContract(suite.ctx, contract)
+	suite.Require().False(found)
+
+	// the contract is no longer registered
+	err = handler(suite.ctx, types.NewRemoveSystemContractProposal("title", "description", contract))
+	suite.Require().Error(err)
+}
+
+func (suite *EvmTestSuite) TestDeployContractProposalHandler() {
+	handler := evm.NewProposalHandler(suite.app.EvmKeeper)
+
+	// MSTORE(0, SLOAD(0)), RETURN(0, 32)
+	code := ethcmn.Hex2Bytes("60005460005260206000f3")
+	slot := ethcmn.Hash{}
+	value := ethcmn.BigToHash(big.NewInt(42))
+	proposal := types.NewDeployContractProposal("title", "description", ethcmn.BytesToHash([]byte{1}), code, types.Storage{types.NewState(slot, value)})
+
+	ctx := suite.ctx.WithEventManager(sdk.NewEventManager())
+	err := handler(ctx, proposal)
+	suite.Require().NoError(err)
+
+	contract := proposal.ContractAddress()
+	events := ctx.EventManager().Events()
+	suite.Require().Len(events, 2)
+	suite.Require().Equal(types.EventTypeDeployContract, events[0].Type)
+	suite.Require().Equal(types.EventTypeSetContractStorage, events[1].Type)
+
+	// the contract is persisted to the store
+	suite.Require().Equal(code, suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+	suite.Require().Equal(value, suite.app.EvmKeeper.GetState(suite.ctx, contract, slot))
+	suite.Require().Equal(uint64(1), suite.app.EvmKeeper.GetNonce(suite.ctx, contract))
+
+	res, err := suite.app.EvmKeeper.CallContract(suite.ctx, types.ModuleAddress, contract, nil, types.ModuleGasLimit)
+	suite.Require().NoError(err)
+	resultData, err := types.DecodeResultData(res.Result.Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(value.Bytes(), resultData.Ret)
+
+	// the address is already in use
+	err = handler(suite.ctx, proposal)
+	suite.Require().Error(err)
+}
+
+func (suite *EvmTestSuite) TestPatchContractProposalHandler() {
+	handler := evm.NewProposalHandler(suite.app.EvmKeeper)
+	address := ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
+
+	// CODECOPY(0, 12, 1), RETURN(0, 1) followed by the runtime code STOP
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, address, ethcmn.Hex2Bytes("6001600c60003960016000f300"))
+	suite.Require().NoError(err)
+
+	slot := ethcmn.BigToHash(big.NewInt(3))
+	suite.app.EvmKeeper.SetState(suite.ctx, contract, slot, ethcmn.BigToHash(big.NewInt(1)))
+
+	code := ethcmn.Hex2Bytes("60005460005260206000f3")
+	ctx := suite.ctx.WithEventManager(sdk.NewEventManager())
+	err = handler(ctx, types.NewPatchContractProposal("title", "description", contract, code, types.Storage{types.NewState(slot, ethcmn.Hash{})}))
+	suite.Require().NoError(err)
+
+	events := ctx.EventManager().Events()
+	suite.Require().Len(events, 2)
+	suite.Require().Equal(types.EventTypePatchContract, events[0].Type)
+	suite.Require().Equal(types.EventTypeSetContractStorage, events[1].Type)
+
+	attributes := make(map[string]string)
+	for _, attr := range events[1].Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+	suite.Require().Equal(ethcmn.BigToHash(big.NewInt(1)).String(), attributes[types.AttributeKeyPrevValue])
+	suite.Require().Equal(ethcmn.Hash{}.String(), attributes[types.AttributeKeyValue])
+
+	suite.Require().Equal(code, suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.GetState(suite.ctx, contract, slot))
+
+	// accounts without code can't be patched
+	err = handler(suite.ctx, types.NewPatchContractProposal("title", "description", address, code, nil))
+	suite.Require().Error(err)
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, address))
+}
+
+// LogRecordHook records the receipts of the EVM transactions
+type LogRecordHook struct {
+	Receipts []*ethtypes.Receipt
+}
+
+func (dh *LogRecordHook) PostTxProcessing(_ sdk.Context, _ ethcmn.Address, _ *ethcmn.Address, receipt *ethtypes.Receipt) error {
+	dh.Receipts = append(dh.Receipts, receipt)
+	return nil
+}
+
+// GasConsumingHook consumes gas on the context of the hooks
+type GasConsumingHook struct{}
+
+func (GasConsumingHook) PostTxProcessing(ctx sdk.Context, _ ethcmn.Address, _ *ethcmn.Address, _ *ethtypes.Receipt) error {
+	ctx.GasMeter().ConsumeGas(50000, "hook")
+	return nil
+}
+
+// FailureHook always fails
+type FailureHook struct{}
+
+func (FailureHook) PostTxProcessing(sdk.Context, ethcmn.Address, *ethcmn.Address, *ethtypes.Receipt) error {
+	return errors.New("post tx processing failed")
+}
+
+func (suite *EvmTestSuite) TestEvmHooks() {
+	// Test contract emitting the Hello(17) event on deployment, see TestHandlerLogs
+	bytecode := common.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+
+	testCases := []struct {
+		name   string
+		hooks  func(*LogRecordHook) types.EvmHooks
+		expErr bool
+	}{
+		{
+			"hooks succeed",
+			func(recorder *LogRecordHook) types.EvmHooks {
+				return types.NewMultiEvmHooks(recorder, GasConsumingHook{})
+			},
+			false,
+		},
+		{
+			"hook fails",
+			func(recorder *LogRecordHook) types.EvmHooks {
+				return types.NewMultiEvmHooks(recorder, FailureHook{})
+			},
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			recorder := &LogRecordHook{}
+			suite.app.EvmKeeper.SetHooks(tc.hooks(recorder))
+
+			priv, err := ethsecp256k1.GenerateKey()
+			suite.Require().NoError(err)
+			sender := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+
+			tx := types.NewMsgEthereumTx(1, nil, big.NewInt(0), 100000, big.NewInt(1000000), bytecode)
+			suite.Require().NoError(tx.Sign(big.NewInt(3), priv.ToECDSA()))
+
+			ctx := suite.ctx.WithGasMeter(sdk.NewGasMeter(100000))
+			_, err = suite.handler(ctx, tx)
+
+			suite.Require().Len(recorder.Receipts, 1)
+			receipt := recorder.Receipts[0]
+			suite.Require().Equal(ethtypes.ReceiptStatusSuccessful, receipt.Status)
+			suite.Require().Equal(ethcrypto.CreateAddress(sender, 1), receipt.ContractAddress)
+			suite.Require().Len(receipt.Logs, 1)
+			suite.Require().Len(receipt.Logs[0].Topics, 2)
+
+			code := suite.app.EvmKeeper.NewStateDB(suite.ctx).GetCode(receipt.ContractAddress)
+			if tc.expErr {
+				suite.Require().Error(err)
+				// the state transition is reverted
+				suite.Require().Empty(code)
+				suite.Require().Zero(suite.app.EvmKeeper.Bloom.Sign())
+			} else {
+				suite.Require().NoError(err)
+				suite.Require().NotEmpty(code)
+				// the hooks don't consume the gas of the transaction
+				suite.Require().Equal(receipt.GasUsed, ctx.GasMeter().GasConsumed())
+			}
+		})
+	}
 }
@@ -9,11 +9,18 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
 )
 
 // BeginBlock sets the block hash -> block height map for the previous block height
-// and resets the Bloom filter and the transaction count to 0.
+// and resets the Bloom filter and the transaction count to 0. It then calls the
+// system contracts registered for the begin_block hook.
 func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
+	// the state of the previous block is committed, so the storage slots written
+	// on it can be cached again
+	k.stateCache.NewBlock()
+
 	if req.Header.LastBlockId.GetHash() == nil || req.Header.GetHeight() < 1 {
 		return
 	}
@@ -27,39 +34,34 @@ func (k *Keeper) BeginBlock(ctx sdk.Context, req abci.RequestBeginBlock) {
 
 	k.SetHeightHash(ctx, uint64(height), common.BytesToHash(currentHash))
 	k.SetBlockHash(ctx, currentHash, height)
-	k.CommitStateDB.SetBlockHash(common.BytesToHash(currentHash))
 
 	// reset counters that are used on CommitStateDB.Prepare
 	k.Bloom = big.NewInt(0)
 	k.TxCount = 0
+	k.moduleCallCount = 0
+
+	k.CallSystemContracts(ctx, types.SystemContractHookBeginBlock)
 }
 
-// EndBlock updates the accounts and commits state objects to the KV Store, while
-// deleting the empty ones. It also sets the bloom filers for the request block to
-// the store. The EVM end block logic doesn't update the validator set, thus it returns
-// an empty slice.
-func (k Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
+// EndBlock calls the system contracts registered for the end_block hook and sets the
+// bloom filers for the request block to the store. It then deletes the storage of
+// the destructed accounts and prunes the logs, blooms and block hashes that are
+// older than the retention parameters. The state objects are committed by each state transition, so there's
+// no state left to commit. The EVM end block logic doesn't update the validator
+// set, thus it returns an empty slice.
+func (k *Keeper) EndBlock(ctx sdk.Context, req abci.RequestEndBlock) []abci.ValidatorUpdate {
 	// Gas costs are handled within msg handler so costs should be ignored
 	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
 
-	// Update account balances before committing other parts of state
-	k.UpdateAccounts(ctx)
-
-	root, err := k.Commit(ctx, true)
-	// Commit state objects to KV store
-	if err != nil {
-		k.Logger(ctx).Error("failed to commit state objects", "error", err, "height", ctx.BlockHeight())
-		panic(err)
-	}
-
-	// reset all cache after account data has been committed, that make sure node state consistent
-	if err = k.Reset(ctx, root); err != nil {
-		panic(err)
-	}
+	k.CallSystemContracts(ctx, types.SystemContractHookEndBlock)
 
 	// set the block bloom filter bytes to store
 	bloom := ethtypes.BytesToBloom(k.Bloom.Bytes())
 	k.SetBlockBloom(ctx, req.Height, bloom)
 
+	if pruned := k.PruneExpiredData(ctx, req.Height); pruned > 0 {
+		k.Logger(ctx).Debug("pruned expired evm data", "entries", pruned, "height", req.Height)
+	}
+
 	return []abci.ValidatorUpdate{}
 }
@@ -0,0 +1,326 @@
+package keeper
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcore "github.com/ethereum/go-ethereum/core"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
+)
+
+// simulateCall executes the message call as a simulated state transition on the
+// query context. Unlike the simulation of a transaction, the call isn't wrapped in
+// a StdTx and doesn't run the AnteHandler, so the returned gas info only contains
+// the intrinsic gas and the gas consumed by the EVM, net of the refund, as on
+// Ethereum.
+func simulateCall(
+	ctx sdk.Context, height int64, params types.QueryCallParams, keeper Keeper,
+) (*types.ExecutionResult, types.GasInfo, error) {
+	ctx = queryHeightContext(ctx, height)
+
+	st, config, err := newCallTransition(ctx, params, keeper)
+	if err != nil {
+		return nil, types.GasInfo{}, err
+	}
+
+	// the intrinsic gas and the gas consumed by the EVM are charged on the gas
+	// meter of the call
+	ctx = ctx.WithGasMeter(sdk.NewGasMeter(st.GasLimit))
+
+	res, err := keeper.ApplyTransition(ctx, st, config, false)
+	if err != nil {
+		return nil, types.GasInfo{}, err
+	}
+
+	gasInfo := types.GasInfo{
+		GasLimit:    st.GasLimit,
+		GasConsumed: ctx.GasMeter().GasConsumed(),
+		GasRefunded: res.GasInfo.GasRefunded,
+	}
+
+	return res, gasInfo, nil
+}
+
+// estimateGas returns the lowest gas limit for which the message call succeeds,
+// found by a binary search as in geth's DoEstimateGas. The gas used by a call isn't
+// enough when it makes nested calls, as they are only given 63/64 of the remaining
+// gas, so the gas used before the refund is only the lower bound of the search.
+//
+// The upper bound is the gas limit of the call, or the RPC gas limit if it doesn't
+// cover the transfer gas, capped to the gas the sender can pay for at the call gas
+// price. The error of the call is returned if it fails with the upper bound.
+func estimateGas(
+	ctx sdk.Context, height int64, params types.QueryCallParams, keeper Keeper,
+) (uint64, error) {
+	ctx = queryHeightContext(ctx, height)
+
+	hi := params.Gas
+	if hi < ethparams.TxGas {
+		hi = ethermint.DefaultRPCGasLimit
+	}
+
+	if !params.GasPrice.IsNil() && params.GasPrice.IsPositive() {
+		available := keeper.GetBalance(ctx, params.From)
+		if !params.Value.IsNil() && params.Value.IsPositive() {
+			if available.Cmp(params.Value.BigInt()) < 0 {
+				return 0, sdkerrors.Wrapf(
+					sdkerrors.ErrInsufficientFunds, "balance %s is lower than the call value %s", available, params.Value,
+				)
+			}
+			available.Sub(available, params.Value.BigInt())
+		}
+
+		allowance := available.Div(available, params.GasPrice.BigInt())
+		if allowance.IsUint64() && allowance.Uint64() < hi {
+			hi = allowance.Uint64()
+		}
+	}
+
+	execute := func(gas uint64) (types.GasInfo, error) {
+		params.Gas = gas
+		_, gasInfo, err := simulateCall(ctx, 0, params, keeper)
+		return gasInfo, err
+	}
+
+	gasInfo, err := execute(hi)
+	if err != nil {
+		return 0, err
+	}
+
+	// the call can't succeed with less gas than it used before the refund, which is
+	// given back once the execution is over. The errors are gas-dependent below the
+	// upper bound, as the call succeeded with it.
+	lo := gasInfo.GasConsumed + gasInfo.GasRefunded - 1
+	for lo+1 < hi {
+		mid := lo + (hi-lo)/2
+		if _, err := execute(mid); err != nil {
+			lo = mid
+		} else {
+			hi = mid
+		}
+	}
+
+	return hi, nil
+}
+
+// simulateCallBundle executes the message calls of the bundle in order on the
+// query context. Each call sees the state changes of the previous successful ones,
+// and increments the sender nonce as a transaction would. A failed call doesn't
+// abort the bundle: its state changes are discarded and its error is returned in
+// its result. The calls share the bundle gas cap: the gas limit of each call is
+// capped to the gas left by the previous ones, and the calls fail once it's spent.
+func simulateCallBundle(
+	ctx sdk.Context, height int64, params types.QueryCallBundleParams, keeper Keeper,
+) []types.CallResult {
+	// the bundle is executed on a copy of the state, which is never written
+	ctx, _ = queryHeightContext(ctx, height).CacheContext()
+
+	gasLeft := types.MaxCallBundleGas
+	results := make([]types.CallResult, len(params.Calls))
+	for i, call := range params.Calls {
+		if gasLeft == 0 {
+			results[i] = callError(types.CallResult{}, sdkerrors.Wrapf(
+				sdkerrors.ErrOutOfGas, "bundle gas cap of %d exhausted", types.MaxCallBundleGas,
+			))
+			continue
+		}
+
+		if call.Gas == 0 {
+			call.Gas = ethermint.DefaultRPCGasLimit
+		}
+		if call.Gas > gasLeft {
+			call.Gas = gasLeft
+		}
+
+		results[i] = applyBundleCall(ctx, i, call, keeper)
+		if results[i].GasUsed >= gasLeft {
+			gasLeft = 0
+		} else {
+			gasLeft -= results[i].GasUsed
+		}
+	}
+
+	return results
+}
+
+// applyBundleCall executes the call of the given index of a bundle and writes its
+// state changes, logs included, to the context if it succeeds. The EVM hooks are
+// called as for the transactions.
+func applyBundleCall(ctx sdk.Context, index int, params types.QueryCallParams, keeper Keeper) types.CallResult {
+	st, config, err := newCallTransition(ctx, params, keeper)
+	if err != nil {
+		return types.CallResult{Error: err.Error()}
+	}
+
+	// the calls don't have a transaction hash, so their logs are stored under a
+	// hash derived from their index
+	txHash := ethcrypto.Keccak256Hash([]byte(fmt.Sprintf("%s/%s/%d", types.ModuleName, types.QueryCallBundle, index)))
+
+	cacheCtx, write := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(sdk.NewGasMeter(st.GasLimit))
+
+	st.Csdb = keeper.NewStateDB(cacheCtx)
+	st.Csdb.Prepare(txHash, index)
+	st.TxHash = &txHash
+	// the fees aren't deducted from the sender, so the refunded gas isn't paid back
+	st.Price = new(big.Int)
+
+	// the nonce is incremented as by the AnteHandler, even if the execution fails
+	defer keeper.SetNonce(ctx, st.Sender, st.AccountNonce+1)
+
+	res, err := st.TransitionDb(cacheCtx, config)
+	result := types.CallResult{GasUsed: cacheCtx.GasMeter().GasConsumed()}
+	if err != nil {
+		return callError(result, err)
+	}
+
+	// the simulated transitions aren't committed by TransitionDb
+	if _, err := st.Csdb.Commit(true); err != nil {
+		return callError(result, err)
+	}
+
+	logs, err := st.Csdb.GetLogs(txHash)
+	if err != nil {
+		return callError(result, err)
+	}
+
+	res.Logs = logs
+	res.Bloom = new(big.Int).SetBytes(ethtypes.LogsBloom(logs))
+
+	receipt := st.Receipt(cacheCtx, res, uint(index))
+	if err := keeper.PostTxProcessing(cacheCtx, st.Sender, st.Recipient, receipt); err != nil {
+		return callError(result, sdkerrors.Wrap(err, "failed to execute the EVM hooks"))
+	}
+
+	resultData, err := types.DecodeResultData(res.Result.Data)
+	if err != nil {
+		return callError(result, err)
+	}
+
+	write()
+
+	result.Ret = resultData.Ret
+	result.Logs = logs
+	if st.Recipient == nil {
+		result.ContractAddress = &resultData.ContractAddress
+	}
+
+	return result
+}
+
+// callError sets the error of a failed call, and its revert data if it was
+// reverted.
+func callError(result types.CallResult, err error) types.CallResult {
+	result.Error = err.Error()
+
+	var revertErr *types.RevertError
+	if errors.As(err, &revertErr) {
+		result.Revert = revertErr.Data()
+	}
+
+	return result
+}
+
+// newCallTransition returns the simulated state transition of a message call. The
+// checks of the AnteHandler on the transactions (i.e the nonce, the intrinsic gas
+// and the sender balance) are run, as the call isn't wrapped in a transaction.
+func newCallTransition(
+	ctx sdk.Context, params types.QueryCallParams, keeper Keeper,
+) (types.StateTransition, types.ChainConfig, error) {
+	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return types.StateTransition{}, types.ChainConfig{}, err
+	}
+
+	config, found := keeper.GetChainConfig(ctx)
+	if !found {
+		return types.StateTransition{}, types.ChainConfig{}, types.ErrChainConfigNotFound
+	}
+
+	gasLimit := params.Gas
+	if gasLimit == 0 {
+		gasLimit = ethermint.DefaultRPCGasLimit
+	}
+
+	gasPrice := new(big.Int)
+	if !params.GasPrice.IsNil() {
+		gasPrice = params.GasPrice.BigInt()
+	}
+
+	value := new(big.Int)
+	if !params.Value.IsNil() {
+		value = params.Value.BigInt()
+	}
+
+	if gasPrice.Sign() < 0 || value.Sign() < 0 {
+		return types.StateTransition{}, types.ChainConfig{}, sdkerrors.Wrap(
+			sdkerrors.ErrInvalidRequest, "gas price and value cannot be negative",
+		)
+	}
+
+	nonce := keeper.GetNonce(ctx, params.From)
+	if params.Nonce != nil && *params.Nonce != nonce {
+		return types.StateTransition{}, types.ChainConfig{}, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidSequence, "invalid nonce; got %d, expected %d", *params.Nonce, nonce,
+		)
+	}
+
+	rules := config.Rules(chainIDEpoch, ctx.BlockHeight())
+	intrinsicGas, err := ethcore.IntrinsicGas(params.Data, nil, params.To == nil, rules.IsHomestead, rules.IsIstanbul)
+	if err != nil {
+		return types.StateTransition{}, types.ChainConfig{}, sdkerrors.Wrap(err, "invalid intrinsic gas for call")
+	}
+
+	if gasLimit < intrinsicGas {
+		return types.StateTransition{}, types.ChainConfig{}, sdkerrors.Wrapf(
+			sdkerrors.ErrOutOfGas, "gas limit %d is lower than the intrinsic gas %d", gasLimit, intrinsicGas,
+		)
+	}
+
+	cost := new(big.Int).Mul(gasPrice, new(big.Int).SetUint64(gasLimit))
+	cost.Add(cost, value)
+	if balance := keeper.GetBalance(ctx, params.From); balance.Cmp(cost) < 0 {
+		return types.StateTransition{}, types.ChainConfig{}, sdkerrors.Wrapf(
+			sdkerrors.ErrInsufficientFunds, "balance %s is lower than the call cost %s", balance, cost,
+		)
+	}
+
+	// the result data of the simulated transitions requires a transaction hash
+	var txHash ethcmn.Hash
+
+	st := types.StateTransition{
+		AccountNonce: nonce,
+		Price:        gasPrice,
+		GasLimit:     gasLimit,
+		Recipient:    params.To,
+		Amount:       value,
+		Payload:      params.Data,
+		ChainID:      chainIDEpoch,
+		TxHash:       &txHash,
+		Sender:       params.From,
+		Simulate:     true,
+	}
+
+	return st, config, nil
+}
+
+// queryHeightContext returns the query context for the given height. The query
+// context has the header of the latest block, so the EVM uses the queried height
+// for the historical calls.
+func queryHeightContext(ctx sdk.Context, height int64) sdk.Context {
+	if height > 0 && height < ctx.BlockHeight() {
+		return ctx.WithBlockHeight(height)
+	}
+
+	return ctx
+}
@@ -0,0 +1,178 @@
+package keeper
+
+import (
+	"math/big"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	tmtypes "github.com/tendermint/tendermint/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// ExecuteAtomic executes fn on a cached context, which is only written when fn
+// succeeds. It allows Cosmos modules to perform multiple EVM calls that are
+// reverted together with the Cosmos state changes when one of them fails.
+func (k *Keeper) ExecuteAtomic(ctx sdk.Context, fn func(ctx sdk.Context) error) error {
+	cacheCtx, commit := ctx.CacheContext()
+	if err := fn(cacheCtx); err != nil {
+		return err
+	}
+
+	commit()
+	return nil
+}
+
+// CallContract executes an EVM message call from the given address to a contract
+// on behalf of a Cosmos module. The call doesn't transfer any value and has a zero
+// gas price, while the gas consumed by the EVM execution is charged to the context
+// gas meter. The logs emitted by the contract are stored under the ModuleTxHash of
+// the Cosmos transaction that triggered the call.
+//
+// The EVM state changes are only written if the execution succeeds, so the caller
+// doesn't need to take care of them.
+func (k *Keeper) CallContract(
+	ctx sdk.Context, from, contract common.Address, data []byte, gasLimit uint64,
+) (*types.ExecutionResult, error) {
+	return k.applyModuleTransition(ctx, from, &contract, data, gasLimit)
+}
+
+// CallEVM packs the arguments of the contract method with the given ABI, calls the
+// contract from the given address with the ModuleGasLimit and returns the unpacked
+// outputs of the method. See CallContract for the execution details.
+func (k *Keeper) CallEVM(
+	ctx sdk.Context, from, contract common.Address, contractABI abi.ABI, method string, args ...interface{},
+) ([]interface{}, error) {
+	data, err := contractABI.Pack(method, args...)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrABIPack, "method %s: %s", method, err)
+	}
+
+	res, err := k.CallContract(ctx, from, contract, data, types.ModuleGasLimit)
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := types.DecodeResultData(res.Result.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	// methods without outputs don't return any data
+	if len(resultData.Ret) == 0 {
+		return nil, nil
+	}
+
+	values, err := contractABI.Unpack(method, resultData.Ret)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(types.ErrABIUnpack, "method %s: %s", method, err)
+	}
+
+	return values, nil
+}
+
+// DeployContract deploys a contract from the given address with the ModuleGasLimit
+// and returns its address. The ABI encoded constructor arguments, if any, must be
+// appended to the bytecode. As for Ethereum transactions, the nonce of the deployer
+// is incremented so that its next deployment gets a new address.
+//
+// The EVM state changes are only written if the deployment succeeds.
+func (k *Keeper) DeployContract(ctx sdk.Context, from common.Address, bytecode []byte) (contract common.Address, err error) {
+	err = k.ExecuteAtomic(ctx, func(ctx sdk.Context) error {
+		nonce := k.GetNonce(ctx, from)
+
+		res, err := k.applyModuleTransition(ctx, from, nil, bytecode, types.ModuleGasLimit)
+		if err != nil {
+			return err
+		}
+
+		resultData, err := types.DecodeResultData(res.Result.Data)
+		if err != nil {
+			return err
+		}
+
+		contract = resultData.ContractAddress
+
+		// the state transition restores the nonce of the sender
+		if !ctx.IsCheckTx() {
+			k.SetNonce(ctx, from, nonce+1)
+		}
+
+		return nil
+	})
+
+	return contract, err
+}
+
+// applyModuleTransition executes an EVM state transition on behalf of a Cosmos
+// module. The recipient is nil for contract creations.
+func (k *Keeper) applyModuleTransition(
+	ctx sdk.Context, from common.Address, recipient *common.Address, data []byte, gasLimit uint64,
+) (*types.ExecutionResult, error) {
+	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
+	if err != nil {
+		return nil, err
+	}
+
+	config, found := k.GetChainConfig(ctx)
+	if !found {
+		return nil, types.ErrChainConfigNotFound
+	}
+
+	txHash := k.moduleTxHash(ctx)
+
+	// the nonce is read before switching to the EVM gas meter, so that loading
+	// the sender account doesn't decrease the gas limit of the execution
+	nonce := k.GetNonce(ctx, from)
+
+	// the EVM execution runs on a fresh gas meter, as the state transition gas
+	// limit is decreased by the gas already consumed on the context
+	gasMeter := sdk.NewInfiniteGasMeter()
+	evmCtx := ctx.WithGasMeter(gasMeter)
+
+	st := types.StateTransition{
+		AccountNonce: nonce,
+		Price:        big.NewInt(0),
+		GasLimit:     gasLimit,
+		Recipient:    recipient,
+		Amount:       big.NewInt(0),
+		Payload:      data,
+		ChainID:      chainIDEpoch,
+		TxHash:       &txHash,
+		Sender:       from,
+		Simulate:     ctx.IsCheckTx(),
+	}
+
+	executionResult, err := k.ApplyTransition(evmCtx, st, config, false)
+
+	// charge the EVM gas consumption, including the one of failed executions
+	ctx.GasMeter().ConsumeGas(gasMeter.GasConsumed(), "evm contract call")
+
+	if err != nil {
+		return nil, err
+	}
+
+	return executionResult, nil
+}
+
+// moduleTxHash returns the hash used to store the logs of the module EVM executions.
+// The module executions have their own log namespace, so that their logs are never
+// mixed with the ones of an Ethereum transaction, e.g when a hook calls a contract:
+// it's the ModuleTxHash of the Cosmos transaction that triggered the execution or,
+// outside of transactions (i.e on BeginBlock and EndBlock), of a hash derived from
+// the block height and the module execution index.
+func (k *Keeper) moduleTxHash(ctx sdk.Context) common.Hash {
+	if len(ctx.TxBytes()) != 0 {
+		return types.ModuleTxHash(common.BytesToHash(tmtypes.Tx(ctx.TxBytes()).Hash()))
+	}
+
+	return types.ModuleTxHash(crypto.Keccak256Hash(
+		sdk.Uint64ToBigEndian(uint64(ctx.BlockHeight())),
+		sdk.Uint64ToBigEndian(uint64(k.moduleCallCount)),
+	))
+}
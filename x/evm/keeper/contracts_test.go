@@ -0,0 +1,70 @@
+package keeper_test
+
+import (
+	"errors"
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+var (
+	// answerABI is the ABI of a contract that returns 42 on any call
+	answerABI, _ = abi.JSON(strings.NewReader(`[{"inputs":[],"name":"answer","outputs":[{"internalType":"uint256","name":"","type":"uint256"}],"stateMutability":"view","type":"function"}]`))
+
+	// CODECOPY(0, 12, 10), RETURN(0, 10) followed by the runtime code
+	// MSTORE(0, 42), RETURN(0, 32)
+	answerBytecode = ethcmn.Hex2Bytes("600a600c600039600a6000f3" + "602a60005260206000f3")
+
+	// contract emitting the Hello(17) event in its constructor
+	helloBytecode = ethcmn.FromHex("0x6080604052348015600f57600080fd5b5060117f775a94827b8fd9b519d36cd827093c664f93347070a554f65e4a6f56cd73889860405160405180910390a2603580604b6000396000f3fe6080604052600080fdfea165627a7a723058206cab665f0f557620554bb45adf266708d2bd349b8a4314bdff205ee8440e3c240029")
+)
+
+func (suite *KeeperTestSuite) TestDeployContract() {
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, answerBytecode)
+	suite.Require().NoError(err)
+	suite.Require().Equal(ethcrypto.CreateAddress(suite.address, nonce), contract)
+	suite.Require().Equal(answerBytecode[12:], suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+	suite.Require().Equal(nonce+1, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+
+	// the next deployment gets a new address
+	contract2, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, answerBytecode)
+	suite.Require().NoError(err)
+	suite.Require().NotEqual(contract, contract2)
+
+	// the logs are persisted
+	_, err = suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, helloBytecode)
+	suite.Require().NoError(err)
+	suite.Require().Len(suite.app.EvmKeeper.AllLogs(suite.ctx), 1)
+
+	// failed deployments are reverted
+	nonce = suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+	_, err = suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, []byte{0xfe})
+	suite.Require().Error(err)
+	suite.Require().Equal(nonce, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+}
+
+func (suite *KeeperTestSuite) TestCallEVM() {
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, answerBytecode)
+	suite.Require().NoError(err)
+
+	values, err := suite.app.EvmKeeper.CallEVM(suite.ctx, suite.address, contract, answerABI, "answer")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]interface{}{big.NewInt(42)}, values)
+
+	// unknown method
+	_, err = suite.app.EvmKeeper.CallEVM(suite.ctx, suite.address, contract, answerABI, "question")
+	suite.Require().Error(err)
+	suite.Require().True(errors.Is(err, types.ErrABIPack))
+
+	// contract without code doesn't return any output
+	values, err = suite.app.EvmKeeper.CallEVM(suite.ctx, suite.address, ethcmn.Address{0x1}, answerABI, "answer")
+	suite.Require().NoError(err)
+	suite.Require().Nil(values)
+}
@@ -22,7 +22,7 @@ func RegisterInvariants(ir sdk.InvariantRegistry, k Keeper) {
 }
 
 // BalanceInvariant checks that all auth module's EthAccounts in the application have the same balance
-// as the EVM one.
+// as the EVM one, once the EVM balance dust is removed.
 func (k Keeper) BalanceInvariant() sdk.Invariant {
 	return func(ctx sdk.Context) (string, bool) {
 		var (
@@ -37,11 +37,11 @@ func (k Keeper) BalanceInvariant() sdk.Invariant {
 				return false
 			}
 
-			evmDenom := k.GetParams(ctx).EvmDenom
-			accountBalance := ethAccount.GetCoins().AmountOf(evmDenom)
+			params := k.GetParams(ctx)
+			accountBalance := ethAccount.GetCoins().AmountOf(params.EvmDenom)
 			evmBalance := k.GetBalance(ctx, ethAccount.EthAddress())
 
-			if evmBalance.Cmp(accountBalance.BigInt()) != 0 {
+			if coins, _ := params.FromEVMAmount(evmBalance); !coins.Equal(accountBalance) {
 				count++
 				msg += fmt.Sprintf(
 					"\tbalance mismatch for address %s: account balance %s, evm balance %s\n",
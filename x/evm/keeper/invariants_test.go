@@ -5,9 +5,12 @@ import (
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authtypes "github.com/cosmos/cosmos-sdk/x/auth/types"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 )
@@ -24,7 +27,7 @@ func (suite *KeeperTestSuite) TestBalanceInvariant() {
 		expBroken bool
 	}{
 		{
-			"balance mismatch",
+			"evm balance written to the account",
 			func() {
 				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, address.Bytes())
 				suite.Require().NotNil(acc)
@@ -32,9 +35,10 @@ func (suite *KeeperTestSuite) TestBalanceInvariant() {
 				suite.Require().NoError(err)
 				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
 
+				// the keeper commits the state object to the account on every update
 				suite.app.EvmKeeper.SetBalance(suite.ctx, address, big.NewInt(1000))
 			},
-			true,
+			false,
 		},
 		{
 			"balance ok",
@@ -49,6 +53,23 @@ func (suite *KeeperTestSuite) TestBalanceInvariant() {
 			},
 			false,
 		},
+		{
+			"balance with dust ok",
+			func() {
+				params := suite.app.EvmKeeper.GetParams(suite.ctx)
+				params.EvmDenomDecimals = 6
+				suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, address.Bytes())
+				suite.Require().NotNil(acc)
+				err := acc.SetCoins(sdk.NewCoins(ethermint.NewPhotonCoinInt64(1)))
+				suite.Require().NoError(err)
+				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+
+				suite.app.EvmKeeper.SetBalance(suite.ctx, address, big.NewInt(1000000000005))
+			},
+			false,
+		},
 		{
 			"invalid account type",
 			func() {
@@ -87,7 +108,7 @@ func (suite *KeeperTestSuite) TestNonceInvariant() {
 		expBroken bool
 	}{
 		{
-			"nonce mismatch",
+			"evm nonce written to the account",
 			func() {
 				acc := suite.app.AccountKeeper.NewAccountWithAddress(suite.ctx, address.Bytes())
 				suite.Require().NotNil(acc)
@@ -95,9 +116,11 @@ func (suite *KeeperTestSuite) TestNonceInvariant() {
 				suite.Require().NoError(err)
 				suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
 
+				// the keeper commits the state object to the account on every update
 				suite.app.EvmKeeper.SetNonce(suite.ctx, address, 100)
+				suite.Require().Equal(uint64(100), suite.app.AccountKeeper.GetAccount(suite.ctx, address.Bytes()).GetSequence())
 			},
-			true,
+			false,
 		},
 		{
 			"nonce ok",
@@ -137,3 +160,54 @@ func (suite *KeeperTestSuite) TestNonceInvariant() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestSupplyWithDust() {
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.EvmDenomDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+
+	coins := sdk.NewCoins(ethermint.NewPhotonCoinInt64(10))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, coins))
+	suite.Require().NoError(suite.app.SupplyKeeper.SendCoinsFromModuleToAccount(suite.ctx, mint.ModuleName, sender.Bytes(), coins))
+
+	// 1 photon is 10^12 on the EVM
+	testCases := []struct {
+		name       string
+		from, to   ethcmn.Address
+		amount     int64
+		expReserve int64
+	}{
+		{"half unit", sender, recipient, 500000000000, 1},
+		{"dust carried to a whole unit", sender, recipient, 500000000000, 0},
+		{"sub-unit amount", recipient, sender, 3, 1},
+		{"dust moved between accounts", sender, recipient, 1250000000000, 1},
+		{"whole units", recipient, sender, 2000000000000, 1},
+		{"dust cleared", recipient, sender, 249999999997, 0},
+	}
+
+	for _, tc := range testCases {
+		csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+		csdb.SubBalance(tc.from, big.NewInt(tc.amount))
+		csdb.AddBalance(tc.to, big.NewInt(tc.amount))
+		suite.Require().NoError(csdb.Finalise(false), tc.name)
+
+		// the supply matches the sum of the account balances, including the reserve
+		msg, broken := supply.TotalSupply(suite.app.SupplyKeeper)(suite.ctx)
+		suite.Require().False(broken, tc.name, msg)
+
+		reserve := suite.app.SupplyKeeper.GetModuleAccount(suite.ctx, types.ReserveName)
+		suite.Require().Equal(sdk.NewInt(tc.expReserve), reserve.GetCoins().AmountOf(params.EvmDenom), tc.name)
+
+		totalDust := new(big.Int).Add(
+			new(big.Int).Mod(suite.app.EvmKeeper.GetBalance(suite.ctx, sender), params.ScaleFactor()),
+			new(big.Int).Mod(suite.app.EvmKeeper.GetBalance(suite.ctx, recipient), params.ScaleFactor()),
+		)
+		suite.Require().Equal(totalDust, suite.app.EvmKeeper.NewStateDB(suite.ctx).GetTotalDust(), tc.name)
+	}
+
+	suite.Require().Equal(big.NewInt(10000000000000), suite.app.EvmKeeper.GetBalance(suite.ctx, sender))
+	suite.Require().Zero(suite.app.EvmKeeper.GetBalance(suite.ctx, recipient).Sign())
+}
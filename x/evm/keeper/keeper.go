@@ -18,8 +18,8 @@ import (
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
-// Keeper wraps the CommitStateDB, allowing us to pass in SDK context while adhering
-// to the StateDB interface.
+// Keeper creates a new CommitStateDB for each state transition, allowing us to pass
+// in SDK context while adhering to the StateDB interface.
 type Keeper struct {
 	// Amino codec
 	cdc *codec.Codec
@@ -30,37 +30,136 @@ type Keeper struct {
 	// - storing block height -> bloom filter map. Needed for the Web3 API.
 	// - storing block hash -> block height map. Needed for the Web3 API.
 	storeKey sdk.StoreKey
+	// Parameter space used to store the module params and to use custom denominations
+	// for the EVM operations
+	paramSpace params.Subspace
 	// Account Keeper for fetching accounts
 	accountKeeper types.AccountKeeper
-	// Ethermint concrete implementation on the EVM StateDB interface
-	CommitStateDB *types.CommitStateDB
+	// Supply Keeper for refunding the fees of the unused gas from the fee collector
+	supplyKeeper types.SupplyKeeper
+	// Stateful precompiled contracts that can be enabled through the ActivePrecompiles param
+	precompiles *types.PrecompileRegistry
+	// Module accounts that can't receive value transfers from the EVM
+	blockedAddrs map[string]bool
+	// Node-local read cache of the contract codes and storage slots, shared by the
+	// CommitStateDBs across blocks
+	stateCache *types.StateCache
+	// Node-local store of the call traces of the executed transactions, disabled
+	// unless the node enables tracing
+	traceStore *types.TraceStore
+	// Hooks called after the successful EVM transactions
+	hooks types.EvmHooks
 	// Transaction counter in a block. Used on StateSB's Prepare function.
 	// It is reset to 0 every block on BeginBlock so there's no point in storing the counter
 	// on the KVStore or adding it as a field on the EVM genesis state.
 	TxCount int
 	Bloom   *big.Int
+	// Counter of the EVM executions of the block done on behalf of the modules, which
+	// aren't Ethereum transactions and don't increment TxCount. It's also reset on
+	// BeginBlock.
+	moduleCallCount int
+	// Gas refund of the failed MsgEthereumTx being delivered. The state changes of a
+	// failed message are discarded, so it's paid by PayFailedTxRefund at the end of
+	// DeliverTx, outside of the reverted cached store.
+	failedTxRefund *gasRefund
 }
 
-// NewKeeper generates new evm module keeper
+// NewKeeper generates new evm module keeper. The blocked addresses, i.e the module
+// accounts, can't receive value transfers from the EVM.
 func NewKeeper(
 	cdc *codec.Codec, storeKey sdk.StoreKey, paramSpace params.Subspace, ak types.AccountKeeper,
+	sk types.SupplyKeeper, blockedAddrs map[string]bool,
 ) *Keeper {
 	// set KeyTable if it has not already been set
 	if !paramSpace.HasKeyTable() {
 		paramSpace = paramSpace.WithKeyTable(types.ParamKeyTable())
 	}
 
-	// NOTE: we pass in the parameter space to the CommitStateDB in order to use custom denominations for the EVM operations
 	return &Keeper{
 		cdc:           cdc,
 		storeKey:      storeKey,
+		paramSpace:    paramSpace,
 		accountKeeper: ak,
-		CommitStateDB: types.NewCommitStateDB(sdk.Context{}, storeKey, paramSpace, ak),
+		supplyKeeper:  sk,
+		precompiles:   types.NewPrecompileRegistry(),
+		blockedAddrs:  blockedAddrs,
+		stateCache:    types.NewStateCache(types.DefaultCodeCacheSize, types.DefaultStorageCacheSize),
+		traceStore:    types.NewTraceStore(),
 		TxCount:       0,
 		Bloom:         big.NewInt(0),
 	}
 }
 
+// NewStateDB returns a new CommitStateDB over the given context, with the block
+// hash of the context height. The state objects are only written to the context
+// once the CommitStateDB is committed, so it must not be shared between state
+// transitions.
+func (k Keeper) NewStateDB(ctx sdk.Context) *types.CommitStateDB {
+	// NOTE: we pass in the parameter space to the CommitStateDB in order to use custom denominations for the EVM operations
+	csdb := types.NewCommitStateDB(ctx, k.storeKey, k.paramSpace, k.accountKeeper)
+	csdb.SetPrecompiles(k.precompiles)
+	csdb.SetBlockedAddrs(k.blockedAddrs)
+	csdb.SetStateCache(k.stateCache)
+
+	// the block hash is read without consuming gas, as it was set on BeginBlock
+	infiniteGasCtx := ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	csdb.SetBlockHash(k.GetHeightHash(infiniteGasCtx, uint64(ctx.BlockHeight())))
+	return csdb
+}
+
+// StateCache returns the read cache of the contract codes and storage slots. It's
+// shared by all the copies of the Keeper, so it can be configured once the app is
+// created.
+func (k Keeper) StateCache() *types.StateCache {
+	return k.stateCache
+}
+
+// TraceStore returns the store of the call traces. It's shared by all the copies
+// of the Keeper, so it can be enabled once the app is created.
+func (k Keeper) TraceStore() *types.TraceStore {
+	return k.traceStore
+}
+
+// RegisterPrecompiles registers the stateful precompiled contracts on the EVM. A
+// registered precompile is only executed once its address is included on the
+// ActivePrecompiles param.
+//
+// CONTRACT: must be called during the app initialization.
+func (k *Keeper) RegisterPrecompiles(precompiles ...types.PrecompiledContract) *Keeper {
+	if err := k.precompiles.Register(precompiles...); err != nil {
+		panic(err)
+	}
+
+	return k
+}
+
+// GetPrecompile returns the stateful precompiled contract registered at the given
+// address.
+func (k Keeper) GetPrecompile(address common.Address) (types.PrecompiledContract, bool) {
+	return k.precompiles.Get(address)
+}
+
+// SetHooks sets the EVM hooks. Use NewMultiEvmHooks to set multiple hooks.
+//
+// CONTRACT: must be called during the app initialization.
+func (k *Keeper) SetHooks(eh types.EvmHooks) *Keeper {
+	if k.hooks != nil {
+		panic("cannot set evm hooks twice")
+	}
+
+	k.hooks = eh
+	return k
+}
+
+// PostTxProcessing calls the EVM hooks, if any, after a successful EVM transaction.
+func (k Keeper) PostTxProcessing(ctx sdk.Context, from common.Address, to *common.Address, receipt *ethtypes.Receipt) error {
+	if k.hooks == nil {
+		return nil
+	}
+
+	return k.hooks.PostTxProcessing(ctx, from, to, receipt)
+}
+
 // Logger returns a module-specific logger.
 func (k Keeper) Logger(ctx sdk.Context) log.Logger {
 	return ctx.Logger().With("module", fmt.Sprintf("x/%s", types.ModuleName))
@@ -98,12 +197,19 @@ func (k Keeper) SetBlockHash(ctx sdk.Context, hash []byte, height int64) {
 
 // GetHeightHash returns the block header hash associated with a given block height and chain epoch number.
 func (k Keeper) GetHeightHash(ctx sdk.Context, height uint64) common.Hash {
-	return k.CommitStateDB.WithContext(ctx).GetHeightHash(height)
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightHash)
+	bz := store.Get(types.HeightHashKey(height))
+	if len(bz) == 0 {
+		return common.Hash{}
+	}
+
+	return common.BytesToHash(bz)
 }
 
 // SetHeightHash sets the block header hash associated with a given height.
 func (k Keeper) SetHeightHash(ctx sdk.Context, height uint64, hash common.Hash) {
-	k.CommitStateDB.WithContext(ctx).SetHeightHash(height, hash)
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightHash)
+	store.Set(types.HeightHashKey(height), hash.Bytes())
 }
 
 // ----------------------------------------------------------------------------
@@ -148,6 +254,36 @@ func (k Keeper) GetAllTxLogs(ctx sdk.Context) []types.TransactionLogs {
 	return txsLogs
 }
 
+// GetBlockTxLogs returns the logs of the transactions stored at the given height,
+// skipping the first offset transactions and returning at most limit of them,
+// together with the number of transactions with logs at that height. The
+// transactions are ordered by hash, as in the height index of the logs.
+func (k Keeper) GetBlockTxLogs(ctx sdk.Context, height uint64, offset, limit int) ([]types.TransactionLogs, int, error) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightLogs)
+	iterator := sdk.KVStorePrefixIterator(store, sdk.Uint64ToBigEndian(height))
+	defer iterator.Close()
+
+	txsLogs := []types.TransactionLogs{}
+	total := 0
+	for ; iterator.Valid(); iterator.Next() {
+		total++
+		if total <= offset || len(txsLogs) >= limit {
+			continue
+		}
+
+		// the key is the height followed by the tx hash
+		hash := common.BytesToHash(iterator.Key()[8:])
+		logs, err := k.GetLogs(ctx, hash)
+		if err != nil {
+			return nil, 0, err
+		}
+
+		txsLogs = append(txsLogs, types.NewTransactionLogs(hash, logs))
+	}
+
+	return txsLogs, total, nil
+}
+
 // GetAccountStorage return state storage associated with an account
 func (k Keeper) GetAccountStorage(ctx sdk.Context, address common.Address) (types.Storage, error) {
 	storage := types.Storage{}
@@ -163,6 +299,38 @@ func (k Keeper) GetAccountStorage(ctx sdk.Context, address common.Address) (type
 	return storage, nil
 }
 
+// GetStorageRange returns at most limit storage slots of an account, starting from
+// the slot stored under the given key, and the key of the next slot, which is nil
+// if the range reaches the end of the account storage. The slots are stored and
+// ordered by the hash of the address and the slot key, and only the slots of the
+// range are loaded, so that a large storage can be iterated range by range. The
+// slots written before the account was destructed, which are still queued for
+// deletion, are skipped.
+func (k Keeper) GetStorageRange(ctx sdk.Context, address common.Address, start common.Hash, limit int) (types.Storage, *common.Hash) {
+	epoch := k.NewStateDB(ctx).GetStorageEpoch(address)
+
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(address))
+	iterator := store.Iterator(start.Bytes(), nil)
+	defer iterator.Close()
+
+	storage := types.Storage{}
+	for ; iterator.Valid(); iterator.Next() {
+		valueEpoch, value := types.DecodeStorageValue(iterator.Value())
+		if valueEpoch != epoch {
+			continue
+		}
+
+		key := common.BytesToHash(iterator.Key())
+		if len(storage) == limit {
+			return storage, &key
+		}
+
+		storage = append(storage, types.NewState(key, value))
+	}
+
+	return storage, nil
+}
+
 // GetChainConfig gets block height from block consensus hash
 func (k Keeper) GetChainConfig(ctx sdk.Context) (types.ChainConfig, bool) {
 	store := ctx.KVStore(k.storeKey)
@@ -174,7 +342,8 @@ func (k Keeper) GetChainConfig(ctx sdk.Context) (types.ChainConfig, bool) {
 
 	var config types.ChainConfig
 	k.cdc.MustUnmarshalBinaryBare(bz, &config)
-	return config, true
+	// disable the forks that are missing on configs stored by previous versions
+	return config.Migrate(), true
 }
 
 // SetChainConfig sets the mapping from block consensus hash to block height
@@ -83,7 +83,8 @@ func (suite *KeeperTestSuite) TestTransactionLogs() {
 	expLogs = []*ethtypes.Log{log2, log}
 
 	// add another log under the zero hash
-	suite.app.EvmKeeper.AddLog(suite.ctx, log2)
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.AddLog(log2)
 	logs = suite.app.EvmKeeper.AllLogs(suite.ctx)
 	suite.Require().Equal(expLogs, logs)
 
@@ -93,7 +94,7 @@ func (suite *KeeperTestSuite) TestTransactionLogs() {
 		Data:        []byte("log3"),
 		BlockNumber: 10,
 	}
-	suite.app.EvmKeeper.AddLog(suite.ctx, log3)
+	csdb.AddLog(log3)
 
 	txLogs := suite.app.EvmKeeper.GetAllTxLogs(suite.ctx)
 	suite.Require().Equal(2, len(txLogs))
@@ -142,10 +143,6 @@ func (suite *KeeperTestSuite) TestDBStorage() {
 	suite.Require().True(found)
 	suite.Require().Equal(bloom, testBloom)
 
-	// commit stateDB
-	_, err := suite.app.EvmKeeper.Commit(suite.ctx, false)
-	suite.Require().NoError(err, "failed to commit StateDB")
-
 	// simulate BaseApp EndBlocker commitment
 	suite.app.Commit()
 }
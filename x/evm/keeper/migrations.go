@@ -0,0 +1,154 @@
+package keeper
+
+import (
+	"bytes"
+
+	"github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// SetMissingParams sets the default value of the params that aren't in the param
+// space, i.e the params added after the chain was started, as the param space
+// panics when a missing param is read. The params already set are kept. It
+// returns the keys of the params that were set.
+//
+// NOTE: it must be run by the software upgrades that add params, before the params
+// are read.
+func (k Keeper) SetMissingParams(ctx sdk.Context) []string {
+	defaults := types.DefaultParams()
+
+	var keys []string
+	for _, pair := range defaults.ParamSetPairs() {
+		if k.paramSpace.Has(ctx, pair.Key) {
+			continue
+		}
+
+		k.paramSpace.Set(ctx, pair.Key, pair.Value)
+		keys = append(keys, string(pair.Key))
+	}
+
+	return keys
+}
+
+// DeleteOrphanedStorage deletes the storage slots of the addresses that aren't
+// contracts, i.e the storage left in the store by the contracts destructed before
+// SELFDESTRUCT deleted it, including when the address was funded again and has a
+// plain account, and the slots of the destructed accounts that are still queued
+// for deletion. It returns the number of deleted slots.
+//
+// NOTE: the whole EVM storage is iterated, so it must only be run as a store
+// migration (e.g on a software upgrade).
+func (k Keeper) DeleteOrphanedStorage(ctx sdk.Context) int {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixStorage)
+	csdb := k.NewStateDB(ctx)
+
+	// the account of an address is cached as its slots are contiguous
+	var (
+		lastAddr     common.Address
+		lastContract bool
+		lastEpoch    uint64
+		loaded       bool
+		keys         [][]byte
+	)
+
+	iterator := store.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		// the key is the address followed by the storage slot
+		key := iterator.Key()
+		if len(key) < common.AddressLength {
+			continue
+		}
+
+		addr := common.BytesToAddress(key[:common.AddressLength])
+		if !loaded || addr != lastAddr {
+			lastAddr, loaded = addr, true
+			lastContract = k.isContract(ctx, addr)
+			lastEpoch = csdb.GetStorageEpoch(addr)
+
+			if !lastContract {
+				k.stateCache.InvalidateAccountStorage(addr)
+			}
+		}
+
+		if epoch, _ := types.DecodeStorageValue(iterator.Value()); !lastContract || epoch != lastEpoch {
+			keys = append(keys, key)
+		}
+	}
+	iterator.Close()
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	return len(keys)
+}
+
+// isContract returns true if the address has an account with code.
+func (k Keeper) isContract(ctx sdk.Context, addr common.Address) bool {
+	ethAccount, ok := k.accountKeeper.GetAccount(ctx, addr.Bytes()).(*ethermint.EthAccount)
+	return ok && len(ethAccount.CodeHash) != 0 && !bytes.Equal(ethAccount.CodeHash, ethcrypto.Keccak256(nil))
+}
+
+// IndexLogsHeight indexes by block height the transaction logs stored before the
+// height index was introduced, so that they are pruned on EndBlock once they are
+// older than the LogRetentionBlocks param. The height of the logs of a transaction
+// is the block number of its first log, and the transactions without logs are
+// deleted. It returns the number of indexed transactions.
+//
+// NOTE: the whole logs store is iterated, so it must only be run as a store
+// migration (e.g on a software upgrade).
+func (k Keeper) IndexLogsHeight(ctx sdk.Context) (int, error) {
+	heightStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightLogs)
+	logsStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixLogs)
+
+	// the key of the height index is the height followed by the tx hash
+	indexed := make(map[common.Hash]bool)
+	heightIterator := heightStore.Iterator(nil, nil)
+	for ; heightIterator.Valid(); heightIterator.Next() {
+		indexed[common.BytesToHash(heightIterator.Key()[8:])] = true
+	}
+	heightIterator.Close()
+
+	var (
+		keys  [][]byte
+		empty [][]byte
+	)
+
+	iterator := logsStore.Iterator(nil, nil)
+	for ; iterator.Valid(); iterator.Next() {
+		txHash := common.BytesToHash(iterator.Key())
+		if indexed[txHash] {
+			continue
+		}
+
+		logs, err := types.UnmarshalLogs(iterator.Value())
+		if err != nil {
+			iterator.Close()
+			return 0, err
+		}
+
+		if len(logs) == 0 {
+			empty = append(empty, iterator.Key())
+			continue
+		}
+
+		keys = append(keys, types.HeightLogsKey(logs[0].BlockNumber, txHash))
+	}
+	iterator.Close()
+
+	for _, key := range empty {
+		logsStore.Delete(key)
+	}
+
+	for _, key := range keys {
+		heightStore.Set(key, []byte{0x01})
+	}
+
+	return len(keys), nil
+}
@@ -0,0 +1,80 @@
+package keeper_test
+
+import (
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+func (suite *KeeperTestSuite) TestDeleteOrphanedStorage() {
+	key := ethcmn.BytesToHash([]byte("key"))
+	value := ethcmn.BytesToHash([]byte("value"))
+
+	suite.app.EvmKeeper.SetCode(suite.ctx, suite.address, []byte("code"))
+	suite.app.EvmKeeper.SetState(suite.ctx, suite.address, key, value)
+
+	// storage left behind by a contract destructed before the storage was deleted
+	// on SELFDESTRUCT
+	orphaned := ethcmn.BytesToAddress([]byte("orphaned"))
+	store := prefix.NewStore(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), types.AddressStoragePrefix(orphaned))
+	store.Set(key.Bytes(), value.Bytes())
+	store.Set(ethcmn.BytesToHash([]byte("key2")).Bytes(), value.Bytes())
+
+	// the destructed address was funded again, which created a plain account
+	funded := ethcmn.BytesToAddress([]byte("funded"))
+	suite.app.EvmKeeper.SetNonce(suite.ctx, funded, 1)
+	fundedStore := prefix.NewStore(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), types.AddressStoragePrefix(funded))
+	fundedStore.Set(key.Bytes(), value.Bytes())
+
+	suite.Require().Equal(3, suite.app.EvmKeeper.DeleteOrphanedStorage(suite.ctx))
+	suite.Require().Nil(store.Get(key.Bytes()))
+	suite.Require().Nil(fundedStore.Get(key.Bytes()))
+	suite.Require().Equal(value, suite.app.EvmKeeper.GetState(suite.ctx, suite.address, key))
+
+	suite.Require().Zero(suite.app.EvmKeeper.DeleteOrphanedStorage(suite.ctx))
+}
+
+func (suite *KeeperTestSuite) TestIndexLogsHeight() {
+	indexed := ethcmn.BytesToHash([]byte("indexed"))
+	legacy := ethcmn.BytesToHash([]byte("legacy"))
+	empty := ethcmn.BytesToHash([]byte("empty"))
+
+	// logs stored with the height index
+	err := suite.app.EvmKeeper.SetLogs(suite.ctx.WithBlockHeight(10), indexed, []*ethtypes.Log{{BlockNumber: 10}})
+	suite.Require().NoError(err)
+
+	// logs stored before the height index was introduced
+	store := prefix.NewStore(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), types.KeyPrefixLogs)
+	for hash, logs := range map[ethcmn.Hash][]*ethtypes.Log{
+		legacy: {{BlockNumber: 3}, {BlockNumber: 3}},
+		empty:  {},
+	} {
+		bz, err := types.MarshalLogs(logs)
+		suite.Require().NoError(err)
+		store.Set(hash.Bytes(), bz)
+	}
+
+	count, err := suite.app.EvmKeeper.IndexLogsHeight(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, count)
+	suite.Require().Nil(store.Get(empty.Bytes()))
+
+	heightStore := prefix.NewStore(suite.ctx.KVStore(suite.app.GetKey(types.StoreKey)), types.KeyPrefixHeightLogs)
+	suite.Require().NotNil(heightStore.Get(types.HeightLogsKey(3, legacy)))
+
+	// the indexed logs are pruned once they expire
+	params := suite.app.EvmKeeper.GetParams(suite.ctx)
+	params.LogRetentionBlocks = 5
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 10)
+	suite.Require().Nil(store.Get(legacy.Bytes()))
+	suite.Require().NotNil(store.Get(indexed.Bytes()))
+
+	count, err = suite.app.EvmKeeper.IndexLogsHeight(suite.ctx)
+	suite.Require().NoError(err)
+	suite.Require().Zero(count)
+}
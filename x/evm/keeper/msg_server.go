@@ -10,8 +10,11 @@ import (
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
-// EthereumTx implements the Msg/EthereumTx gRPC method.
-func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Result, error) {
+// EthereumTx implements the Msg/EthereumTx gRPC method. The state transition is
+// applied through ApplyTransition, which increments the transaction count of the
+// block. As on Ethereum, the fee of the unused gas is refunded to the sender
+// whether the transition succeeds or not.
+func (k *Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (result *sdk.Result, err error) {
 	// parse the chainID from a string to a base-10 integer
 	chainIDEpoch, err := ethermint.ParseChainID(ctx.ChainID())
 	if err != nil {
@@ -40,43 +43,29 @@ func (k Keeper) EthereumTx(ctx sdk.Context, msg types.MsgEthereumTx) (*sdk.Resul
 		Recipient:    recipient,
 		Amount:       msg.Data.Amount.BigInt(),
 		Payload:      msg.Data.Payload,
-		Csdb:         k.CommitStateDB.WithContext(ctx),
 		ChainID:      chainIDEpoch,
 		TxHash:       &ethHash,
 		Sender:       sender,
 		Simulate:     ctx.IsCheckTx(),
 	}
 
-	// since the txCount is used by the stateDB, and a simulated tx is run only on the node it's submitted to,
-	// then this will cause the txCount/stateDB of the node that ran the simulated tx to be different than the
-	// other nodes, causing a consensus error
+	// the simulations don't deduct the fees
 	if !st.Simulate {
-		// Prepare db for logs
-		k.CommitStateDB.Prepare(ethHash, k.TxCount)
-		k.TxCount++
+		defer k.refundGas(ctx, msg, sender, ctx.GasMeter().GasConsumed(), &err)
 	}
 
-	config, found := k.GetChainConfig(ctx)
+	// the chain config is read without consuming gas, so that the gas used by the
+	// transaction is the gas used on Ethereum
+	config, found := k.GetChainConfig(ctx.WithGasMeter(sdk.NewInfiniteGasMeter()))
 	if !found {
 		return nil, types.ErrChainConfigNotFound
 	}
 
-	executionResult, err := st.TransitionDb(ctx, config)
+	executionResult, err := k.ApplyTransition(ctx, st, config, true)
 	if err != nil {
 		return nil, err
 	}
 
-	if !st.Simulate {
-		// update block bloom filter
-		k.Bloom.Or(k.Bloom, executionResult.Bloom)
-
-		// update transaction logs in KVStore
-		err = k.SetLogs(ctx, common.BytesToHash(txHash), executionResult.Logs)
-		if err != nil {
-			panic(err)
-		}
-	}
-
 	ctx.EventManager().EmitEvents(sdk.Events{
 		sdk.NewEvent(
 			types.EventTypeEthereumTx,
@@ -8,10 +8,15 @@ import (
 
 // GetParams returns the total set of evm parameters.
 func (k Keeper) GetParams(ctx sdk.Context) (params types.Params) {
-	return k.CommitStateDB.WithContext(ctx).GetParams()
+	k.paramSpace.GetParamSet(ctx, &params)
+	params.EvmDenomDecimals = types.GetEVMDenomDecimals(ctx.KVStore(k.storeKey))
+	return params
 }
 
-// SetParams sets the evm parameters to the param space.
+// SetParams sets the evm parameters to the param space, and the EVM denom
+// decimals to the module store. It must only be called on genesis, as changing
+// the decimals rescales all the EVM balances.
 func (k Keeper) SetParams(ctx sdk.Context, params types.Params) {
-	k.CommitStateDB.WithContext(ctx).SetParams(params)
+	k.paramSpace.SetParamSet(ctx, &params)
+	types.SetEVMDenomDecimals(ctx.KVStore(k.storeKey), params.EvmDenomDecimals)
 }
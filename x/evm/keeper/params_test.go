@@ -1,6 +1,8 @@
 package keeper_test
 
 import (
+	"github.com/cosmos/cosmos-sdk/x/params"
+
 	"github.com/cosmos/ethermint/x/evm/types"
 )
 
@@ -12,3 +14,31 @@ func (suite *KeeperTestSuite) TestParams() {
 	newParams := suite.app.EvmKeeper.GetParams(suite.ctx)
 	suite.Require().Equal(newParams, params)
 }
+
+func (suite *KeeperTestSuite) TestParamsDenomDecimalsGenesisOnly() {
+	genesisParams := suite.app.EvmKeeper.GetParams(suite.ctx)
+	genesisParams.EvmDenomDecimals = 6
+	suite.app.EvmKeeper.SetParams(suite.ctx, genesisParams)
+
+	handler := params.NewParamChangeProposalHandler(suite.app.ParamsKeeper)
+
+	// the decimals aren't a registered param, so the proposals changing them fail on
+	// submission, when gov executes their content in a cached context
+	proposal := params.NewParameterChangeProposal("decimals", "change the decimals", []params.ParamChange{
+		params.NewParamChange(types.ModuleName, "EVMDenomDecimals", `18`),
+	})
+	suite.Require().Panics(func() {
+		_ = handler(suite.ctx, proposal)
+	})
+	suite.Require().Equal(uint32(6), suite.app.EvmKeeper.GetParams(suite.ctx).EvmDenomDecimals)
+
+	// the change of the other params keeps the decimals
+	proposal = params.NewParameterChangeProposal("create", "disable create", []params.ParamChange{
+		params.NewParamChange(types.ModuleName, string(types.ParamStoreKeyEnableCreate), `false`),
+	})
+	suite.Require().NoError(handler(suite.ctx, proposal))
+
+	newParams := suite.app.EvmKeeper.GetParams(suite.ctx)
+	suite.Require().False(newParams.EnableCreate)
+	suite.Require().Equal(uint32(6), newParams.EvmDenomDecimals)
+}
@@ -0,0 +1,84 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// UpgradeChainConfig replaces the stored chain config with the given one. The
+// upgrade is validated against the current config and block height, so that the
+// active forks remain unchanged.
+func (k Keeper) UpgradeChainConfig(ctx sdk.Context, config types.ChainConfig) error {
+	current, found := k.GetChainConfig(ctx)
+	if !found {
+		return types.ErrChainConfigNotFound
+	}
+
+	if err := current.ValidateUpgrade(config, ctx.BlockHeight()); err != nil {
+		return err
+	}
+
+	k.SetChainConfig(ctx, config)
+	return nil
+}
+
+// DeployModuleContract deploys the given runtime code and initial storage at the
+// ModuleContractAddress of the salt and code. The constructor isn't executed, so
+// the contract state must be provided through the storage. The deployment fails
+// if the address is already in use.
+func (k *Keeper) DeployModuleContract(
+	ctx sdk.Context, salt common.Hash, code []byte, storage types.Storage,
+) (contract common.Address, err error) {
+	contract = types.ModuleContractAddress(salt, code)
+
+	err = k.ExecuteAtomic(ctx, func(ctx sdk.Context) error {
+		csdb := k.NewStateDB(ctx)
+		if csdb.GetNonce(contract) != 0 || csdb.GetCodeSize(contract) != 0 {
+			return sdkerrors.Wrap(types.ErrContractAlreadyExists, contract.String())
+		}
+
+		// contracts start with a nonce of 1 (EIP-161)
+		csdb.SetNonce(contract, 1)
+		csdb.SetCode(contract, code)
+		setStorage(csdb, contract, storage)
+
+		return persistStateObjects(csdb)
+	})
+
+	return contract, err
+}
+
+// PatchContract replaces the code of an existing contract, unless the given code
+// is empty, and sets the given storage slots. A zero value deletes the slot.
+func (k *Keeper) PatchContract(ctx sdk.Context, contract common.Address, code []byte, storage types.Storage) error {
+	return k.ExecuteAtomic(ctx, func(ctx sdk.Context) error {
+		csdb := k.NewStateDB(ctx)
+		if csdb.GetCodeSize(contract) == 0 {
+			return sdkerrors.Wrap(types.ErrContractNotFound, contract.String())
+		}
+
+		if len(code) != 0 {
+			csdb.SetCode(contract, code)
+		}
+
+		setStorage(csdb, contract, storage)
+
+		return persistStateObjects(csdb)
+	})
+}
+
+func setStorage(csdb *types.CommitStateDB, contract common.Address, storage types.Storage) {
+	for _, state := range storage {
+		csdb.SetState(contract, common.HexToHash(state.Key), common.HexToHash(state.Value))
+	}
+}
+
+// persistStateObjects writes the dirty storage, accounts and code to the store.
+func persistStateObjects(csdb *types.CommitStateDB) error {
+	_, err := csdb.Commit(false)
+	return err
+}
@@ -0,0 +1,183 @@
+package keeper
+
+import (
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// MaxPrunedEntriesPerBlock is the maximum number of expired entries deleted from
+// the store on each EndBlock. It bounds the pruning work when the retention
+// parameters are enabled or lowered on a chain with a large history, in which case
+// the expired entries are pruned over the following blocks.
+const MaxPrunedEntriesPerBlock = 1000
+
+// PruneExpiredData deletes the storage slots of the destructed accounts and the
+// transaction logs, the block blooms and the block hash mappings that are older
+// than the retention parameters, up to MaxPrunedEntriesPerBlock entries. It returns
+// the number of deleted entries.
+//
+// NOTE: the logs stored before the height index was introduced are only pruned
+// once they are indexed by IndexLogsHeight.
+func (k Keeper) PruneExpiredData(ctx sdk.Context, height int64) int {
+	params := k.GetParams(ctx)
+	limit := MaxPrunedEntriesPerBlock
+
+	// the visited storage slots count towards the limit, as the slots written after
+	// the account was re-created are kept
+	deleted, visited := k.pruneDestructedStorage(ctx, limit)
+	limit -= visited
+
+	if maxHeight, ok := expiredHeight(height, params.LogRetentionBlocks); ok {
+		limit -= k.pruneLogs(ctx, maxHeight, limit)
+		limit -= k.pruneBlooms(ctx, maxHeight, limit)
+	}
+
+	if maxHeight, ok := expiredHeight(height, params.BlockHashRetentionBlocks); ok {
+		limit -= k.pruneBlockHashes(ctx, maxHeight, limit)
+	}
+
+	return MaxPrunedEntriesPerBlock - limit - visited + deleted
+}
+
+// expiredHeight returns the highest height that is expired for the given retention
+// blocks at the current height. It returns false if the pruning is disabled or if
+// no height is expired yet.
+func expiredHeight(height int64, retentionBlocks uint64) (uint64, bool) {
+	if retentionBlocks == 0 || height <= 0 || uint64(height) <= retentionBlocks {
+		return 0, false
+	}
+
+	return uint64(height) - retentionBlocks, true
+}
+
+// pruneDestructedStorage visits up to limit storage slots of the destructed
+// accounts queued for deletion and deletes the ones written before their last
+// destruction. An account is removed from the queue once all its slots are
+// visited, otherwise the key of the next slot to visit is stored. It returns the
+// number of deleted and visited slots.
+func (k Keeper) pruneDestructedStorage(ctx sdk.Context, limit int) (deleted, visited int) {
+	queueStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixStorageQueue)
+	csdb := k.NewStateDB(ctx)
+
+	// the queue is collected first as the visits write to it. At most limit accounts
+	// are collected, as each of them is removed from the queue or uses the rest of
+	// the limit.
+	var addresses []common.Address
+	queueIterator := queueStore.Iterator(nil, nil)
+	for ; queueIterator.Valid() && len(addresses) < limit; queueIterator.Next() {
+		addresses = append(addresses, common.BytesToAddress(queueIterator.Key()))
+	}
+	queueIterator.Close()
+
+	for _, addr := range addresses {
+		if visited >= limit {
+			break
+		}
+
+		epoch := csdb.GetStorageEpoch(addr)
+		store := prefix.NewStore(ctx.KVStore(k.storeKey), types.AddressStoragePrefix(addr))
+
+		var (
+			keys [][]byte
+			next []byte
+		)
+
+		iterator := store.Iterator(queueStore.Get(addr.Bytes()), nil)
+		for ; iterator.Valid(); iterator.Next() {
+			if visited == limit {
+				next = iterator.Key()
+				break
+			}
+
+			visited++
+			if valueEpoch, _ := types.DecodeStorageValue(iterator.Value()); valueEpoch != epoch {
+				keys = append(keys, iterator.Key())
+			}
+		}
+		iterator.Close()
+
+		for _, key := range keys {
+			store.Delete(key)
+		}
+		deleted += len(keys)
+
+		if next != nil {
+			queueStore.Set(addr.Bytes(), next)
+		} else {
+			queueStore.Delete(addr.Bytes())
+		}
+	}
+
+	return deleted, visited
+}
+
+// pruneLogs deletes up to limit transaction logs stored at a height lower than or
+// equal to maxHeight, together with their height index.
+func (k Keeper) pruneLogs(ctx sdk.Context, maxHeight uint64, limit int) int {
+	heightStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightLogs)
+	keys := expiredKeys(heightStore, maxHeight, limit)
+
+	logsStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixLogs)
+	for _, key := range keys {
+		// the key is the height followed by the tx hash
+		txHash := common.BytesToHash(key[8:])
+		logsStore.Delete(txHash.Bytes())
+		heightStore.Delete(key)
+	}
+
+	return len(keys)
+}
+
+// pruneBlooms deletes up to limit block blooms of a height lower than or equal to
+// maxHeight.
+func (k Keeper) pruneBlooms(ctx sdk.Context, maxHeight uint64, limit int) int {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBloom)
+	keys := expiredKeys(store, maxHeight, limit)
+
+	for _, key := range keys {
+		store.Delete(key)
+	}
+
+	return len(keys)
+}
+
+// pruneBlockHashes deletes up to limit height to hash mappings of a height lower
+// than or equal to maxHeight, together with their hash to height mapping.
+func (k Keeper) pruneBlockHashes(ctx sdk.Context, maxHeight uint64, limit int) int {
+	heightStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixHeightHash)
+	keys := expiredKeys(heightStore, maxHeight, limit)
+
+	hashStore := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixBlockHash)
+	for _, key := range keys {
+		if hash := heightStore.Get(key); len(hash) != 0 {
+			hashStore.Delete(hash)
+		}
+
+		heightStore.Delete(key)
+	}
+
+	return len(keys)
+}
+
+// expiredKeys returns up to limit keys of a store whose keys start with a big
+// endian height, from the lowest height up to maxHeight included. The keys are
+// collected before deleting them as the store can't be modified while iterating.
+func expiredKeys(store sdk.KVStore, maxHeight uint64, limit int) [][]byte {
+	if limit <= 0 {
+		return nil
+	}
+
+	iterator := store.Iterator(nil, sdk.Uint64ToBigEndian(maxHeight+1))
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid() && len(keys) < limit; iterator.Next() {
+		keys = append(keys, iterator.Key())
+	}
+
+	return keys
+}
@@ -0,0 +1,185 @@
+package keeper_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/evm/keeper"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+)
+
+// storeBlock sets the logs of a transaction, the block bloom and the block hash
+// mappings for the given height.
+func (suite *KeeperTestSuite) storeBlock(height int64) (txHash, blockHash ethcmn.Hash) {
+	ctx := suite.ctx.WithBlockHeight(height)
+	txHash = ethcmn.BigToHash(big.NewInt(height))
+	blockHash = ethcmn.BytesToHash(append([]byte("block"), byte(height)))
+
+	logs := []*ethtypes.Log{{Address: suite.address, Data: []byte("log"), BlockNumber: uint64(height)}}
+	suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx, txHash, logs))
+	suite.app.EvmKeeper.SetBlockBloom(ctx, height, ethtypes.BytesToBloom([]byte{0x01}))
+	suite.app.EvmKeeper.SetBlockHash(ctx, blockHash.Bytes(), height)
+	suite.app.EvmKeeper.SetHeightHash(ctx, uint64(height), blockHash)
+
+	return txHash, blockHash
+}
+
+func (suite *KeeperTestSuite) TestPruneExpiredData() {
+	txHashes := make([]ethcmn.Hash, 6)
+	blockHashes := make([]ethcmn.Hash, 6)
+	for height := int64(1); height <= 5; height++ {
+		txHashes[height], blockHashes[height] = suite.storeBlock(height)
+	}
+
+	logsStored := func(height int64) bool {
+		logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, txHashes[height])
+		suite.Require().NoError(err)
+		return len(logs) != 0
+	}
+
+	// nothing is pruned with the default params
+	suite.Require().Zero(suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 300))
+	suite.Require().True(logsStored(1))
+
+	params := types.DefaultParams()
+	params.LogRetentionBlocks = 2
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	// the logs and blooms of the heights 1 to 3 are expired at height 5
+	suite.Require().Equal(6, suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 5))
+
+	for height := int64(1); height <= 5; height++ {
+		expStored := height > 3
+		suite.Require().Equal(expStored, logsStored(height), height)
+
+		_, found := suite.app.EvmKeeper.GetBlockBloom(suite.ctx, height)
+		suite.Require().Equal(expStored, found, height)
+
+		// the block hashes are kept
+		_, found = suite.app.EvmKeeper.GetBlockHash(suite.ctx, blockHashes[height].Bytes())
+		suite.Require().True(found, height)
+		suite.Require().Equal(blockHashes[height], suite.app.EvmKeeper.GetHeightHash(suite.ctx, uint64(height)))
+	}
+
+	suite.Require().Zero(suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 5))
+
+	params.BlockHashRetentionBlocks = types.MinBlockHashRetentionBlocks
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	height := int64(types.MinBlockHashRetentionBlocks + 2)
+	suite.Require().Equal(2+2*2, suite.app.EvmKeeper.PruneExpiredData(suite.ctx, height))
+
+	for height := int64(1); height <= 5; height++ {
+		_, found := suite.app.EvmKeeper.GetBlockHash(suite.ctx, blockHashes[height].Bytes())
+		suite.Require().Equal(height > 2, found, height)
+	}
+
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.GetHeightHash(suite.ctx, 2))
+	suite.Require().Equal(blockHashes[3], suite.app.EvmKeeper.GetHeightHash(suite.ctx, 3))
+}
+
+func (suite *KeeperTestSuite) TestPruneExpiredDataLimit() {
+	params := types.DefaultParams()
+	params.LogRetentionBlocks = 1
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	ctx := suite.ctx.WithBlockHeight(1)
+	for i := 0; i < keeper.MaxPrunedEntriesPerBlock+1; i++ {
+		txHash := ethcmn.BigToHash(big.NewInt(int64(i + 1)))
+		suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx, txHash, []*ethtypes.Log{}))
+	}
+	suite.app.EvmKeeper.SetBlockBloom(ctx, 1, ethtypes.Bloom{})
+
+	// the remaining expired entries are pruned on the next block
+	suite.Require().Equal(keeper.MaxPrunedEntriesPerBlock, suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 2))
+	suite.Require().Equal(2, suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 3))
+	suite.Require().Zero(suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 4))
+}
+
+func (suite *KeeperTestSuite) TestEndBlockPruning() {
+	params := types.DefaultParams()
+	params.LogRetentionBlocks = 10
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	txHash, _ := suite.storeBlock(5)
+
+	_ = suite.app.EvmKeeper.EndBlock(suite.ctx.WithBlockHeight(15), abci.RequestEndBlock{Height: 15})
+
+	logs, err := suite.app.EvmKeeper.GetLogs(suite.ctx, txHash)
+	suite.Require().NoError(err)
+	suite.Require().Empty(logs)
+
+	_, found := suite.app.EvmKeeper.GetBlockBloom(suite.ctx, 5)
+	suite.Require().False(found)
+
+	_, found = suite.app.EvmKeeper.GetBlockBloom(suite.ctx, 15)
+	suite.Require().True(found)
+}
+
+func (suite *KeeperTestSuite) TestPruneDestructedStorage() {
+	value := ethcmn.BytesToHash([]byte("value"))
+	slots := keeper.MaxPrunedEntriesPerBlock + 10
+
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.SetCode(suite.address, []byte("code"))
+	for i := 0; i < slots; i++ {
+		csdb.SetState(suite.address, ethcmn.BigToHash(big.NewInt(int64(i))), value)
+	}
+	_, err := csdb.Commit(false)
+	suite.Require().NoError(err)
+
+	csdb = suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	suite.Require().True(csdb.Suicide(suite.address))
+	_, err = csdb.Commit(true)
+	suite.Require().NoError(err)
+
+	// the contract is re-created at the same address before its slots are deleted
+	newKey := ethcmn.BytesToHash([]byte("new key"))
+	csdb = suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.CreateAccount(suite.address)
+	csdb.SetCode(suite.address, []byte("code"))
+	suite.Require().Equal(ethcmn.Hash{}, csdb.GetState(suite.address, ethcmn.BigToHash(big.NewInt(1))))
+	csdb.SetState(suite.address, newKey, value)
+	_, err = csdb.Commit(false)
+	suite.Require().NoError(err)
+
+	storage, err := suite.app.EvmKeeper.GetAccountStorage(suite.ctx, suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Len(storage, 1)
+	suite.Require().Equal(value.String(), storage[0].Value)
+
+	storeKey := suite.app.GetKey(types.StoreKey)
+	storedSlots := func() int {
+		iterator := sdk.KVStorePrefixIterator(suite.ctx.KVStore(storeKey), types.AddressStoragePrefix(suite.address))
+		defer iterator.Close()
+
+		var count int
+		for ; iterator.Valid(); iterator.Next() {
+			count++
+		}
+		return count
+	}
+	queued := func() bool {
+		return suite.ctx.KVStore(storeKey).Has(append(types.KeyPrefixStorageQueue, suite.address.Bytes()...))
+	}
+
+	suite.Require().Equal(slots+1, storedSlots())
+	suite.Require().True(queued())
+
+	// the deletions are bounded per block
+	deleted := suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 1)
+	suite.Require().True(deleted >= keeper.MaxPrunedEntriesPerBlock-1)
+	suite.Require().Equal(slots+1-deleted, storedSlots())
+	suite.Require().True(queued())
+
+	suite.Require().Equal(slots-deleted, suite.app.EvmKeeper.PruneExpiredData(suite.ctx, 2))
+	suite.Require().Equal(1, storedSlots())
+	suite.Require().False(queued())
+	suite.Require().Equal(value, suite.app.EvmKeeper.GetState(suite.ctx, suite.address, newKey))
+}
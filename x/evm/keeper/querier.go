@@ -1,6 +1,7 @@
 package keeper
 
 import (
+	"encoding/json"
 	"fmt"
 	"strconv"
 
@@ -18,7 +19,7 @@ import (
 
 // NewQuerier is the module level router for state queries
 func NewQuerier(keeper Keeper) sdk.Querier {
-	return func(ctx sdk.Context, path []string, _ abci.RequestQuery) ([]byte, error) {
+	return func(ctx sdk.Context, path []string, req abci.RequestQuery) ([]byte, error) {
 		if len(path) < 1 {
 			return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
 				"Insufficient parameters, at least 1 parameter is required")
@@ -31,18 +32,34 @@ func NewQuerier(keeper Keeper) sdk.Querier {
 			return queryBlockNumber(ctx, keeper)
 		case types.QueryStorage:
 			return queryStorage(ctx, path, keeper)
+		case types.QueryStorageRange:
+			return queryStorageRange(ctx, path, keeper)
 		case types.QueryCode:
 			return queryCode(ctx, path, keeper)
 		case types.QueryHashToHeight:
 			return queryHashToHeight(ctx, path, keeper)
 		case types.QueryTransactionLogs:
 			return queryTransactionLogs(ctx, path, keeper)
+		case types.QueryBlockLogs:
+			return queryBlockLogs(ctx, path, keeper)
 		case types.QueryBloom:
 			return queryBlockBloom(ctx, path, keeper)
 		case types.QueryLogs:
 			return queryLogs(ctx, keeper)
 		case types.QueryAccount:
 			return queryAccount(ctx, path, keeper)
+		case types.QueryCall:
+			return queryCall(ctx, req, keeper)
+		case types.QueryEstimateGas:
+			return queryEstimateGas(ctx, req, keeper)
+		case types.QueryCallBundle:
+			return queryCallBundle(ctx, req, keeper)
+		case types.QueryTraceTx:
+			return queryTraceTx(path, keeper)
+		case types.QueryTraceBlock:
+			return queryTraceBlock(path, keeper)
+		case types.QueryTraceFilter:
+			return queryTraceFilter(req, keeper)
 		default:
 			return nil, sdkerrors.Wrap(sdkerrors.ErrUnknownRequest, "unknown query endpoint")
 		}
@@ -99,6 +116,36 @@ func queryStorage(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error)
 	return bz, nil
 }
 
+func queryStorageRange(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 4 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 4 parameters is required")
+	}
+
+	addr := ethcmn.HexToAddress(path[1])
+	start := ethcmn.HexToHash(path[2])
+	limit, err := strconv.Atoi(path[3])
+	if err != nil || limit < 0 {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid limit %s", path[3])
+	}
+
+	if limit == 0 || limit > types.MaxStorageRangeLimit {
+		limit = types.MaxStorageRangeLimit
+	}
+
+	storage, nextKey := keeper.GetStorageRange(ctx, addr, start, limit)
+	res := types.QueryResStorageRange{Storage: storage}
+	if nextKey != nil {
+		res.NextKey = nextKey.Hex()
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
 func queryCode(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
 	if len(path) < 2 {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
@@ -176,6 +223,24 @@ func queryTransactionLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte
 	}
 
 	res := types.QueryETHLogs{Logs: logs}
+
+	// the page is optional, to return all the logs to the existing clients
+	if len(path) > 2 {
+		offset, limit, err := parseLogsPage(path[2:])
+		if err != nil {
+			return nil, err
+		}
+
+		res.Total = len(logs)
+		if offset > len(logs) {
+			offset = len(logs)
+		}
+		if limit > len(logs)-offset {
+			limit = len(logs) - offset
+		}
+		res.Logs = logs[offset : offset+limit]
+	}
+
 	bz, err := codec.MarshalJSONIndent(keeper.cdc, res)
 	if err != nil {
 		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
@@ -184,6 +249,61 @@ func queryTransactionLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte
 	return bz, nil
 }
 
+func queryBlockLogs(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	height, err := strconv.ParseUint(path[1], 10, 64)
+	if err != nil {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid height %s", path[1])
+	}
+
+	offset, limit := 0, types.MaxLogsPageLimit
+	if len(path) > 2 {
+		if offset, limit, err = parseLogsPage(path[2:]); err != nil {
+			return nil, err
+		}
+	}
+
+	txsLogs, total, err := keeper.GetBlockTxLogs(ctx, height, offset, limit)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := codec.MarshalJSONIndent(keeper.cdc, types.QueryResBlockLogs{TxLogs: txsLogs, Total: total})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+
+	return bz, nil
+}
+
+// parseLogsPage parses the offset and limit of a page of the logs queries. A zero
+// limit defaults to the maximum page size.
+func parseLogsPage(path []string) (offset, limit int, err error) {
+	if len(path) < 2 {
+		return 0, 0, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest, "a page requires both an offset and a limit")
+	}
+
+	offset, err = strconv.Atoi(path[0])
+	if err != nil || offset < 0 {
+		return 0, 0, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid offset %s", path[0])
+	}
+
+	limit, err = strconv.Atoi(path[1])
+	if err != nil || limit < 0 {
+		return 0, 0, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest, "invalid limit %s", path[1])
+	}
+
+	if limit == 0 || limit > types.MaxLogsPageLimit {
+		limit = types.MaxLogsPageLimit
+	}
+
+	return offset, limit, nil
+}
+
 func queryLogs(ctx sdk.Context, keeper Keeper) ([]byte, error) {
 	logs := keeper.AllLogs(ctx)
 
@@ -202,7 +322,7 @@ func queryAccount(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error)
 	}
 
 	addr := ethcmn.HexToAddress(path[1])
-	so := keeper.GetOrNewStateObject(ctx, addr)
+	so := keeper.NewStateDB(ctx).GetOrNewStateObject(addr)
 
 	balance, err := utils.MarshalBigInt(so.Balance())
 	if err != nil {
@@ -220,3 +340,133 @@ func queryAccount(ctx sdk.Context, path []string, keeper Keeper) ([]byte, error)
 	}
 	return bz, nil
 }
+
+func queryCall(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryCallParams
+	if err := json.Unmarshal(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	res, gasInfo, err := simulateCall(ctx, req.Height, params, keeper)
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := types.DecodeResultData(res.Result.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(types.QueryResCall{
+		Result:   resultData,
+		GasUsed:  gasInfo.GasConsumed,
+		GasLimit: gasInfo.GasLimit,
+	})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryEstimateGas(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryCallParams
+	if err := json.Unmarshal(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	gas, err := estimateGas(ctx, req.Height, params, keeper)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(types.QueryResEstimateGas{Gas: gas})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryCallBundle(ctx sdk.Context, req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var params types.QueryCallBundleParams
+	if err := json.Unmarshal(req.Data, &params); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if len(params.Calls) > types.MaxCallBundleSize {
+		return nil, sdkerrors.Wrapf(
+			sdkerrors.ErrInvalidRequest, "bundle of %d calls exceeds the maximum of %d", len(params.Calls), types.MaxCallBundleSize,
+		)
+	}
+
+	results := simulateCallBundle(ctx, req.Height, params, keeper)
+
+	bz, err := json.Marshal(types.QueryResCallBundle{Results: results})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTraceTx(path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	trace, err := keeper.traceStore.GetTxTrace(ethcmn.HexToHash(path[1]))
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(types.QueryResTraceTx{Trace: trace})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTraceBlock(path []string, keeper Keeper) ([]byte, error) {
+	if len(path) < 2 {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrInvalidRequest,
+			"Insufficient parameters, at least 2 parameters is required")
+	}
+
+	height, err := strconv.ParseInt(path[1], 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("could not unmarshal block height: %w", err)
+	}
+
+	traces, err := keeper.traceStore.GetBlockTraces(height)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(types.QueryResTraceBlock{Traces: traces})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
+
+func queryTraceFilter(req abci.RequestQuery, keeper Keeper) ([]byte, error) {
+	var filter types.TraceFilter
+	if err := json.Unmarshal(req.Data, &filter); err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONUnmarshal, err.Error())
+	}
+
+	if filter.FromBlock > filter.ToBlock {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrInvalidRequest,
+			"invalid block range %d-%d", filter.FromBlock, filter.ToBlock)
+	}
+
+	frames, err := keeper.traceStore.FilterTraces(filter)
+	if err != nil {
+		return nil, err
+	}
+
+	bz, err := json.Marshal(types.QueryResTraceFilter{Frames: frames})
+	if err != nil {
+		return nil, sdkerrors.Wrap(sdkerrors.ErrJSONMarshal, err.Error())
+	}
+	return bz, nil
+}
@@ -1,12 +1,20 @@
 package keeper_test
 
 import (
+	"encoding/json"
 	"math/big"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 
 	abci "github.com/tendermint/tendermint/abci/types"
+	dbm "github.com/tendermint/tm-db"
 )
 
 func (suite *KeeperTestSuite) TestQuerier() {
@@ -59,3 +67,422 @@ func (suite *KeeperTestSuite) TestQuerier() {
 		})
 	}
 }
+
+func (suite *KeeperTestSuite) TestQuerierStorageRange() {
+	for i := byte(1); i <= 3; i++ {
+		suite.app.EvmKeeper.SetState(suite.ctx, suite.address, ethcmn.BytesToHash([]byte{i}), ethcmn.BytesToHash([]byte{i}))
+	}
+
+	// iterate the storage by ranges of 2 slots
+	var storage types.Storage
+	start := ethcmn.Hash{}
+	for ranges := 0; ; ranges++ {
+		suite.Require().Less(ranges, 2)
+
+		bz, err := suite.querier(suite.ctx, []string{types.QueryStorageRange, addrHex, start.Hex(), "2"}, abci.RequestQuery{})
+		suite.Require().NoError(err)
+
+		var res types.QueryResStorageRange
+		suite.app.Codec().MustUnmarshalJSON(bz, &res)
+		suite.Require().LessOrEqual(len(res.Storage), 2)
+		storage = append(storage, res.Storage...)
+
+		if res.NextKey == "" {
+			break
+		}
+		start = ethcmn.HexToHash(res.NextKey)
+	}
+
+	expStorage, err := suite.app.EvmKeeper.GetAccountStorage(suite.ctx, suite.address)
+	suite.Require().NoError(err)
+	suite.Require().Len(expStorage, 3)
+	suite.Require().Equal(expStorage, storage)
+
+	// the whole storage fits in a range of the maximum size
+	storage, nextKey := suite.app.EvmKeeper.GetStorageRange(suite.ctx, suite.address, ethcmn.Hash{}, types.MaxStorageRangeLimit)
+	suite.Require().Equal(expStorage, storage)
+	suite.Require().Nil(nextKey)
+
+	// a zero limit defaults to the maximum number of slots
+	bz, err := suite.querier(suite.ctx, []string{types.QueryStorageRange, addrHex, "0x0", "0"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	var res types.QueryResStorageRange
+	suite.app.Codec().MustUnmarshalJSON(bz, &res)
+	suite.Require().Equal(expStorage, res.Storage)
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryStorageRange, addrHex, "0x0", "-1"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+	_, err = suite.querier(suite.ctx, []string{types.QueryStorageRange, addrHex, "0x0"}, abci.RequestQuery{})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQuerierLogsPages() {
+	ctx := suite.ctx.WithBlockHeight(7)
+	txHashes := []ethcmn.Hash{{1}, {2}, {3}}
+	for _, txHash := range txHashes {
+		logs := []*ethtypes.Log{
+			{TxHash: txHash, Topics: []ethcmn.Hash{{0xa}}, Data: []byte{1}, Index: 0},
+			{TxHash: txHash, Topics: []ethcmn.Hash{{0xa}}, Data: []byte{1}, Index: 1},
+		}
+		suite.Require().NoError(suite.app.EvmKeeper.SetLogs(ctx, txHash, logs))
+	}
+
+	// the transaction logs are paginated by offset
+	bz, err := suite.querier(ctx, []string{types.QueryTransactionLogs, txHashes[0].Hex(), "1", "5"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	var txRes types.QueryETHLogs
+	suite.app.Codec().MustUnmarshalJSON(bz, &txRes)
+	suite.Require().Equal(2, txRes.Total)
+	suite.Require().Len(txRes.Logs, 1)
+	suite.Require().Equal(uint(1), txRes.Logs[0].Index)
+
+	// without a page, all the logs are returned
+	bz, err = suite.querier(ctx, []string{types.QueryTransactionLogs, txHashes[0].Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	txRes = types.QueryETHLogs{}
+	suite.app.Codec().MustUnmarshalJSON(bz, &txRes)
+	suite.Require().Zero(txRes.Total)
+	suite.Require().Len(txRes.Logs, 2)
+
+	// the block logs are paginated by transaction
+	bz, err = suite.querier(ctx, []string{types.QueryBlockLogs, "7", "1", "1"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	var blockRes types.QueryResBlockLogs
+	suite.app.Codec().MustUnmarshalJSON(bz, &blockRes)
+	suite.Require().Equal(3, blockRes.Total)
+	suite.Require().Len(blockRes.TxLogs, 1)
+	suite.Require().Equal(txHashes[1].Hex(), blockRes.TxLogs[0].Hash)
+	suite.Require().Len(blockRes.TxLogs[0].Logs, 2)
+
+	bz, err = suite.querier(ctx, []string{types.QueryBlockLogs, "7"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	blockRes = types.QueryResBlockLogs{}
+	suite.app.Codec().MustUnmarshalJSON(bz, &blockRes)
+	suite.Require().Len(blockRes.TxLogs, 3)
+
+	bz, err = suite.querier(ctx, []string{types.QueryBlockLogs, "7", "3", "0"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+	blockRes = types.QueryResBlockLogs{}
+	suite.app.Codec().MustUnmarshalJSON(bz, &blockRes)
+	suite.Require().Empty(blockRes.TxLogs)
+	suite.Require().Equal(3, blockRes.Total)
+
+	for _, path := range [][]string{
+		{types.QueryTransactionLogs, txHashes[0].Hex(), "1"},
+		{types.QueryTransactionLogs, txHashes[0].Hex(), "-1", "1"},
+		{types.QueryBlockLogs, "7", "0", "-1"},
+		{types.QueryBlockLogs, "-7"},
+		{types.QueryBlockLogs},
+	} {
+		_, err = suite.querier(ctx, path, abci.RequestQuery{})
+		suite.Require().Error(err, path)
+	}
+}
+
+func (suite *KeeperTestSuite) TestQuerierCall() {
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, answerBytecode)
+	suite.Require().NoError(err)
+
+	data, err := answerABI.Pack("answer")
+	suite.Require().NoError(err)
+
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{
+		Data: mustMarshalJSON(types.QueryCallParams{From: suite.address, To: &contract, Data: data}),
+	})
+	suite.Require().NoError(err)
+
+	var res types.QueryResCall
+	suite.Require().NoError(json.Unmarshal(bz, &res))
+	suite.Require().Equal(ethcmn.LeftPadBytes([]byte{42}, 32), res.Result.Ret)
+	suite.Require().Equal(uint64(ethermint.DefaultRPCGasLimit), res.GasLimit)
+	suite.Require().True(res.GasUsed > 21000)
+
+	// the call isn't committed
+	suite.Require().Equal(nonce, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+
+	// the estimated gas of a transfer is the intrinsic gas
+	suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, big.NewInt(1))
+	recipient := ethcmn.BytesToAddress([]byte("recipient"))
+
+	bz, err = suite.querier(suite.ctx, []string{types.QueryEstimateGas}, abci.RequestQuery{
+		Data: mustMarshalJSON(types.QueryCallParams{
+			From: suite.address, To: &recipient, Gas: 21000, Value: sdk.OneInt(),
+		}),
+	})
+	suite.Require().NoError(err)
+
+	var estimate types.QueryResEstimateGas
+	suite.Require().NoError(json.Unmarshal(bz, &estimate))
+	suite.Require().Equal(uint64(21000), estimate.Gas)
+	suite.Require().Zero(suite.app.EvmKeeper.GetBalance(suite.ctx, recipient).Sign())
+
+	// the estimate falls back to the RPC gas limit when the gas doesn't cover the
+	// intrinsic gas, but the call fails
+	lowGas := types.QueryCallParams{From: suite.address, To: &recipient, Gas: 20999}
+	bz, err = suite.querier(suite.ctx, []string{types.QueryEstimateGas}, abci.RequestQuery{Data: mustMarshalJSON(lowGas)})
+	suite.Require().NoError(err)
+	suite.Require().NoError(json.Unmarshal(bz, &estimate))
+	suite.Require().Equal(uint64(21000), estimate.Gas)
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: mustMarshalJSON(lowGas)})
+	suite.Require().Error(err)
+
+	testCases := []struct {
+		msg    string
+		params types.QueryCallParams
+	}{
+		{"insufficient funds", types.QueryCallParams{From: suite.address, To: &recipient, Value: sdk.NewInt(2)}},
+		{"insufficient funds for gas", types.QueryCallParams{From: suite.address, To: &recipient, GasPrice: sdk.OneInt()}},
+		{"negative value", types.QueryCallParams{From: suite.address, To: &recipient, Value: sdk.NewInt(-1)}},
+		{"reverted deployment", types.QueryCallParams{From: suite.address, Data: []byte{0xfe}}},
+	}
+
+	for _, tc := range testCases {
+		for _, route := range []string{types.QueryCall, types.QueryEstimateGas} {
+			_, err = suite.querier(suite.ctx, []string{route}, abci.RequestQuery{
+				Data: mustMarshalJSON(tc.params),
+			})
+			suite.Require().Error(err, tc.msg)
+		}
+	}
+
+	_, err = suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: []byte("{")})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQuerierEstimateGasNestedCall() {
+	// the callee stores 1 in the slot 0, which needs more gas than the 1/64 of the
+	// gas the caller keeps, and the caller reverts if the call fails
+	callee := ethcmn.BytesToAddress([]byte("callee"))
+	caller := ethcmn.BytesToAddress([]byte("caller"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, callee, ethcmn.FromHex("0x600160005500"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, caller, ethcmn.FromHex(
+		"0x6000600060006000600073"+ethcmn.Bytes2Hex(callee.Bytes())+"5af1156026570060005b60006000fd",
+	))
+
+	params := types.QueryCallParams{From: suite.address, To: &caller}
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().NoError(err)
+	var res types.QueryResCall
+	suite.Require().NoError(json.Unmarshal(bz, &res))
+
+	bz, err = suite.querier(suite.ctx, []string{types.QueryEstimateGas}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().NoError(err)
+	var estimate types.QueryResEstimateGas
+	suite.Require().NoError(json.Unmarshal(bz, &estimate))
+
+	// the gas used by the call isn't enough to execute it
+	suite.Require().True(estimate.Gas > res.GasUsed)
+
+	params.Gas = res.GasUsed
+	_, err = suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().Error(err)
+
+	params.Gas = estimate.Gas - 1
+	_, err = suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().Error(err)
+
+	params.Gas = estimate.Gas
+	_, err = suite.querier(suite.ctx, []string{types.QueryCall}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().NoError(err)
+}
+
+func mustMarshalJSON(v interface{}) []byte {
+	bz, err := json.Marshal(v)
+	if err != nil {
+		panic(err)
+	}
+	return bz
+}
+
+func (suite *KeeperTestSuite) TestQuerierCallBundle() {
+	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address)
+	contract := ethcrypto.CreateAddress(suite.address, nonce)
+
+	data, err := answerABI.Pack("answer")
+	suite.Require().NoError(err)
+
+	// CODECOPY(0, 12, 100), REVERT(0, 100) followed by the Error("denied") revert data
+	revertData := ethcmn.FromHex("0x08c379a0" +
+		"0000000000000000000000000000000000000000000000000000000000000020" +
+		"0000000000000000000000000000000000000000000000000000000000000006" +
+		"64656e6965640000000000000000000000000000000000000000000000000000")
+	revertBytecode := append(ethcmn.FromHex("0x6064600c60003960646000fd"), revertData...)
+
+	wrongNonce := nonce
+	params := types.QueryCallBundleParams{
+		Calls: []types.QueryCallParams{
+			// the contract deployed by the first call is called by the second one
+			{From: suite.address, Data: answerBytecode},
+			{From: suite.address, To: &contract, Data: data},
+			{From: suite.address, Data: revertBytecode},
+			{From: suite.address, To: &contract, Nonce: &wrongNonce},
+			{From: suite.address, Data: helloBytecode},
+		},
+	}
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryCallBundle}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().NoError(err)
+
+	var res types.QueryResCallBundle
+	suite.Require().NoError(json.Unmarshal(bz, &res))
+	suite.Require().Len(res.Results, 5)
+
+	suite.Require().Empty(res.Results[0].Error)
+	suite.Require().Equal(contract, *res.Results[0].ContractAddress)
+
+	suite.Require().Empty(res.Results[1].Error)
+	suite.Require().Equal(ethcmn.LeftPadBytes([]byte{42}, 32), res.Results[1].Ret)
+	suite.Require().True(res.Results[1].GasUsed > 21000)
+
+	// the reverted call returns its revert data and increments the nonce
+	suite.Require().Equal("execution reverted: denied", res.Results[2].Error)
+	suite.Require().Equal(revertData, res.Results[2].Revert)
+	suite.Require().NotZero(res.Results[2].GasUsed)
+
+	suite.Require().Contains(res.Results[3].Error, "invalid nonce")
+	suite.Require().Zero(res.Results[3].GasUsed)
+
+	// the deployment after the failed calls gets the next nonce
+	suite.Require().Empty(res.Results[4].Error)
+	suite.Require().Equal(ethcrypto.CreateAddress(suite.address, nonce+3), *res.Results[4].ContractAddress)
+	suite.Require().Len(res.Results[4].Logs, 1)
+	suite.Require().Equal(uint(4), res.Results[4].Logs[0].TxIndex)
+
+	// the state changes of the bundle are discarded
+	suite.Require().Equal(nonce, suite.app.EvmKeeper.GetNonce(suite.ctx, suite.address))
+	suite.Require().Empty(suite.app.EvmKeeper.GetCode(suite.ctx, contract))
+	suite.Require().Empty(suite.app.EvmKeeper.AllLogs(suite.ctx))
+
+	// the bundle size is capped
+	params.Calls = make([]types.QueryCallParams, types.MaxCallBundleSize+1)
+	_, err = suite.querier(suite.ctx, []string{types.QueryCallBundle}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().Error(err)
+}
+
+func (suite *KeeperTestSuite) TestQuerierCallBundleGasCap() {
+	// JUMPDEST, JUMP(0) loops until the gas is spent
+	loop := ethcmn.BytesToAddress([]byte("loop"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, loop, ethcmn.FromHex("0x5b600056"))
+
+	gas := types.MaxCallBundleGas * 3 / 5
+	params := types.QueryCallBundleParams{
+		Calls: []types.QueryCallParams{
+			{From: suite.address, To: &loop, Gas: gas},
+			{From: suite.address, To: &loop, Gas: gas},
+			{From: suite.address, To: &loop, Gas: gas},
+		},
+	}
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryCallBundle}, abci.RequestQuery{Data: mustMarshalJSON(params)})
+	suite.Require().NoError(err)
+
+	var res types.QueryResCallBundle
+	suite.Require().NoError(json.Unmarshal(bz, &res))
+	suite.Require().Len(res.Results, 3)
+
+	// the second call only gets the gas left by the first one
+	suite.Require().NotEmpty(res.Results[0].Error)
+	suite.Require().Equal(gas, res.Results[0].GasUsed)
+	suite.Require().NotEmpty(res.Results[1].Error)
+	suite.Require().Equal(types.MaxCallBundleGas-gas, res.Results[1].GasUsed)
+
+	suite.Require().Contains(res.Results[2].Error, "bundle gas cap")
+	suite.Require().Zero(res.Results[2].GasUsed)
+}
+
+// proxyCode returns the runtime code of a contract that calls the target and
+// returns the first 32 bytes of its output, i.e CALL(0xffff, target, 0, 0, 0, 0,
+// 32), POP, RETURN(0, 32).
+func proxyCode(target ethcmn.Address) []byte {
+	code := ethcmn.FromHex("0x60206000600060006000")
+	code = append(append(append(code, 0x73), target.Bytes()...), ethcmn.FromHex("0x61fffff15060206000f3")...)
+	return code
+}
+
+func (suite *KeeperTestSuite) TestQuerierTrace() {
+	_, err := suite.querier(suite.ctx, []string{types.QueryTraceBlock, "1"}, abci.RequestQuery{})
+	suite.Require().Equal(types.ErrTracingDisabled, err)
+
+	suite.app.EvmKeeper.TraceStore().SetDB(dbm.NewMemDB())
+
+	answer, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, answerBytecode)
+	suite.Require().NoError(err)
+
+	proxy, reverter, reverterProxy := ethcmn.Address{0x1}, ethcmn.Address{0x2}, ethcmn.Address{0x3}
+	suite.app.EvmKeeper.SetCode(suite.ctx, proxy, proxyCode(answer))
+	// REVERT(0, 0)
+	suite.app.EvmKeeper.SetCode(suite.ctx, reverter, ethcmn.FromHex("0x60006000fd"))
+	suite.app.EvmKeeper.SetCode(suite.ctx, reverterProxy, proxyCode(reverter))
+
+	values, err := suite.app.EvmKeeper.CallEVM(suite.ctx, suite.address, proxy, answerABI, "answer")
+	suite.Require().NoError(err)
+	suite.Require().Equal([]interface{}{big.NewInt(42)}, values)
+
+	_, err = suite.app.EvmKeeper.CallEVM(suite.ctx, suite.address, reverterProxy, answerABI, "answer")
+	suite.Require().NoError(err)
+
+	bz, err := suite.querier(suite.ctx, []string{types.QueryTraceBlock, "1"}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var res types.QueryResTraceBlock
+	suite.Require().NoError(json.Unmarshal(bz, &res))
+	suite.Require().Len(res.Traces, 3)
+
+	// the deployment
+	deployment := res.Traces[0]
+	suite.Require().Len(deployment.Frames, 1)
+	suite.Require().Equal(types.CallTypeCreate, deployment.Frames[0].Type)
+	suite.Require().Equal(answer, deployment.Frames[0].To)
+	suite.Require().Equal(answerBytecode[12:], deployment.Frames[0].Output)
+
+	// the call through the proxy
+	call := res.Traces[1]
+	suite.Require().Equal(uint32(1), call.Index)
+	suite.Require().Len(call.Frames, 2)
+	suite.Require().Equal(1, call.Frames[0].Subtraces)
+	suite.Require().Empty(call.Frames[0].TraceAddress)
+	suite.Require().Equal(proxy, call.Frames[0].To)
+
+	inner := call.Frames[1]
+	suite.Require().Equal(types.CallTypeCall, inner.Type)
+	suite.Require().Equal(proxy, inner.From)
+	suite.Require().Equal(answer, inner.To)
+	suite.Require().Equal([]int{0}, inner.TraceAddress)
+	suite.Require().Equal(ethcmn.LeftPadBytes([]byte{42}, 32), inner.Output)
+	suite.Require().NotZero(inner.Gas)
+	// MSTORE(0, 42), RETURN(0, 32) including the memory expansion
+	suite.Require().Equal(uint64(18), inner.GasUsed)
+	suite.Require().Empty(inner.Error)
+
+	// the reverted inner call doesn't fail the transaction
+	reverted := res.Traces[2]
+	suite.Require().Len(reverted.Frames, 2)
+	suite.Require().Empty(reverted.Frames[0].Error)
+	suite.Require().Equal("execution reverted", reverted.Frames[1].Error)
+
+	bz, err = suite.querier(suite.ctx, []string{types.QueryTraceTx, call.TxHash.Hex()}, abci.RequestQuery{})
+	suite.Require().NoError(err)
+
+	var txRes types.QueryResTraceTx
+	suite.Require().NoError(json.Unmarshal(bz, &txRes))
+	suite.Require().Equal(call, *txRes.Trace)
+
+	filter := types.TraceFilter{FromBlock: 1, ToBlock: 1, ToAddresses: []ethcmn.Address{answer}}
+	bz, err = suite.querier(suite.ctx, []string{types.QueryTraceFilter}, abci.RequestQuery{Data: mustMarshalJSON(filter)})
+	suite.Require().NoError(err)
+
+	var filterRes types.QueryResTraceFilter
+	suite.Require().NoError(json.Unmarshal(bz, &filterRes))
+	suite.Require().Len(filterRes.Frames, 2)
+	suite.Require().Equal(types.CallTypeCreate, filterRes.Frames[0].Type)
+	suite.Require().Equal(inner, filterRes.Frames[1].CallFrame)
+	suite.Require().Equal(call.TxHash, filterRes.Frames[1].TxHash)
+
+	filter = types.TraceFilter{FromBlock: 2, ToBlock: 1}
+	_, err = suite.querier(suite.ctx, []string{types.QueryTraceFilter}, abci.RequestQuery{Data: mustMarshalJSON(filter)})
+	suite.Require().Error(err)
+}
@@ -0,0 +1,83 @@
+package keeper
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// gasRefund defines the fee of the unused gas of a failed transaction, which is
+// refunded to its sender once the failed message is reverted, as part of the same
+// DeliverTx (see PayFailedTxRefund).
+type gasRefund struct {
+	ctx    sdk.Context
+	sender common.Address
+	amount sdk.Coins
+}
+
+// refundGas refunds the sender of a MsgEthereumTx the fee of its unused gas, i.e
+// the gas limit minus the gas consumed on the context since gasStart, at the gas
+// price of the transaction. The AnteHandler deducts the fee of the whole gas limit, so the
+// refund is paid by the fee collector, which keeps the fee of the gas used.
+//
+// The state changes of a failed message are discarded, so the refund of a failed
+// transaction is kept by the keeper instead, and paid by PayFailedTxRefund once the
+// message is reverted.
+func (k *Keeper) refundGas(ctx sdk.Context, msg types.MsgEthereumTx, sender common.Address, gasStart uint64, err *error) {
+	gasUsed := ctx.GasMeter().GasConsumed() - gasStart
+	if gasUsed > msg.Data.GasLimit {
+		gasUsed = msg.Data.GasLimit
+	}
+
+	// the refund doesn't consume gas, as it's not part of the execution
+	ctx = ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	params := k.GetParams(ctx)
+
+	// the fees are rounded up on the EVM denomination, as by the AnteHandler
+	price := msg.Data.Price.BigInt()
+	paid := params.FromEVMAmountCeil(new(big.Int).Mul(price, new(big.Int).SetUint64(msg.Data.GasLimit)))
+	fee := params.FromEVMAmountCeil(new(big.Int).Mul(price, new(big.Int).SetUint64(gasUsed)))
+
+	amount := sdk.NewCoins(sdk.NewCoin(params.EvmDenom, paid.Sub(fee)))
+	if amount.IsZero() {
+		return
+	}
+
+	if *err != nil {
+		k.failedTxRefund = &gasRefund{ctx: ctx, sender: sender, amount: amount}
+		return
+	}
+
+	if sendErr := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, auth.FeeCollectorName, sender.Bytes(), amount); sendErr != nil {
+		*err = sdkerrors.Wrap(sendErr, "failed to refund the unused gas")
+	}
+}
+
+// PayFailedTxRefund pays the gas refund of the failed MsgEthereumTx of the last
+// DeliverTx, if any, from the fee collector. It must be called by the app at the end
+// of DeliverTx with the multistore of the block, i.e the parent of the reverted
+// cached store of the message. A refund that can't be paid is logged.
+func (k *Keeper) PayFailedTxRefund(ms sdk.MultiStore) {
+	refund := k.failedTxRefund
+	if refund == nil {
+		return
+	}
+
+	k.failedTxRefund = nil
+
+	// the refund doesn't consume gas and its events aren't part of the failed transaction
+	ctx := refund.ctx.
+		WithMultiStore(ms).
+		WithGasMeter(sdk.NewInfiniteGasMeter()).
+		WithEventManager(sdk.NewEventManager())
+
+	if err := k.supplyKeeper.SendCoinsFromModuleToAccount(ctx, auth.FeeCollectorName, refund.sender.Bytes(), refund.amount); err != nil {
+		k.Logger(ctx).Error("failed to refund the unused gas", "sender", refund.sender.String(), "error", err)
+	}
+}
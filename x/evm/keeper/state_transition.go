@@ -0,0 +1,110 @@
+package keeper
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+// ApplyTransition executes the state transition on a new CommitStateDB created
+// over a cached context. The EVM state changes, the logs and the block bloom are
+// only written when the execution, and the EVM hooks if ethTx is true, succeed and
+// the transition isn't simulated. A failed transition therefore doesn't leave any
+// state behind (see https://github.com/cosmos/ethermint/issues/668).
+//
+// ethTx is true for the Ethereum transactions (i.e MsgEthereumTx and MsgEthermint),
+// which are the only ones indexed in the block by TxCount and followed by the EVM
+// hooks. The other transitions are executed on behalf of a module and counted
+// separately.
+//
+// The CommitStateDB of the given state transition is replaced. The chain config
+// is passed by the caller, which reads it with the gas meter of its choice.
+//
+// If tracing is enabled, the call frames of the transitions that aren't simulated
+// are recorded on the TraceStore, whether they succeed or not.
+func (k *Keeper) ApplyTransition(
+	ctx sdk.Context, st types.StateTransition, config types.ChainConfig, ethTx bool,
+) (executionResult *types.ExecutionResult, err error) {
+	cacheCtx, commit := ctx.CacheContext()
+	st.Csdb = k.NewStateDB(cacheCtx)
+
+	// since the txCount is used by the stateDB, and a simulated tx is run only on the node it's submitted to,
+	// then this will cause the txCount/stateDB of the node that ran the simulated tx to be different than the
+	// other nodes, causing a consensus error
+	if !st.Simulate {
+		txIndex := k.moduleCallCount
+		if ethTx {
+			txIndex = k.TxCount
+		}
+
+		if k.traceStore.Enabled() {
+			st.Tracer = types.NewCallTracer()
+			defer k.setTxTrace(ctx, *st.TxHash, uint32(txIndex), st.Tracer, &err)
+		}
+
+		// Prepare db for logs
+		st.Csdb.Prepare(*st.TxHash, txIndex)
+
+		if ethTx {
+			k.TxCount++
+		} else {
+			k.moduleCallCount++
+		}
+	}
+
+	executionResult, err = st.TransitionDb(cacheCtx, config)
+	if err != nil {
+		return nil, err
+	}
+
+	// the state changes of simulated transitions are discarded
+	if st.Simulate {
+		return executionResult, nil
+	}
+
+	if ethTx {
+		// the hooks are called before updating the block bloom filter so that their
+		// errors revert the whole transaction. They don't consume the gas of the
+		// transaction, so that its gas used is the one of the receipt.
+		receipt := st.Receipt(cacheCtx, executionResult, uint(k.TxCount-1))
+		if err := k.PostTxProcessing(cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter()), st.Sender, st.Recipient, receipt); err != nil {
+			return nil, sdkerrors.Wrap(err, "failed to execute the EVM hooks")
+		}
+	}
+
+	// update transaction logs in KVStore. The logs are paid by the EVM execution, so
+	// storing them doesn't consume gas.
+	if err := k.SetLogs(cacheCtx.WithGasMeter(sdk.NewInfiniteGasMeter()), *st.TxHash, executionResult.Logs); err != nil {
+		return nil, err
+	}
+
+	commit()
+
+	// update block bloom filter
+	k.Bloom.Or(k.Bloom, executionResult.Bloom)
+
+	return executionResult, nil
+}
+
+// setTxTrace stores the call frames recorded by the tracer. The trace isn't part
+// of the consensus state, so the storage errors are only logged.
+func (k Keeper) setTxTrace(ctx sdk.Context, txHash common.Hash, index uint32, tracer *types.CallTracer, err *error) {
+	frames := tracer.Frames(*err)
+	if len(frames) == 0 {
+		return
+	}
+
+	trace := types.TxTrace{
+		TxHash: txHash,
+		Height: ctx.BlockHeight(),
+		Index:  index,
+		Frames: frames,
+	}
+
+	if err := k.traceStore.SetTxTrace(trace); err != nil {
+		k.Logger(ctx).Error("failed to store the call trace", "hash", txHash.String(), "error", err)
+	}
+}
@@ -18,7 +18,8 @@ import (
 func (suite *KeeperTestSuite) TestBloomFilter() {
 	// Prepare db for logs
 	tHash := ethcmn.BytesToHash([]byte{0x1})
-	suite.app.EvmKeeper.Prepare(suite.ctx, tHash, 0)
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.Prepare(tHash, 0)
 	contractAddress := ethcmn.BigToAddress(big.NewInt(1))
 	log := ethtypes.Log{Address: contractAddress}
 
@@ -37,7 +38,7 @@ func (suite *KeeperTestSuite) TestBloomFilter() {
 		{
 			"add log",
 			func() {
-				suite.app.EvmKeeper.AddLog(suite.ctx, &log)
+				csdb.AddLog(&log)
 			},
 			1,
 			false,
@@ -111,13 +112,14 @@ func (suite *KeeperTestSuite) TestStateDBNonce() {
 }
 
 func (suite *KeeperTestSuite) TestStateDB_Error() {
-	nonce := suite.app.EvmKeeper.GetNonce(suite.ctx, ethcmn.Address{})
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	nonce := csdb.GetNonce(ethcmn.Address{})
 	suite.Require().Equal(0, int(nonce))
-	suite.Require().Error(suite.app.EvmKeeper.Error(suite.ctx))
+	suite.Require().Error(csdb.Error())
 }
 
 func (suite *KeeperTestSuite) TestStateDB_Database() {
-	suite.Require().Nil(suite.app.EvmKeeper.Database(suite.ctx))
+	suite.Require().Nil(suite.app.EvmKeeper.NewStateDB(suite.ctx).Database())
 }
 
 func (suite *KeeperTestSuite) TestStateDB_State() {
@@ -229,11 +231,12 @@ func (suite *KeeperTestSuite) TestStateDB_Logs() {
 		suite.Require().NoError(err, tc.name)
 		suite.Require().Empty(dbLogs, tc.name)
 
-		suite.app.EvmKeeper.AddLog(suite.ctx, &tc.log)
+		csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+		csdb.AddLog(&tc.log)
 		suite.Require().Equal(logs, suite.app.EvmKeeper.AllLogs(suite.ctx), tc.name)
 
 		//resets state but checking to see if storekey still persists.
-		err = suite.app.EvmKeeper.Reset(suite.ctx, hash)
+		err = csdb.Reset(hash)
 		suite.Require().NoError(err, tc.name)
 		suite.Require().Equal(logs, suite.app.EvmKeeper.AllLogs(suite.ctx), tc.name)
 	}
@@ -243,8 +246,9 @@ func (suite *KeeperTestSuite) TestStateDB_Preimage() {
 	hash := ethcmn.BytesToHash([]byte("hash"))
 	preimage := []byte("preimage")
 
-	suite.app.EvmKeeper.AddPreimage(suite.ctx, hash, preimage)
-	suite.Require().Equal(preimage, suite.app.EvmKeeper.Preimages(suite.ctx)[hash])
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.AddPreimage(hash, preimage)
+	suite.Require().Equal(preimage, csdb.Preimages()[hash])
 }
 
 func (suite *KeeperTestSuite) TestStateDB_Refund() {
@@ -274,18 +278,18 @@ func (suite *KeeperTestSuite) TestStateDB_Refund() {
 
 	for _, tc := range testCase {
 		suite.Run(tc.name, func() {
-			suite.SetupTest() // reset
+			csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
 
-			suite.app.EvmKeeper.AddRefund(suite.ctx, tc.addAmount)
-			suite.Require().Equal(tc.addAmount, suite.app.EvmKeeper.GetRefund(suite.ctx))
+			csdb.AddRefund(tc.addAmount)
+			suite.Require().Equal(tc.addAmount, csdb.GetRefund())
 
 			if tc.expPanic {
 				suite.Panics(func() {
-					suite.app.EvmKeeper.SubRefund(suite.ctx, tc.subAmount)
+					csdb.SubRefund(tc.subAmount)
 				})
 			} else {
-				suite.app.EvmKeeper.SubRefund(suite.ctx, tc.subAmount)
-				suite.Require().Equal(tc.expRefund, suite.app.EvmKeeper.GetRefund(suite.ctx))
+				csdb.SubRefund(tc.subAmount)
+				suite.Require().Equal(tc.expRefund, csdb.GetRefund())
 			}
 		})
 	}
@@ -333,11 +337,12 @@ func (suite *KeeperTestSuite) TestStateDB_ClearStateObj() {
 
 	addr := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
 
-	suite.app.EvmKeeper.CreateAccount(suite.ctx, addr)
-	suite.Require().True(suite.app.EvmKeeper.Exist(suite.ctx, addr))
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.CreateAccount(addr)
+	suite.Require().True(csdb.Exist(addr))
 
-	suite.app.EvmKeeper.ClearStateObjects(suite.ctx)
-	suite.Require().False(suite.app.EvmKeeper.Exist(suite.ctx, addr))
+	csdb.ClearStateObjects()
+	suite.Require().False(csdb.Exist(addr))
 }
 
 func (suite *KeeperTestSuite) TestStateDB_Reset() {
@@ -346,12 +351,13 @@ func (suite *KeeperTestSuite) TestStateDB_Reset() {
 
 	addr := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
 
-	suite.app.EvmKeeper.CreateAccount(suite.ctx, addr)
-	suite.Require().True(suite.app.EvmKeeper.Exist(suite.ctx, addr))
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.CreateAccount(addr)
+	suite.Require().True(csdb.Exist(addr))
 
-	err = suite.app.EvmKeeper.Reset(suite.ctx, ethcmn.BytesToHash(nil))
+	err = csdb.Reset(ethcmn.BytesToHash(nil))
 	suite.Require().NoError(err)
-	suite.Require().False(suite.app.EvmKeeper.Exist(suite.ctx, addr))
+	suite.Require().False(csdb.Exist(addr))
 }
 
 func (suite *KeeperTestSuite) TestSuiteDB_Prepare() {
@@ -359,11 +365,31 @@ func (suite *KeeperTestSuite) TestSuiteDB_Prepare() {
 	bhash := ethcmn.BytesToHash([]byte("bhash"))
 	txi := 1
 
-	suite.app.EvmKeeper.Prepare(suite.ctx, thash, txi)
-	suite.app.EvmKeeper.CommitStateDB.SetBlockHash(bhash)
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.Prepare(thash, txi)
+	csdb.SetBlockHash(bhash)
+
+	suite.Require().Equal(txi, csdb.TxIndex())
+	suite.Require().Equal(bhash, csdb.BlockHash())
+}
+
+func (suite *KeeperTestSuite) TestNewStateDB() {
+	bhash := ethcmn.BytesToHash([]byte("bhash"))
+	suite.app.EvmKeeper.SetHeightHash(suite.ctx, uint64(suite.ctx.BlockHeight()), bhash)
 
-	suite.Require().Equal(txi, suite.app.EvmKeeper.TxIndex(suite.ctx))
+	// the block hash of the current height is set on BeginBlock
 	suite.Require().Equal(bhash, suite.app.EvmKeeper.BlockHash(suite.ctx))
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.BlockHash(suite.ctx.WithBlockHeight(suite.ctx.BlockHeight()+1)))
+
+	// the state objects of a StateDB aren't shared with the other ones until they
+	// are committed
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	csdb.SetBalance(suite.address, big.NewInt(100))
+	suite.Require().Zero(suite.app.EvmKeeper.NewStateDB(suite.ctx).GetBalance(suite.address).Sign())
+
+	_, err := csdb.Commit(false)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(100), suite.app.EvmKeeper.NewStateDB(suite.ctx).GetBalance(suite.address))
 }
 
 func (suite *KeeperTestSuite) TestSuiteDB_CopyState() {
@@ -394,7 +420,7 @@ func (suite *KeeperTestSuite) TestSuiteDB_CopyState() {
 		err := suite.app.EvmKeeper.SetLogs(suite.ctx, hash, logs)
 		suite.Require().NoError(err, tc.name)
 
-		copyDB := suite.app.EvmKeeper.Copy(suite.ctx)
+		copyDB := suite.app.EvmKeeper.NewStateDB(suite.ctx).Copy()
 		suite.Require().Equal(suite.app.EvmKeeper.Exist(suite.ctx, suite.address), copyDB.Exist(suite.address), tc.name)
 	}
 }
@@ -411,52 +437,43 @@ func (suite *KeeperTestSuite) TestSuiteDB_Suicide() {
 		name    string
 		amount  *big.Int
 		expPass bool
-		delete  bool
 	}{
 		{
 			"suicide zero balance",
 			big.NewInt(0),
-			false, false,
+			false,
 		},
 		{
 			"suicide with balance",
 			big.NewInt(100),
-			true, false,
-		},
-		{
-			"delete",
-			big.NewInt(0),
-			true, true,
+			true,
 		},
 	}
 
 	for _, tc := range testCase {
-		if tc.delete {
-			_, err := suite.app.EvmKeeper.Commit(suite.ctx, tc.delete)
-			suite.Require().NoError(err, tc.name)
-			suite.Require().False(suite.app.EvmKeeper.Exist(suite.ctx, suite.address), tc.name)
-			continue
-		}
-
 		if tc.expPass {
 			suite.app.EvmKeeper.SetBalance(suite.ctx, suite.address, tc.amount)
 			suicide := suite.app.EvmKeeper.Suicide(suite.ctx, suite.address)
 			suite.Require().True(suicide, tc.name)
-			suite.Require().True(suite.app.EvmKeeper.HasSuicided(suite.ctx, suite.address), tc.name)
+			// the suicided account is deleted on commit
+			suite.Require().False(suite.app.EvmKeeper.Exist(suite.ctx, suite.address), tc.name)
 		} else {
 			//Suicide only works for an account with non-zero balance/nonce
 			priv, err := ethsecp256k1.GenerateKey()
 			suite.Require().NoError(err)
 
 			addr := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
-			suicide := suite.app.EvmKeeper.Suicide(suite.ctx, addr)
+			csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+			suicide := csdb.Suicide(addr)
 			suite.Require().False(suicide, tc.name)
-			suite.Require().False(suite.app.EvmKeeper.HasSuicided(suite.ctx, addr), tc.name)
+			suite.Require().False(csdb.HasSuicided(addr), tc.name)
 		}
 	}
 }
 
 func (suite *KeeperTestSuite) TestCommitStateDB_Commit() {
+	var csdb *types.CommitStateDB
+
 	testCase := []struct {
 		name       string
 		malleate   func()
@@ -466,7 +483,7 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Commit() {
 		{
 			"commit suicided",
 			func() {
-				ok := suite.app.EvmKeeper.Suicide(suite.ctx, suite.address)
+				ok := csdb.Suicide(suite.address)
 				suite.Require().True(ok)
 			},
 			true, true,
@@ -474,16 +491,17 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Commit() {
 		{
 			"commit with dirty value",
 			func() {
-				suite.app.EvmKeeper.SetCode(suite.ctx, suite.address, []byte("code"))
+				csdb.SetCode(suite.address, []byte("code"))
 			},
 			false, true,
 		},
 	}
 
 	for _, tc := range testCase {
+		csdb = suite.app.EvmKeeper.NewStateDB(suite.ctx)
 		tc.malleate()
 
-		hash, err := suite.app.EvmKeeper.Commit(suite.ctx, tc.deleteObjs)
+		hash, err := csdb.Commit(tc.deleteObjs)
 		suite.Require().Equal(ethcmn.Hash{}, hash)
 
 		if !tc.expPass {
@@ -507,6 +525,8 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Commit() {
 }
 
 func (suite *KeeperTestSuite) TestCommitStateDB_Finalize() {
+	var csdb *types.CommitStateDB
+
 	testCase := []struct {
 		name       string
 		malleate   func()
@@ -516,7 +536,7 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Finalize() {
 		{
 			"finalize suicided",
 			func() {
-				ok := suite.app.EvmKeeper.Suicide(suite.ctx, suite.address)
+				ok := csdb.Suicide(suite.address)
 				suite.Require().True(ok)
 			},
 			true, true,
@@ -524,27 +544,28 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Finalize() {
 		{
 			"finalize, not suicided",
 			func() {
-				suite.app.EvmKeeper.AddBalance(suite.ctx, suite.address, big.NewInt(5))
+				csdb.AddBalance(suite.address, big.NewInt(5))
 			},
 			false, true,
 		},
 		{
 			"finalize, dirty storage",
 			func() {
-				suite.app.EvmKeeper.SetState(suite.ctx, suite.address, ethcmn.BytesToHash([]byte("key")), ethcmn.BytesToHash([]byte("value")))
+				csdb.SetState(suite.address, ethcmn.BytesToHash([]byte("key")), ethcmn.BytesToHash([]byte("value")))
 			},
 			false, true,
 		},
 	}
 
 	for _, tc := range testCase {
+		csdb = suite.app.EvmKeeper.NewStateDB(suite.ctx)
 		tc.malleate()
 
-		err := suite.app.EvmKeeper.Finalise(suite.ctx, tc.deleteObjs)
+		err := csdb.Finalise(tc.deleteObjs)
 
 		if !tc.expPass {
 			suite.Require().Error(err, tc.name)
-			hash := suite.app.EvmKeeper.GetCommittedState(suite.ctx, suite.address, ethcmn.BytesToHash([]byte("key")))
+			hash := csdb.GetCommittedState(suite.address, ethcmn.BytesToHash([]byte("key")))
 			suite.Require().NotEqual(ethcmn.Hash{}, hash, tc.name)
 			continue
 		}
@@ -561,18 +582,19 @@ func (suite *KeeperTestSuite) TestCommitStateDB_Finalize() {
 	}
 }
 func (suite *KeeperTestSuite) TestCommitStateDB_GetCommittedState() {
-	hash := suite.app.EvmKeeper.GetCommittedState(suite.ctx, ethcmn.Address{}, ethcmn.BytesToHash([]byte("key")))
+	hash := suite.app.EvmKeeper.NewStateDB(suite.ctx).GetCommittedState(ethcmn.Address{}, ethcmn.BytesToHash([]byte("key")))
 	suite.Require().Equal(ethcmn.Hash{}, hash)
 }
 
 func (suite *KeeperTestSuite) TestCommitStateDB_Snapshot() {
-	id := suite.app.EvmKeeper.Snapshot(suite.ctx)
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	id := csdb.Snapshot()
 	suite.Require().NotPanics(func() {
-		suite.app.EvmKeeper.RevertToSnapshot(suite.ctx, id)
+		csdb.RevertToSnapshot(id)
 	})
 
 	suite.Require().Panics(func() {
-		suite.app.EvmKeeper.RevertToSnapshot(suite.ctx, -1)
+		csdb.RevertToSnapshot(-1)
 	}, "invalid revision should panic")
 }
 
@@ -627,7 +649,6 @@ func (suite *KeeperTestSuite) TestCommitStateDB_ForEachStorage() {
 		suite.Run(tc.name, func() {
 			suite.SetupTest() // reset
 			tc.malleate()
-			suite.app.EvmKeeper.Finalise(suite.ctx, false)
 
 			err := suite.app.EvmKeeper.ForEachStorage(suite.ctx, suite.address, tc.callback)
 			suite.Require().NoError(err)
@@ -0,0 +1,156 @@
+package keeper
+
+import (
+	"fmt"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/cosmos-sdk/store/prefix"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+// GetSystemContract returns the registered system contract of the given address.
+func (k Keeper) GetSystemContract(ctx sdk.Context, contract common.Address) (types.SystemContract, bool) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSystemContract)
+	bz := store.Get(contract.Bytes())
+	if len(bz) == 0 {
+		return types.SystemContract{}, false
+	}
+
+	var sc types.SystemContract
+	k.cdc.MustUnmarshalBinaryBare(bz, &sc)
+	return sc, true
+}
+
+// SetSystemContract registers the system contract, replacing the existing one for
+// the same address.
+func (k Keeper) SetSystemContract(ctx sdk.Context, sc types.SystemContract) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSystemContract)
+	store.Set(sc.GetAddress().Bytes(), k.cdc.MustMarshalBinaryBare(sc))
+}
+
+// DeleteSystemContract removes the system contract of the given address.
+func (k Keeper) DeleteSystemContract(ctx sdk.Context, contract common.Address) {
+	store := prefix.NewStore(ctx.KVStore(k.storeKey), types.KeyPrefixSystemContract)
+	store.Delete(contract.Bytes())
+}
+
+// IterateSystemContracts iterates over the system contracts and performs a
+// callback function. The iteration stops if the callback returns true.
+func (k Keeper) IterateSystemContracts(ctx sdk.Context, cb func(sc types.SystemContract) (stop bool)) {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(k.storeKey), types.KeyPrefixSystemContract)
+	defer iterator.Close()
+
+	for ; iterator.Valid(); iterator.Next() {
+		var sc types.SystemContract
+		k.cdc.MustUnmarshalBinaryBare(iterator.Value(), &sc)
+
+		if cb(sc) {
+			break
+		}
+	}
+}
+
+// GetSystemContracts returns all the registered system contracts ordered by
+// address.
+func (k Keeper) GetSystemContracts(ctx sdk.Context) []types.SystemContract {
+	systemContracts := []types.SystemContract{}
+	k.IterateSystemContracts(ctx, func(sc types.SystemContract) bool {
+		systemContracts = append(systemContracts, sc)
+		return false
+	})
+
+	return systemContracts
+}
+
+// RegisterSystemContract validates and registers the system contract. The
+// contract must already be deployed on the EVM.
+func (k Keeper) RegisterSystemContract(ctx sdk.Context, sc types.SystemContract) error {
+	if err := sc.Validate(); err != nil {
+		return sdkerrors.Wrap(types.ErrInvalidSystemContract, err.Error())
+	}
+
+	if len(k.GetCode(ctx, sc.GetAddress())) == 0 {
+		return sdkerrors.Wrapf(types.ErrInvalidSystemContract, "account %s has no code", sc.Address)
+	}
+
+	k.SetSystemContract(ctx, sc)
+	return nil
+}
+
+// RemoveSystemContract removes a registered system contract.
+func (k Keeper) RemoveSystemContract(ctx sdk.Context, contract common.Address) error {
+	if _, found := k.GetSystemContract(ctx, contract); !found {
+		return sdkerrors.Wrap(types.ErrSystemContractNotFound, contract.String())
+	}
+
+	k.DeleteSystemContract(ctx, contract)
+	return nil
+}
+
+// CallSystemContracts calls, in address order, the system contracts that are due
+// on the given hook at the current block height. Each call is isolated: a failed
+// or panicking call doesn't modify the state nor prevents the other calls from
+// being executed. An event with the result is emitted for every call.
+func (k *Keeper) CallSystemContracts(ctx sdk.Context, hook string) {
+	// the system contracts are collected first as the calls write to the store
+	var due []types.SystemContract
+	k.IterateSystemContracts(ctx, func(sc types.SystemContract) bool {
+		if sc.IsDue(hook, ctx.BlockHeight()) {
+			due = append(due, sc)
+		}
+		return false
+	})
+
+	for _, sc := range due {
+		gasUsed, err := k.callSystemContract(ctx, sc)
+
+		attributes := []sdk.Attribute{
+			sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+			sdk.NewAttribute(types.AttributeKeyContractAddress, sc.Address),
+			sdk.NewAttribute(types.AttributeKeyHook, hook),
+			sdk.NewAttribute(types.AttributeKeySuccess, strconv.FormatBool(err == nil)),
+			sdk.NewAttribute(types.AttributeKeyGasUsed, strconv.FormatUint(gasUsed, 10)),
+		}
+
+		if err != nil {
+			k.Logger(ctx).Error("system contract call failed", "contract", sc.Address, "hook", hook, "error", err.Error())
+			attributes = append(attributes, sdk.NewAttribute(types.AttributeKeyError, err.Error()))
+		}
+
+		ctx.EventManager().EmitEvent(sdk.NewEvent(types.EventTypeSystemContractExecution, attributes...))
+	}
+}
+
+// callSystemContract executes a system contract call on a cached context, which
+// is only written when the call succeeds. It returns the gas consumed by the call,
+// including the one of failed executions.
+func (k *Keeper) callSystemContract(ctx sdk.Context, sc types.SystemContract) (gasUsed uint64, err error) {
+	input, err := sc.GetInput()
+	if err != nil {
+		return 0, err
+	}
+
+	gasMeter := sdk.NewInfiniteGasMeter()
+	cacheCtx, writeCache := ctx.CacheContext()
+	cacheCtx = cacheCtx.WithGasMeter(gasMeter)
+
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("system contract call panicked: %v", r)
+		}
+
+		gasUsed = gasMeter.GasConsumed()
+	}()
+
+	if _, err = k.CallContract(cacheCtx, types.SystemContractCaller, sc.GetAddress(), input, sc.GasLimit); err != nil {
+		return 0, err
+	}
+
+	writeCache()
+	return 0, nil
+}
@@ -0,0 +1,109 @@
+package keeper_test
+
+import (
+	"errors"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+)
+
+var (
+	// CODECOPY(0, 12, 10), RETURN(0, 10) followed by the runtime code
+	// SSTORE(0, SLOAD(0) + 1), STOP
+	counterBytecode = ethcmn.Hex2Bytes("600a600c600039600a6000f3" + "60005460010160005500")
+
+	// CODECOPY(0, 12, 5), RETURN(0, 5) followed by the runtime code REVERT(0, 0)
+	revertBytecode = ethcmn.Hex2Bytes("6005600c60003960056000f3" + "60006000fd")
+)
+
+func (suite *KeeperTestSuite) TestRegisterSystemContract() {
+	contract, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, counterBytecode)
+	suite.Require().NoError(err)
+
+	sc := types.NewSystemContract(contract, types.SystemContractHookEndBlock, 1, nil, 100000)
+	suite.Require().NoError(suite.app.EvmKeeper.RegisterSystemContract(suite.ctx, sc))
+
+	stored, found := suite.app.EvmKeeper.GetSystemContract(suite.ctx, contract)
+	suite.Require().True(found)
+	suite.Require().Equal(sc, stored)
+
+	// registering again replaces the settings
+	sc.Interval = 10
+	suite.Require().NoError(suite.app.EvmKeeper.RegisterSystemContract(suite.ctx, sc))
+	suite.Require().Equal([]types.SystemContract{sc}, suite.app.EvmKeeper.GetSystemContracts(suite.ctx))
+
+	// accounts without code can't be registered
+	err = suite.app.EvmKeeper.RegisterSystemContract(suite.ctx, types.NewSystemContract(suite.address, types.SystemContractHookEndBlock, 1, nil, 100000))
+	suite.Require().True(errors.Is(err, types.ErrInvalidSystemContract))
+
+	suite.Require().NoError(suite.app.EvmKeeper.RemoveSystemContract(suite.ctx, contract))
+	suite.Require().Empty(suite.app.EvmKeeper.GetSystemContracts(suite.ctx))
+
+	err = suite.app.EvmKeeper.RemoveSystemContract(suite.ctx, contract)
+	suite.Require().True(errors.Is(err, types.ErrSystemContractNotFound))
+}
+
+func (suite *KeeperTestSuite) TestCallSystemContracts() {
+	counter, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, counterBytecode)
+	suite.Require().NoError(err)
+	reverter, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, revertBytecode)
+	suite.Require().NoError(err)
+
+	suite.app.EvmKeeper.SetSystemContract(suite.ctx, types.NewSystemContract(counter, types.SystemContractHookEndBlock, 2, nil, 100000))
+	suite.app.EvmKeeper.SetSystemContract(suite.ctx, types.NewSystemContract(reverter, types.SystemContractHookEndBlock, 1, nil, 100000))
+
+	for height := int64(1); height <= 4; height++ {
+		ctx := suite.ctx.WithBlockHeight(height).WithEventManager(sdk.NewEventManager())
+		_ = suite.app.EvmKeeper.EndBlock(ctx, abci.RequestEndBlock{Height: height})
+
+		var events []sdk.Event
+		for _, event := range ctx.EventManager().Events() {
+			if event.Type == types.EventTypeSystemContractExecution {
+				events = append(events, event)
+			}
+		}
+
+		// the reverting contract is called on every block without affecting the counter
+		if height%2 == 0 {
+			suite.Require().Len(events, 2)
+		} else {
+			suite.Require().Len(events, 1)
+		}
+	}
+
+	suite.Require().Equal(ethcmn.BigToHash(ethcmn.Big2), suite.app.EvmKeeper.GetState(suite.ctx, counter, ethcmn.Hash{}))
+
+	// the counter isn't called on begin block
+	suite.app.EvmKeeper.CallSystemContracts(suite.ctx.WithBlockHeight(6), types.SystemContractHookBeginBlock)
+	suite.Require().Equal(ethcmn.BigToHash(ethcmn.Big2), suite.app.EvmKeeper.GetState(suite.ctx, counter, ethcmn.Hash{}))
+}
+
+func (suite *KeeperTestSuite) TestCallSystemContractOutOfGas() {
+	counter, err := suite.app.EvmKeeper.DeployContract(suite.ctx, suite.address, counterBytecode)
+	suite.Require().NoError(err)
+
+	// the gas limit doesn't cover the storage access
+	suite.app.EvmKeeper.SetSystemContract(suite.ctx, types.NewSystemContract(counter, types.SystemContractHookBeginBlock, 1, nil, 100))
+
+	ctx := suite.ctx.WithEventManager(sdk.NewEventManager())
+	suite.app.EvmKeeper.CallSystemContracts(ctx, types.SystemContractHookBeginBlock)
+	suite.Require().Equal(ethcmn.Hash{}, suite.app.EvmKeeper.GetState(suite.ctx, counter, ethcmn.Hash{}))
+
+	events := ctx.EventManager().Events()
+	suite.Require().Len(events, 1)
+	suite.Require().Equal(types.EventTypeSystemContractExecution, events[0].Type)
+
+	attributes := make(map[string]string)
+	for _, attr := range events[0].Attributes {
+		attributes[string(attr.Key)] = string(attr.Value)
+	}
+
+	suite.Require().Equal(counter.String(), attributes[types.AttributeKeyContractAddress])
+	suite.Require().Equal("false", attributes[types.AttributeKeySuccess])
+	suite.Require().NotEmpty(attributes[types.AttributeKeyError])
+}
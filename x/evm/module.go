@@ -47,6 +47,8 @@ func (AppModuleBasic) ValidateGenesis(bz json.RawMessage) error {
 		return err
 	}
 
+	// disable the forks that are missing on genesis files exported by previous versions
+	genesisState.ChainConfig = genesisState.ChainConfig.Migrate()
 	return genesisState.Validate()
 }
 
@@ -0,0 +1,55 @@
+package precompiles
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+)
+
+// mustParseABI parses the JSON ABI definition of a precompile. It panics on failure
+// as the definitions are constants.
+func mustParseABI(def string) abi.ABI {
+	parsed, err := abi.JSON(strings.NewReader(def))
+	if err != nil {
+		panic(fmt.Errorf("invalid precompile ABI: %w", err))
+	}
+
+	return parsed
+}
+
+// parseMethod returns the ABI method selected by the input and its unpacked
+// arguments.
+func parseMethod(contractABI abi.ABI, input []byte) (*abi.Method, []interface{}, error) {
+	if len(input) < 4 {
+		return nil, nil, errors.New("invalid input length, missing method selector")
+	}
+
+	method, err := contractABI.MethodById(input[:4])
+	if err != nil {
+		return nil, nil, err
+	}
+
+	args, err := method.Inputs.Unpack(input[4:])
+	if err != nil {
+		return nil, nil, fmt.Errorf("failed to unpack %s arguments: %w", method.Name, err)
+	}
+
+	return method, args, nil
+}
+
+// methodGas returns the gas cost of the method selected by the input or zero if the
+// method is not found.
+func methodGas(contractABI abi.ABI, input []byte, costs map[string]uint64) uint64 {
+	if len(input) < 4 {
+		return 0
+	}
+
+	method, err := contractABI.MethodById(input[:4])
+	if err != nil {
+		return 0
+	}
+
+	return costs[method.Name]
+}
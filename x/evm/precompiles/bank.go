@@ -0,0 +1,155 @@
+package precompiles
+
+import (
+	"errors"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// BankPrecompileAddress defines the address of the bank precompiled contract
+const BankPrecompileAddress = "0x0000000000000000000000000000000000000800"
+
+// Gas costs of the bank precompile methods
+const (
+	BankBalanceOfGas   uint64 = 2600
+	BankTotalSupplyGas uint64 = 2600
+	BankTransferGas    uint64 = 25000
+)
+
+const bankABI = `[
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"account","type":"address"},{"name":"denom","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[{"name":"denom","type":"string"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"recipient","type":"address"},{"name":"denom","type":"string"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"from","type":"address","indexed":true},{"name":"to","type":"address","indexed":true},{"name":"denom","type":"string","indexed":false},{"name":"amount","type":"uint256","indexed":false}]}
+]`
+
+var _ types.PrecompiledContract = BankPrecompile{}
+
+// BankPrecompile is a stateful precompiled contract that allows EVM accounts and
+// contracts to query and transfer any Cosmos SDK coin denomination.
+//
+// The transfers are applied to the EVM state db in order for them to be reverted
+// along with the EVM call frame that performed them.
+type BankPrecompile struct {
+	abi          abi.ABI
+	supplyKeeper SupplyKeeper
+	blockedAddrs map[string]bool
+}
+
+// NewBankPrecompile creates a new BankPrecompile instance. The blocked addresses,
+// and the ones of the TransferDenylist param, can't receive funds from the
+// precompile transfers.
+func NewBankPrecompile(sk SupplyKeeper, blockedAddrs map[string]bool) BankPrecompile {
+	return BankPrecompile{
+		abi:          mustParseABI(bankABI),
+		supplyKeeper: sk,
+		blockedAddrs: blockedAddrs,
+	}
+}
+
+// ABI returns the Solidity ABI of the bank precompile.
+func (bp BankPrecompile) ABI() abi.ABI {
+	return bp.abi
+}
+
+// Address implements PrecompiledContract.
+func (BankPrecompile) Address() ethcmn.Address {
+	return ethcmn.HexToAddress(BankPrecompileAddress)
+}
+
+// RequiredGas implements PrecompiledContract.
+func (bp BankPrecompile) RequiredGas(input []byte) uint64 {
+	return methodGas(bp.abi, input, map[string]uint64{
+		"balanceOf":   BankBalanceOfGas,
+		"totalSupply": BankTotalSupplyGas,
+		"transfer":    BankTransferGas,
+	})
+}
+
+// Run implements PrecompiledContract.
+func (bp BankPrecompile) Run(pc *types.PrecompileContext, input []byte) ([]byte, error) {
+	method, args, err := parseMethod(bp.abi, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if pc.Value.Sign() != 0 {
+		return nil, errors.New("bank precompile methods are not payable")
+	}
+
+	switch method.Name {
+	case "balanceOf":
+		account := args[0].(ethcmn.Address)
+		denom := args[1].(string)
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return nil, err
+		}
+
+		balance := pc.StateDB.GetCoinBalance(account, denom)
+		return method.Outputs.Pack(balance.BigInt())
+
+	case "totalSupply":
+		denom := args[0].(string)
+		if err := sdk.ValidateDenom(denom); err != nil {
+			return nil, err
+		}
+
+		supply := bp.supplyKeeper.GetSupply(pc.Ctx).GetTotal().AmountOf(denom)
+		return method.Outputs.Pack(supply.BigInt())
+
+	case "transfer":
+		if pc.ReadOnly {
+			return nil, errors.New("bank transfer cannot be executed on read only mode")
+		}
+
+		recipient := args[0].(ethcmn.Address)
+		denom := args[1].(string)
+		amount := sdk.NewIntFromBigInt(args[2].(*big.Int))
+
+		if err := bp.transfer(pc, recipient, denom, amount); err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(true)
+
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown bank precompile method %s", method.Name)
+	}
+}
+
+// transfer moves the coins from the caller to the recipient and emits a Transfer
+// log.
+func (bp BankPrecompile) transfer(pc *types.PrecompileContext, recipient ethcmn.Address, denom string, amount sdk.Int) error {
+	if err := sdk.ValidateDenom(denom); err != nil {
+		return err
+	}
+
+	// the recipient is checked as for the EVM value transfers, so that the
+	// precompile can't be used to bypass the TransferDenylist param
+	if bp.blockedAddrs[sdk.AccAddress(recipient.Bytes()).String()] || pc.StateDB.GetParams().IsTransferDenied(recipient) {
+		return sdkerrors.Wrapf(sdkerrors.ErrUnauthorized, "%s is not allowed to receive funds", recipient.String())
+	}
+
+	balance := pc.StateDB.GetCoinBalance(pc.Caller, denom)
+	if balance.LT(amount) {
+		return sdkerrors.Wrapf(
+			sdkerrors.ErrInsufficientFunds,
+			"%s%s is smaller than %s%s", balance, denom, amount, denom,
+		)
+	}
+
+	pc.StateDB.SetCoinBalance(pc.Caller, denom, balance.Sub(amount))
+	pc.StateDB.SetCoinBalance(recipient, denom, pc.StateDB.GetCoinBalance(recipient, denom).Add(amount))
+
+	return addEventLog(
+		pc, bp.Address(), bp.abi.Events["Transfer"], []ethcmn.Address{pc.Caller, recipient},
+		denom, amount.BigInt(),
+	)
+}
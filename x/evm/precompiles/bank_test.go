@@ -0,0 +1,223 @@
+package precompiles_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/precompiles"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func (suite *PrecompileTestSuite) TestBankRequiredGas() {
+	suite.Require().Equal(precompiles.BankBalanceOfGas, suite.bank.RequiredGas(suite.pack(suite.bank, "balanceOf", suite.address, testDenom)))
+	suite.Require().Equal(precompiles.BankTotalSupplyGas, suite.bank.RequiredGas(suite.pack(suite.bank, "totalSupply", testDenom)))
+	suite.Require().Equal(precompiles.BankTransferGas, suite.bank.RequiredGas(suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(1))))
+	suite.Require().Equal(uint64(0), suite.bank.RequiredGas([]byte{0x1}))
+}
+
+func (suite *PrecompileTestSuite) TestBankTransfer() {
+	testCases := []struct {
+		name        string
+		malleate    func()
+		input       func() []byte
+		amount      *big.Int
+		expPass     bool
+		expSender   int64
+		expReceiver int64
+	}{
+		{
+			"transfer non evm denom",
+			func() {},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(300))
+			},
+			big.NewInt(0),
+			true,
+			700,
+			300,
+		},
+		{
+			"insufficient funds",
+			func() {},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(1001))
+			},
+			big.NewInt(0),
+			false,
+			1000,
+			0,
+		},
+		{
+			"invalid denom",
+			func() {},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, "1", big.NewInt(1))
+			},
+			big.NewInt(0),
+			false,
+			1000,
+			0,
+		},
+		{
+			"non payable",
+			func() {},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(1))
+			},
+			big.NewInt(1),
+			false,
+			1000,
+			0,
+		},
+		{
+			"unknown method",
+			func() {},
+			func() []byte {
+				return []byte{0x1, 0x2, 0x3, 0x4}
+			},
+			big.NewInt(0),
+			false,
+			1000,
+			0,
+		},
+		{
+			"recipient in the transfer denylist",
+			func() {
+				params := suite.app.EvmKeeper.GetParams(suite.ctx)
+				params.TransferDenylist = []string{suite.recipient.Hex()}
+				suite.app.EvmKeeper.SetParams(suite.ctx, params)
+			},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(300))
+			},
+			big.NewInt(0),
+			false,
+			1000,
+			0,
+		},
+		{
+			"precompile disabled",
+			func() {
+				suite.app.EvmKeeper.SetParams(suite.ctx, types.DefaultParams())
+			},
+			func() []byte {
+				return suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(300))
+			},
+			big.NewInt(0),
+			true,
+			1000,
+			0,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			tc.malleate()
+
+			res, err := suite.call(suite.bank, tc.input(), tc.amount)
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().NotNil(res)
+			} else {
+				suite.Require().Error(err)
+			}
+
+			suite.Require().Equal(sdk.NewInt(tc.expSender), suite.balance(suite.address, testDenom))
+			suite.Require().Equal(sdk.NewInt(tc.expReceiver), suite.balance(suite.recipient, testDenom))
+		})
+	}
+}
+
+func (suite *PrecompileTestSuite) TestBankTransferFromContract() {
+	relay := ethcmn.BytesToAddress([]byte("relay"))
+	staticRelay := ethcmn.BytesToAddress([]byte("static relay"))
+
+	// the relay forwards its call data to the bank precompile with a CALL and the
+	// static relay forwards it to the relay with a STATICCALL. Both revert if the
+	// forwarded call fails.
+	suite.fund(relay, sdk.NewCoins(sdk.NewCoin(testDenom, sdk.NewInt(500))))
+	suite.fund(staticRelay, sdk.NewCoins())
+	suite.stateDB.SetCode(relay, forwarderCode(0xf1, suite.bank.Address()))
+	suite.stateDB.SetCode(staticRelay, forwarderCode(0xfa, relay))
+	_, err := suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	input := suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(300))
+
+	// the precompile can't modify the state from within a STATICCALL frame, even
+	// though it's called with a CALL
+	_, err = suite.callAddress(staticRelay, input, big.NewInt(0))
+	suite.Require().Error(err)
+	suite.Require().Equal(sdk.NewInt(500), suite.balance(relay, testDenom))
+	suite.Require().Equal(sdk.ZeroInt(), suite.balance(suite.recipient, testDenom))
+
+	// the caller of the precompile is the relay contract
+	_, err = suite.callAddress(relay, input, big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Equal(sdk.NewInt(200), suite.balance(relay, testDenom))
+	suite.Require().Equal(sdk.NewInt(300), suite.balance(suite.recipient, testDenom))
+	suite.Require().Equal(sdk.NewInt(1000), suite.balance(suite.address, testDenom))
+}
+
+// forwarderCode returns the runtime code of a contract that forwards its call data
+// to the target with the given call opcode (CALL or STATICCALL) and reverts if the
+// call fails.
+func forwarderCode(op byte, target ethcmn.Address) []byte {
+	// CALLDATACOPY(0, 0, CALLDATASIZE)
+	code := []byte{0x36, 0x60, 0x00, 0x60, 0x00, 0x37}
+	// push the return data size and offset, the call data size and offset and, for
+	// a CALL, a zero value
+	code = append(code, 0x60, 0x00, 0x60, 0x00, 0x36, 0x60, 0x00)
+	if op == 0xf1 {
+		code = append(code, 0x60, 0x00)
+	}
+	// PUSH20 target, GAS, op
+	code = append(code, 0x73)
+	code = append(code, target.Bytes()...)
+	code = append(code, 0x5a, op)
+	// ISZERO, PUSH1 revert, JUMPI, STOP, revert: JUMPDEST, REVERT(0, 0)
+	revert := byte(len(code) + 5)
+	return append(code, 0x15, 0x60, revert, 0x57, 0x00, 0x5b, 0x60, 0x00, 0x60, 0x00, 0xfd)
+}
+
+func (suite *PrecompileTestSuite) TestBankTransferLog() {
+	res, err := suite.call(suite.bank, suite.pack(suite.bank, "transfer", suite.recipient, testDenom, big.NewInt(300)), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Logs, 1)
+
+	event := suite.bank.ABI().Events["Transfer"]
+	log := res.Logs[0]
+	suite.Require().Equal(suite.bank.Address(), log.Address)
+	suite.Require().Equal(event.ID, log.Topics[0])
+	suite.Require().Equal(ethcmn.BytesToHash(suite.address.Bytes()), log.Topics[1])
+	suite.Require().Equal(ethcmn.BytesToHash(suite.recipient.Bytes()), log.Topics[2])
+
+	values, err := event.Inputs.NonIndexed().Unpack(log.Data)
+	suite.Require().NoError(err)
+	suite.Require().Equal(testDenom, values[0])
+	suite.Require().Equal(big.NewInt(300), values[1])
+
+	// the evm denom balance remains unchanged as the gas is refunded on the test
+	suite.Require().Equal(sdk.NewInt(100), suite.balance(suite.address, ethermint.AttoPhoton))
+}
+
+func (suite *PrecompileTestSuite) TestBankQueries() {
+	res, err := suite.call(suite.bank, suite.pack(suite.bank, "balanceOf", suite.address, testDenom), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	values := suite.unpack(suite.bank, "balanceOf", res)
+	suite.Require().Equal(big.NewInt(1000), values[0])
+
+	res, err = suite.call(suite.bank, suite.pack(suite.bank, "totalSupply", ethermint.AttoPhoton), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	supply := suite.app.SupplyKeeper.GetSupply(suite.ctx).GetTotal().AmountOf(ethermint.AttoPhoton)
+	values = suite.unpack(suite.bank, "totalSupply", res)
+	suite.Require().Equal(supply.String(), values[0].(*big.Int).String())
+}
@@ -0,0 +1,150 @@
+package precompiles
+
+import (
+	"errors"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// DistributionPrecompileAddress defines the address of the distribution precompiled
+// contract
+const DistributionPrecompileAddress = "0x0000000000000000000000000000000000000802"
+
+// Gas costs of the distribution precompile methods
+const (
+	DistributionWithdrawRewardsGas    uint64 = 40000
+	DistributionSetWithdrawAddressGas uint64 = 20000
+	DistributionWithdrawAddressGas    uint64 = 2600
+)
+
+const distributionABI = `[
+	{"type":"function","name":"withdrawRewards","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"}],"outputs":[{"name":"amount","type":"uint256"}]},
+	{"type":"function","name":"setWithdrawAddress","stateMutability":"nonpayable","inputs":[{"name":"withdrawAddress","type":"address"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"withdrawAddress","stateMutability":"view","inputs":[{"name":"delegator","type":"address"}],"outputs":[{"name":"","type":"address"}]},
+	{"type":"event","name":"WithdrawRewards","anonymous":false,"inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"SetWithdrawAddress","anonymous":false,"inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"withdrawAddress","type":"address","indexed":true}]}
+]`
+
+var _ types.PrecompiledContract = DistributionPrecompile{}
+
+// DistributionPrecompile is a stateful precompiled contract that allows EVM accounts
+// and contracts to withdraw their delegation rewards and to set the address that
+// receives them. The caller of the precompile is the delegator of the operations.
+type DistributionPrecompile struct {
+	abi                abi.ABI
+	distributionKeeper DistributionKeeper
+}
+
+// NewDistributionPrecompile creates a new DistributionPrecompile instance.
+func NewDistributionPrecompile(dk DistributionKeeper) DistributionPrecompile {
+	return DistributionPrecompile{
+		abi:                mustParseABI(distributionABI),
+		distributionKeeper: dk,
+	}
+}
+
+// ABI returns the Solidity ABI of the distribution precompile.
+func (dp DistributionPrecompile) ABI() abi.ABI {
+	return dp.abi
+}
+
+// Address implements PrecompiledContract.
+func (DistributionPrecompile) Address() ethcmn.Address {
+	return ethcmn.HexToAddress(DistributionPrecompileAddress)
+}
+
+// RequiredGas implements PrecompiledContract.
+func (dp DistributionPrecompile) RequiredGas(input []byte) uint64 {
+	return methodGas(dp.abi, input, map[string]uint64{
+		"withdrawRewards":    DistributionWithdrawRewardsGas,
+		"setWithdrawAddress": DistributionSetWithdrawAddressGas,
+		"withdrawAddress":    DistributionWithdrawAddressGas,
+	})
+}
+
+// Run implements PrecompiledContract.
+func (dp DistributionPrecompile) Run(pc *types.PrecompileContext, input []byte) ([]byte, error) {
+	method, args, err := parseMethod(dp.abi, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if pc.Value.Sign() != 0 {
+		return nil, errors.New("distribution precompile methods are not payable")
+	}
+
+	if method.Name == "withdrawAddress" {
+		delegator := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+		withdrawAddr := dp.distributionKeeper.GetDelegatorWithdrawAddr(pc.Ctx, delegator)
+		return method.Outputs.Pack(ethcmn.BytesToAddress(withdrawAddr.Bytes()))
+	}
+
+	if pc.ReadOnly {
+		return nil, errors.New("distribution transactions cannot be executed on read only mode")
+	}
+
+	switch method.Name {
+	case "withdrawRewards":
+		validator := args[0].(ethcmn.Address)
+
+		amount, err := dp.withdrawRewards(pc, validator)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(amount.BigInt())
+
+	case "setWithdrawAddress":
+		withdrawAddr := args[0].(ethcmn.Address)
+
+		if err := dp.setWithdrawAddress(pc, withdrawAddr); err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(true)
+
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown distribution precompile method %s", method.Name)
+	}
+}
+
+// withdrawRewards withdraws the caller rewards from the validator and returns the
+// withdrawn amount of the EVM denomination.
+func (dp DistributionPrecompile) withdrawRewards(pc *types.PrecompileContext, validatorAddr ethcmn.Address) (sdk.Int, error) {
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+	withdrawAddr := dp.distributionKeeper.GetDelegatorWithdrawAddr(pc.Ctx, delegator)
+
+	var rewards sdk.Coins
+	if err := syncAccounts(pc, func() (err error) {
+		rewards, err = dp.distributionKeeper.WithdrawDelegationRewards(pc.Ctx, delegator, sdk.ValAddress(validatorAddr.Bytes()))
+		return err
+	}, pc.Caller, ethcmn.BytesToAddress(withdrawAddr.Bytes())); err != nil {
+		return sdk.ZeroInt(), err
+	}
+
+	amount := rewards.AmountOf(pc.StateDB.GetParams().EvmDenom)
+	err := addEventLog(
+		pc, dp.Address(), dp.abi.Events["WithdrawRewards"], []ethcmn.Address{pc.Caller, validatorAddr},
+		amount.BigInt(),
+	)
+
+	return amount, err
+}
+
+func (dp DistributionPrecompile) setWithdrawAddress(pc *types.PrecompileContext, withdrawAddr ethcmn.Address) error {
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+
+	if err := dp.distributionKeeper.SetWithdrawAddr(pc.Ctx, delegator, sdk.AccAddress(withdrawAddr.Bytes())); err != nil {
+		return err
+	}
+
+	return addEventLog(
+		pc, dp.Address(), dp.abi.Events["SetWithdrawAddress"], []ethcmn.Address{pc.Caller, withdrawAddr},
+	)
+}
@@ -0,0 +1,88 @@
+package precompiles_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/distribution"
+	"github.com/cosmos/cosmos-sdk/x/mint"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// allocateRewards moves to the next block, as the delegations don't receive rewards
+// on their starting height, and allocates the minted rewards to the validator.
+func (suite *PrecompileTestSuite) allocateRewards(validator ethcmn.Address, amount int64) {
+	suite.ctx = suite.ctx.WithBlockHeight(suite.ctx.BlockHeight() + 1)
+
+	rewards := sdk.NewCoins(ethermint.NewPhotonCoin(sdk.NewInt(amount)))
+	suite.Require().NoError(suite.app.SupplyKeeper.MintCoins(suite.ctx, mint.ModuleName, rewards))
+	suite.Require().NoError(
+		suite.app.SupplyKeeper.SendCoinsFromModuleToModule(suite.ctx, mint.ModuleName, distribution.ModuleName, rewards),
+	)
+
+	val := suite.app.StakingKeeper.Validator(suite.ctx, sdk.ValAddress(validator.Bytes()))
+	suite.app.DistrKeeper.AllocateTokensToValidator(suite.ctx, val, sdk.NewDecCoinsFromCoins(rewards...))
+}
+
+func (suite *PrecompileTestSuite) TestDistributionWithdrawRewards() {
+	validator := suite.createValidator(100)
+
+	_, err := suite.call(suite.staking, suite.pack(suite.staking, "delegate", validator, big.NewInt(100)), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	suite.allocateRewards(validator, 1000)
+	balance := suite.balance(suite.address, ethermint.AttoPhoton)
+
+	res, err := suite.call(suite.distribution, suite.pack(suite.distribution, "withdrawRewards", validator), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Logs, 1)
+
+	// the delegator holds half of the validator shares
+	amount := suite.unpack(suite.distribution, "withdrawRewards", res)[0].(*big.Int)
+	suite.Require().Equal(big.NewInt(500), amount)
+	suite.Require().Equal(balance.AddRaw(500), suite.balance(suite.address, ethermint.AttoPhoton))
+
+	// the rewards have already been withdrawn
+	res, err = suite.call(suite.distribution, suite.pack(suite.distribution, "withdrawRewards", validator), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Zero(suite.unpack(suite.distribution, "withdrawRewards", res)[0].(*big.Int).Sign())
+}
+
+func (suite *PrecompileTestSuite) TestDistributionWithdrawAddress() {
+	validator := suite.createValidator(100)
+
+	_, err := suite.call(suite.staking, suite.pack(suite.staking, "delegate", validator, big.NewInt(100)), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	res, err := suite.call(suite.distribution, suite.pack(suite.distribution, "withdrawAddress", suite.address), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Equal(suite.address, suite.unpack(suite.distribution, "withdrawAddress", res)[0])
+
+	res, err = suite.call(suite.distribution, suite.pack(suite.distribution, "setWithdrawAddress", suite.recipient), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Logs, 1)
+
+	res, err = suite.call(suite.distribution, suite.pack(suite.distribution, "withdrawAddress", suite.address), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Equal(suite.recipient, suite.unpack(suite.distribution, "withdrawAddress", res)[0])
+
+	// the rewards are sent to the withdraw address
+	suite.allocateRewards(validator, 1000)
+	balance := suite.balance(suite.address, ethermint.AttoPhoton)
+
+	_, err = suite.call(suite.distribution, suite.pack(suite.distribution, "withdrawRewards", validator), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Equal(balance, suite.balance(suite.address, ethermint.AttoPhoton))
+	suite.Require().Equal(sdk.NewInt(500), suite.balance(suite.recipient, ethermint.AttoPhoton))
+}
+
+func (suite *PrecompileTestSuite) TestDistributionBlockedWithdrawAddress() {
+	moduleAddr := suite.app.SupplyKeeper.GetModuleAddress(distribution.ModuleName)
+
+	input := suite.pack(suite.distribution, "setWithdrawAddress", ethcmn.BytesToAddress(moduleAddr.Bytes()))
+	_, err := suite.call(suite.distribution, input, big.NewInt(0))
+	suite.Require().Error(err)
+}
@@ -0,0 +1,11 @@
+/*
+Package precompiles implements the stateful precompiled contracts that expose
+the Cosmos SDK modules to the EVM.
+
+Each precompile is registered at a fixed address on the EVM keeper during the
+app initialization and is only executed once its address is included on the
+ActivePrecompiles parameter of the evm module. The precompiles receive ABI
+encoded inputs, so they can be called from Solidity through a regular contract
+interface.
+*/
+package precompiles
@@ -0,0 +1,164 @@
+package precompiles_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/suite"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+
+	"github.com/cosmos/ethermint/app"
+	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
+	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/precompiles"
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	"github.com/tendermint/tendermint/crypto/ed25519"
+)
+
+// testDenom is the staking bond denomination of the default genesis
+const testDenom = "stake"
+
+// abiPrecompile is a precompiled contract that exposes its Solidity ABI
+type abiPrecompile interface {
+	types.PrecompiledContract
+	ABI() abi.ABI
+}
+
+type PrecompileTestSuite struct {
+	suite.Suite
+
+	ctx          sdk.Context
+	app          *app.EthermintApp
+	stateDB      *types.CommitStateDB
+	bank         precompiles.BankPrecompile
+	staking      precompiles.StakingPrecompile
+	distribution precompiles.DistributionPrecompile
+	address      ethcmn.Address
+	recipient    ethcmn.Address
+	txCount      uint64
+}
+
+func TestPrecompileTestSuite(t *testing.T) {
+	suite.Run(t, new(PrecompileTestSuite))
+}
+
+func (suite *PrecompileTestSuite) SetupTest() {
+	checkTx := false
+
+	suite.app = app.Setup(checkTx)
+	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-3", Time: time.Now().UTC()})
+	suite.stateDB = suite.app.EvmKeeper.NewStateDB(suite.ctx)
+
+	suite.bank = precompiles.NewBankPrecompile(suite.app.SupplyKeeper, suite.app.BlacklistedAccAddrs())
+	suite.staking = precompiles.NewStakingPrecompile(suite.app.StakingKeeper, suite.app.DistrKeeper)
+	suite.distribution = precompiles.NewDistributionPrecompile(suite.app.DistrKeeper)
+
+	params := types.DefaultParams()
+	params.ActivePrecompiles = []string{
+		precompiles.BankPrecompileAddress,
+		precompiles.StakingPrecompileAddress,
+		precompiles.DistributionPrecompileAddress,
+	}
+	suite.app.EvmKeeper.SetParams(suite.ctx, params)
+
+	suite.address = suite.newAddress()
+	suite.recipient = suite.newAddress()
+
+	suite.fund(suite.address, sdk.NewCoins(
+		ethermint.NewPhotonCoin(sdk.NewInt(100)),
+		sdk.NewCoin(testDenom, sdk.NewInt(1000)),
+	))
+}
+
+func (suite *PrecompileTestSuite) newAddress() ethcmn.Address {
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	return ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+}
+
+func (suite *PrecompileTestSuite) fund(addr ethcmn.Address, coins sdk.Coins) {
+	acc := &ethermint.EthAccount{
+		BaseAccount: auth.NewBaseAccount(sdk.AccAddress(addr.Bytes()), coins, nil, 0, 0),
+		CodeHash:    ethcrypto.Keccak256(nil),
+	}
+
+	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
+}
+
+// createValidator creates a bonded validator with a self delegation and returns
+// its operator address.
+func (suite *PrecompileTestSuite) createValidator(selfDelegation int64) ethcmn.Address {
+	operator := suite.newAddress()
+	suite.fund(operator, sdk.NewCoins(sdk.NewCoin(testDenom, sdk.NewInt(selfDelegation))))
+
+	msg := staking.NewMsgCreateValidator(
+		sdk.ValAddress(operator.Bytes()), ed25519.GenPrivKey().PubKey(),
+		sdk.NewCoin(testDenom, sdk.NewInt(selfDelegation)), staking.Description{Moniker: "validator"},
+		staking.NewCommissionRates(sdk.ZeroDec(), sdk.ZeroDec(), sdk.ZeroDec()), sdk.OneInt(),
+	)
+
+	_, err := staking.NewHandler(suite.app.StakingKeeper)(suite.ctx, msg)
+	suite.Require().NoError(err)
+
+	return operator
+}
+
+func (suite *PrecompileTestSuite) pack(contract abiPrecompile, method string, args ...interface{}) []byte {
+	input, err := contract.ABI().Pack(method, args...)
+	suite.Require().NoError(err)
+	return input
+}
+
+func (suite *PrecompileTestSuite) unpack(contract abiPrecompile, method string, res *types.ExecutionResult) []interface{} {
+	data, err := types.DecodeResultData(res.Result.Data)
+	suite.Require().NoError(err)
+
+	values, err := contract.ABI().Unpack(method, data.Ret)
+	suite.Require().NoError(err)
+	return values
+}
+
+func (suite *PrecompileTestSuite) call(contract abiPrecompile, input []byte, amount *big.Int) (*types.ExecutionResult, error) {
+	return suite.callAddress(contract.Address(), input, amount)
+}
+
+func (suite *PrecompileTestSuite) callAddress(recipient ethcmn.Address, input []byte, amount *big.Int) (*types.ExecutionResult, error) {
+	// use a different hash for every call so that the logs aren't mixed
+	suite.txCount++
+	txHash := ethcmn.BigToHash(new(big.Int).SetUint64(suite.txCount))
+	suite.stateDB.Prepare(txHash, 0)
+
+	st := types.StateTransition{
+		AccountNonce: 0,
+		Price:        big.NewInt(0),
+		GasLimit:     1000000,
+		Recipient:    &recipient,
+		Amount:       amount,
+		Payload:      input,
+		ChainID:      big.NewInt(3),
+		Csdb:         suite.stateDB,
+		TxHash:       &txHash,
+		Sender:       suite.address,
+	}
+
+	return st.TransitionDb(suite.ctx, types.DefaultChainConfig())
+}
+
+func (suite *PrecompileTestSuite) balance(addr ethcmn.Address, denom string) sdk.Int {
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, sdk.AccAddress(addr.Bytes()))
+	if acc == nil {
+		return sdk.ZeroInt()
+	}
+
+	return acc.GetCoins().AmountOf(denom)
+}
@@ -0,0 +1,275 @@
+package precompiles
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+	stakingtypes "github.com/cosmos/cosmos-sdk/x/staking/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// StakingPrecompileAddress defines the address of the staking precompiled contract
+const StakingPrecompileAddress = "0x0000000000000000000000000000000000000801"
+
+// Gas costs of the staking precompile methods
+const (
+	StakingDelegateGas    uint64 = 50000
+	StakingUndelegateGas  uint64 = 50000
+	StakingRedelegateGas  uint64 = 60000
+	StakingDelegationGas  uint64 = 5000
+	StakingDelegationsGas uint64 = 20000
+)
+
+// StakingMaxDelegations is the maximum number of delegations returned by the
+// delegations method.
+const StakingMaxDelegations uint16 = 100
+
+const stakingABI = `[
+	{"type":"function","name":"delegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"undelegate","stateMutability":"nonpayable","inputs":[{"name":"validator","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"completionTime","type":"int64"}]},
+	{"type":"function","name":"redelegate","stateMutability":"nonpayable","inputs":[{"name":"validatorSrc","type":"address"},{"name":"validatorDst","type":"address"},{"name":"amount","type":"uint256"}],"outputs":[{"name":"completionTime","type":"int64"}]},
+	{"type":"function","name":"delegation","stateMutability":"view","inputs":[{"name":"delegator","type":"address"},{"name":"validator","type":"address"}],"outputs":[{"name":"shares","type":"uint256"},{"name":"balance","type":"uint256"}]},
+	{"type":"function","name":"delegations","stateMutability":"view","inputs":[{"name":"delegator","type":"address"}],"outputs":[{"name":"validators","type":"address[]"},{"name":"balances","type":"uint256[]"}]},
+	{"type":"event","name":"Delegate","anonymous":false,"inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Unbond","anonymous":false,"inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validator","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"completionTime","type":"int64","indexed":false}]},
+	{"type":"event","name":"Redelegate","anonymous":false,"inputs":[{"name":"delegator","type":"address","indexed":true},{"name":"validatorSrc","type":"address","indexed":true},{"name":"validatorDst","type":"address","indexed":true},{"name":"amount","type":"uint256","indexed":false},{"name":"completionTime","type":"int64","indexed":false}]}
+]`
+
+var _ types.PrecompiledContract = StakingPrecompile{}
+
+// StakingPrecompile is a stateful precompiled contract that allows EVM accounts and
+// contracts to delegate, undelegate and redelegate the bond denomination tokens.
+// The caller of the precompile is the delegator of the operations.
+//
+// The validator operator addresses are represented on the ABI as Ethereum addresses
+// that contain the validator address bytes.
+type StakingPrecompile struct {
+	abi                abi.ABI
+	stakingKeeper      StakingKeeper
+	distributionKeeper DistributionKeeper
+}
+
+// NewStakingPrecompile creates a new StakingPrecompile instance. The distribution
+// keeper is used to keep the EVM state of the delegator rewards withdraw address
+// up to date, as modifying a delegation withdraws its rewards.
+func NewStakingPrecompile(sk StakingKeeper, dk DistributionKeeper) StakingPrecompile {
+	return StakingPrecompile{
+		abi:                mustParseABI(stakingABI),
+		stakingKeeper:      sk,
+		distributionKeeper: dk,
+	}
+}
+
+// ABI returns the Solidity ABI of the staking precompile.
+func (sp StakingPrecompile) ABI() abi.ABI {
+	return sp.abi
+}
+
+// Address implements PrecompiledContract.
+func (StakingPrecompile) Address() ethcmn.Address {
+	return ethcmn.HexToAddress(StakingPrecompileAddress)
+}
+
+// RequiredGas implements PrecompiledContract.
+func (sp StakingPrecompile) RequiredGas(input []byte) uint64 {
+	return methodGas(sp.abi, input, map[string]uint64{
+		"delegate":    StakingDelegateGas,
+		"undelegate":  StakingUndelegateGas,
+		"redelegate":  StakingRedelegateGas,
+		"delegation":  StakingDelegationGas,
+		"delegations": StakingDelegationsGas,
+	})
+}
+
+// Run implements PrecompiledContract.
+func (sp StakingPrecompile) Run(pc *types.PrecompileContext, input []byte) ([]byte, error) {
+	method, args, err := parseMethod(sp.abi, input)
+	if err != nil {
+		return nil, err
+	}
+
+	if pc.Value.Sign() != 0 {
+		return nil, errors.New("staking precompile methods are not payable")
+	}
+
+	switch method.Name {
+	case "delegation":
+		delegator := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+		validator := sdk.ValAddress(args[1].(ethcmn.Address).Bytes())
+
+		shares, balance := sp.delegation(pc.Ctx, delegator, validator)
+		return method.Outputs.Pack(shares, balance)
+
+	case "delegations":
+		delegator := sdk.AccAddress(args[0].(ethcmn.Address).Bytes())
+
+		delegations := sp.stakingKeeper.GetDelegatorDelegations(pc.Ctx, delegator, StakingMaxDelegations)
+		validators := make([]ethcmn.Address, len(delegations))
+		balances := make([]*big.Int, len(delegations))
+
+		for i, delegation := range delegations {
+			validators[i] = ethcmn.BytesToAddress(delegation.ValidatorAddress.Bytes())
+			_, balances[i] = sp.delegation(pc.Ctx, delegator, delegation.ValidatorAddress)
+		}
+
+		return method.Outputs.Pack(validators, balances)
+	}
+
+	if pc.ReadOnly {
+		return nil, errors.New("staking transactions cannot be executed on read only mode")
+	}
+
+	switch method.Name {
+	case "delegate":
+		validator := args[0].(ethcmn.Address)
+		amount := sdk.NewIntFromBigInt(args[1].(*big.Int))
+
+		if err := sp.delegate(pc, validator, amount); err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(true)
+
+	case "undelegate":
+		validator := args[0].(ethcmn.Address)
+		amount := sdk.NewIntFromBigInt(args[1].(*big.Int))
+
+		completionTime, err := sp.undelegate(pc, validator, amount)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(completionTime.Unix())
+
+	case "redelegate":
+		validatorSrc := args[0].(ethcmn.Address)
+		validatorDst := args[1].(ethcmn.Address)
+		amount := sdk.NewIntFromBigInt(args[2].(*big.Int))
+
+		completionTime, err := sp.redelegate(pc, validatorSrc, validatorDst, amount)
+		if err != nil {
+			return nil, err
+		}
+
+		return method.Outputs.Pack(completionTime.Unix())
+
+	default:
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrUnknownRequest, "unknown staking precompile method %s", method.Name)
+	}
+}
+
+// delegation returns the shares, with 18 decimals of precision, and the token
+// balance of a delegation.
+func (sp StakingPrecompile) delegation(ctx sdk.Context, delegator sdk.AccAddress, validator sdk.ValAddress) (*big.Int, *big.Int) {
+	delegation, found := sp.stakingKeeper.GetDelegation(ctx, delegator, validator)
+	if !found {
+		return new(big.Int), new(big.Int)
+	}
+
+	val, found := sp.stakingKeeper.GetValidator(ctx, validator)
+	if !found {
+		return delegation.Shares.BigInt(), new(big.Int)
+	}
+
+	return delegation.Shares.BigInt(), val.TokensFromShares(delegation.Shares).TruncateInt().BigInt()
+}
+
+// syncDelegator executes fn keeping the EVM state of the caller and its rewards
+// withdraw address up to date with the changes done by the SDK keepers.
+func (sp StakingPrecompile) syncDelegator(pc *types.PrecompileContext, fn func() error) error {
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+	withdrawAddr := sp.distributionKeeper.GetDelegatorWithdrawAddr(pc.Ctx, delegator)
+
+	return syncAccounts(pc, fn, pc.Caller, ethcmn.BytesToAddress(withdrawAddr.Bytes()))
+}
+
+func (sp StakingPrecompile) delegate(pc *types.PrecompileContext, validatorAddr ethcmn.Address, amount sdk.Int) error {
+	if err := validateAmount(amount); err != nil {
+		return err
+	}
+
+	validator, found := sp.stakingKeeper.GetValidator(pc.Ctx, sdk.ValAddress(validatorAddr.Bytes()))
+	if !found {
+		return stakingtypes.ErrNoValidatorFound
+	}
+
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+	if err := sp.syncDelegator(pc, func() error {
+		// NOTE: source funds are always unbonded
+		_, err := sp.stakingKeeper.Delegate(pc.Ctx, delegator, amount, sdk.Unbonded, validator, true)
+		return err
+	}); err != nil {
+		return err
+	}
+
+	return sp.addLog(pc, "Delegate", []ethcmn.Address{pc.Caller, validatorAddr}, amount.BigInt())
+}
+
+func (sp StakingPrecompile) undelegate(pc *types.PrecompileContext, validatorAddr ethcmn.Address, amount sdk.Int) (time.Time, error) {
+	if err := validateAmount(amount); err != nil {
+		return time.Time{}, err
+	}
+
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+	validator := sdk.ValAddress(validatorAddr.Bytes())
+
+	shares, err := sp.stakingKeeper.ValidateUnbondAmount(pc.Ctx, delegator, validator, amount)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var completionTime time.Time
+	if err := sp.syncDelegator(pc, func() (err error) {
+		completionTime, err = sp.stakingKeeper.Undelegate(pc.Ctx, delegator, validator, shares)
+		return err
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	err = sp.addLog(pc, "Unbond", []ethcmn.Address{pc.Caller, validatorAddr}, amount.BigInt(), completionTime.Unix())
+	return completionTime, err
+}
+
+func (sp StakingPrecompile) redelegate(
+	pc *types.PrecompileContext, validatorSrcAddr, validatorDstAddr ethcmn.Address, amount sdk.Int,
+) (time.Time, error) {
+	if err := validateAmount(amount); err != nil {
+		return time.Time{}, err
+	}
+
+	delegator := sdk.AccAddress(pc.Caller.Bytes())
+	validatorSrc := sdk.ValAddress(validatorSrcAddr.Bytes())
+	validatorDst := sdk.ValAddress(validatorDstAddr.Bytes())
+
+	shares, err := sp.stakingKeeper.ValidateUnbondAmount(pc.Ctx, delegator, validatorSrc, amount)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	var completionTime time.Time
+	if err := sp.syncDelegator(pc, func() (err error) {
+		completionTime, err = sp.stakingKeeper.BeginRedelegation(pc.Ctx, delegator, validatorSrc, validatorDst, shares)
+		return err
+	}); err != nil {
+		return time.Time{}, err
+	}
+
+	err = sp.addLog(
+		pc, "Redelegate", []ethcmn.Address{pc.Caller, validatorSrcAddr, validatorDstAddr},
+		amount.BigInt(), completionTime.Unix(),
+	)
+	return completionTime, err
+}
+
+// addLog emits the ABI event with the given indexed addresses and non indexed
+// values.
+func (sp StakingPrecompile) addLog(pc *types.PrecompileContext, name string, indexed []ethcmn.Address, values ...interface{}) error {
+	return addEventLog(pc, sp.Address(), sp.abi.Events[name], indexed, values...)
+}
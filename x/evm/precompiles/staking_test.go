@@ -0,0 +1,150 @@
+package precompiles_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func (suite *PrecompileTestSuite) delegationBalance(validator ethcmn.Address) *big.Int {
+	res, err := suite.call(suite.staking, suite.pack(suite.staking, "delegation", suite.address, validator), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	return suite.unpack(suite.staking, "delegation", res)[1].(*big.Int)
+}
+
+func (suite *PrecompileTestSuite) TestStakingDelegate() {
+	var validator ethcmn.Address
+
+	testCases := []struct {
+		name       string
+		malleate   func()
+		amount     int64
+		expPass    bool
+		expBalance int64
+	}{
+		{
+			"delegate",
+			func() {
+				validator = suite.createValidator(100)
+			},
+			300,
+			true,
+			700,
+		},
+		{
+			"validator not found",
+			func() {
+				validator = suite.newAddress()
+			},
+			300,
+			false,
+			1000,
+		},
+		{
+			"insufficient funds",
+			func() {
+				validator = suite.createValidator(100)
+			},
+			1001,
+			false,
+			1000,
+		},
+		{
+			"zero amount",
+			func() {
+				validator = suite.createValidator(100)
+			},
+			0,
+			false,
+			1000,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.SetupTest() // reset
+
+			tc.malleate()
+
+			input := suite.pack(suite.staking, "delegate", validator, big.NewInt(tc.amount))
+			res, err := suite.call(suite.staking, input, big.NewInt(0))
+			if tc.expPass {
+				suite.Require().NoError(err)
+				suite.Require().Len(res.Logs, 1)
+
+				event := suite.staking.ABI().Events["Delegate"]
+				suite.Require().Equal(event.ID, res.Logs[0].Topics[0])
+				suite.Require().Equal(ethcmn.BytesToHash(suite.address.Bytes()), res.Logs[0].Topics[1])
+				suite.Require().Equal(ethcmn.BytesToHash(validator.Bytes()), res.Logs[0].Topics[2])
+				suite.Require().Equal(big.NewInt(tc.amount), suite.delegationBalance(validator))
+			} else {
+				suite.Require().Error(err)
+			}
+
+			suite.Require().Equal(sdk.NewInt(tc.expBalance), suite.balance(suite.address, testDenom))
+		})
+	}
+}
+
+func (suite *PrecompileTestSuite) TestStakingUndelegate() {
+	validator := suite.createValidator(100)
+
+	_, err := suite.call(suite.staking, suite.pack(suite.staking, "delegate", validator, big.NewInt(300)), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	_, err = suite.call(suite.staking, suite.pack(suite.staking, "undelegate", validator, big.NewInt(301)), big.NewInt(0))
+	suite.Require().Error(err)
+
+	res, err := suite.call(suite.staking, suite.pack(suite.staking, "undelegate", validator, big.NewInt(100)), big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Logs, 1)
+
+	completionTime := suite.unpack(suite.staking, "undelegate", res)[0].(int64)
+	unbondingTime := suite.app.StakingKeeper.UnbondingTime(suite.ctx)
+	suite.Require().Equal(suite.ctx.BlockTime().Add(unbondingTime).Unix(), completionTime)
+
+	suite.Require().Equal(big.NewInt(200), suite.delegationBalance(validator))
+
+	ubd, found := suite.app.StakingKeeper.GetUnbondingDelegation(
+		suite.ctx, sdk.AccAddress(suite.address.Bytes()), sdk.ValAddress(validator.Bytes()),
+	)
+	suite.Require().True(found)
+	suite.Require().Len(ubd.Entries, 1)
+	suite.Require().Equal(sdk.NewInt(100), ubd.Entries[0].Balance)
+}
+
+func (suite *PrecompileTestSuite) TestStakingRedelegate() {
+	validatorSrc := suite.createValidator(100)
+	validatorDst := suite.createValidator(100)
+
+	_, err := suite.call(suite.staking, suite.pack(suite.staking, "delegate", validatorSrc, big.NewInt(300)), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	input := suite.pack(suite.staking, "redelegate", validatorSrc, validatorDst, big.NewInt(100))
+	res, err := suite.call(suite.staking, input, big.NewInt(0))
+	suite.Require().NoError(err)
+	suite.Require().Len(res.Logs, 1)
+	suite.Require().Len(res.Logs[0].Topics, 4)
+
+	suite.Require().Equal(big.NewInt(200), suite.delegationBalance(validatorSrc))
+	suite.Require().Equal(big.NewInt(100), suite.delegationBalance(validatorDst))
+
+	res, err = suite.call(suite.staking, suite.pack(suite.staking, "delegations", suite.address), big.NewInt(0))
+	suite.Require().NoError(err)
+
+	values := suite.unpack(suite.staking, "delegations", res)
+	suite.Require().ElementsMatch([]ethcmn.Address{validatorSrc, validatorDst}, values[0])
+	suite.Require().Len(values[1], 2)
+}
+
+func (suite *PrecompileTestSuite) TestStakingReadOnly() {
+	validator := suite.createValidator(100)
+
+	// a delegation transferring value is rejected
+	_, err := suite.call(suite.staking, suite.pack(suite.staking, "delegate", validator, big.NewInt(300)), big.NewInt(1))
+	suite.Require().Error(err)
+	suite.Require().Equal(sdk.NewInt(1000), suite.balance(suite.address, testDenom))
+}
@@ -0,0 +1,59 @@
+package precompiles
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// syncAccounts flushes the live EVM state of the accounts to the precompile
+// context before calling fn and reloads them afterwards, so that the changes done
+// by the SDK keepers are reflected on the EVM state db.
+func syncAccounts(pc *types.PrecompileContext, fn func() error, addrs ...ethcmn.Address) error {
+	for _, addr := range addrs {
+		pc.FlushAccount(addr)
+	}
+
+	if err := fn(); err != nil {
+		return err
+	}
+
+	for _, addr := range addrs {
+		pc.ReloadAccount(addr)
+	}
+
+	return nil
+}
+
+// validateAmount returns an error if the token amount is not positive.
+func validateAmount(amount sdk.Int) error {
+	if !amount.IsPositive() {
+		return sdkerrors.Wrapf(sdkerrors.ErrInvalidCoins, "amount must be positive, got %s", amount)
+	}
+
+	return nil
+}
+
+// addEventLog emits an EVM log for the ABI event from the precompile address. The
+// indexed arguments of the event must be addresses.
+func addEventLog(
+	pc *types.PrecompileContext, address ethcmn.Address, event abi.Event, indexed []ethcmn.Address, values ...interface{},
+) error {
+	data, err := event.Inputs.NonIndexed().Pack(values...)
+	if err != nil {
+		return err
+	}
+
+	topics := make([]ethcmn.Hash, 0, len(indexed)+1)
+	topics = append(topics, event.ID)
+	for _, addr := range indexed {
+		topics = append(topics, ethcmn.BytesToHash(addr.Bytes()))
+	}
+
+	pc.AddLog(address, topics, data)
+	return nil
+}
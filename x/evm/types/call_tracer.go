@@ -0,0 +1,239 @@
+package types
+
+import (
+	"errors"
+	"math/big"
+	"time"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+)
+
+// Call frame types
+const (
+	CallTypeCall         = "CALL"
+	CallTypeCallCode     = "CALLCODE"
+	CallTypeDelegateCall = "DELEGATECALL"
+	CallTypeStaticCall   = "STATICCALL"
+	CallTypeCreate       = "CREATE"
+	CallTypeCreate2      = "CREATE2"
+	CallTypeSelfDestruct = "SELFDESTRUCT"
+)
+
+// CallFrame is a call, contract creation or self-destruct of an EVM execution. The
+// frames of a transaction are flattened in depth-first order, and the position of
+// a frame in the call tree is given by its trace address, i.e the indexes of the
+// frame and of its parents in the calls of their parent. The top-level frame has
+// an empty trace address.
+type CallFrame struct {
+	Type         string         `json:"type"`
+	From         ethcmn.Address `json:"from"`
+	To           ethcmn.Address `json:"to"` // created contract for the creations, beneficiary for the self-destructs
+	Value        *big.Int       `json:"value,omitempty"`
+	Gas          uint64         `json:"gas"`
+	GasUsed      uint64         `json:"gas_used"`
+	Input        []byte         `json:"input,omitempty"`
+	Output       []byte         `json:"output,omitempty"` // runtime code for the creations
+	Error        string         `json:"error,omitempty"`
+	TraceAddress []int          `json:"trace_address"`
+	Subtraces    int            `json:"subtraces"`
+}
+
+// callFrame is a call frame of the call tree being traced.
+type callFrame struct {
+	CallFrame
+
+	calls []*callFrame
+}
+
+// CallTracer is an EVM tracer that records the call frames of an execution. It
+// follows the implementation of the go-ethereum native call tracer: the frames are
+// opened and closed by the EVM when it enters and exits a call, creation or
+// self-destruct.
+//
+// NOTE: a CallTracer must only be used for a single execution.
+type CallTracer struct {
+	// callstack is the stack of the open frames, starting with the top-level one
+	callstack []*callFrame
+	// started is true once the top-level frame is captured
+	started bool
+}
+
+var _ ethvm.EVMLogger = &CallTracer{}
+
+// NewCallTracer creates a new CallTracer.
+func NewCallTracer() *CallTracer {
+	return &CallTracer{
+		callstack: []*callFrame{{}},
+	}
+}
+
+// CaptureStart implements vm.EVMLogger. It's called at the start of the top-level
+// frame.
+func (ct *CallTracer) CaptureStart(_ *ethvm.EVM, from, to ethcmn.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	root := ct.callstack[0]
+	root.Type = CallTypeCall
+	if create {
+		root.Type = CallTypeCreate
+	}
+
+	root.From = from
+	root.To = to
+	root.Value = new(big.Int).Set(value)
+	root.Gas = gas
+	root.Input = ethcmn.CopyBytes(input)
+
+	ct.started = true
+}
+
+// CaptureState implements vm.EVMLogger.
+func (*CallTracer) CaptureState(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, []byte, int, error) {
+}
+
+// CaptureEnter implements vm.EVMLogger. It's called when the EVM enters a call,
+// creation or self-destruct frame.
+func (ct *CallTracer) CaptureEnter(typ ethvm.OpCode, from, to ethcmn.Address, input []byte, gas uint64, value *big.Int) {
+	frame := &callFrame{
+		CallFrame: CallFrame{
+			Type:  typ.String(),
+			From:  from,
+			To:    to,
+			Gas:   gas,
+			Input: ethcmn.CopyBytes(input),
+		},
+	}
+
+	// the delegate and static calls don't transfer value
+	if value != nil {
+		frame.Value = new(big.Int).Set(value)
+	}
+
+	ct.callstack = append(ct.callstack, frame)
+}
+
+// CaptureExit implements vm.EVMLogger. It's called when the EVM exits the last
+// frame, which is added to the calls of its parent.
+func (ct *CallTracer) CaptureExit(output []byte, gasUsed uint64, err error) {
+	if len(ct.callstack) <= 1 {
+		return
+	}
+
+	frame := ct.callstack[len(ct.callstack)-1]
+	ct.callstack = ct.callstack[:len(ct.callstack)-1]
+
+	frame.GasUsed = gasUsed
+	switch {
+	case err == nil:
+		frame.Output = ethcmn.CopyBytes(output)
+	case frame.Type == CallTypeCreate || frame.Type == CallTypeCreate2:
+		// no contract is created by the failed creations
+		frame.To = ethcmn.Address{}
+		frame.Error = err.Error()
+	default:
+		// the revert data contains the revert reason
+		if errors.Is(err, ethvm.ErrExecutionReverted) {
+			frame.Output = ethcmn.CopyBytes(output)
+		}
+		frame.Error = err.Error()
+	}
+
+	parent := ct.callstack[len(ct.callstack)-1]
+	parent.calls = append(parent.calls, frame)
+}
+
+// CaptureFault implements vm.EVMLogger.
+func (*CallTracer) CaptureFault(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, int, error) {
+}
+
+// CaptureEnd implements vm.EVMLogger. It's called at the end of the top-level frame.
+func (ct *CallTracer) CaptureEnd(output []byte, gasUsed uint64, _ time.Duration, err error) {
+	root := ct.callstack[0]
+	root.GasUsed = gasUsed
+	root.Output = ethcmn.CopyBytes(output)
+
+	if err != nil && root.Error == "" {
+		root.Error = err.Error()
+	}
+}
+
+// Frames returns the call frames of the execution in depth-first order, or nil if
+// the execution didn't start. The given error of the state transition is set on
+// the top-level frame if it doesn't have one, as the execution can be aborted
+// without ending the top-level frame.
+func (ct *CallTracer) Frames(err error) []CallFrame {
+	if !ct.started {
+		return nil
+	}
+
+	root := ct.callstack[0]
+	if err != nil && root.Error == "" {
+		root.Error = err.Error()
+	}
+
+	var frames []CallFrame
+	flattenCallFrame(root, []int{}, &frames)
+	return frames
+}
+
+// flattenCallFrame appends the frame and its calls to the list in depth-first
+// order.
+func flattenCallFrame(frame *callFrame, traceAddress []int, frames *[]CallFrame) {
+	flat := frame.CallFrame
+	flat.TraceAddress = traceAddress
+	flat.Subtraces = len(frame.calls)
+	*frames = append(*frames, flat)
+
+	for i, call := range frame.calls {
+		childAddress := make([]int, len(traceAddress), len(traceAddress)+1)
+		copy(childAddress, traceAddress)
+		flattenCallFrame(call, append(childAddress, i), frames)
+	}
+}
+
+// tracers combines the EVM tracers of a state transition.
+type tracers []ethvm.EVMLogger
+
+var _ ethvm.EVMLogger = tracers{}
+
+// CaptureStart implements vm.EVMLogger.
+func (ts tracers) CaptureStart(env *ethvm.EVM, from, to ethcmn.Address, create bool, input []byte, gas uint64, value *big.Int) {
+	for _, t := range ts {
+		t.CaptureStart(env, from, to, create, input, gas, value)
+	}
+}
+
+// CaptureState implements vm.EVMLogger.
+func (ts tracers) CaptureState(pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, rData []byte, depth int, err error) {
+	for _, t := range ts {
+		t.CaptureState(pc, op, gas, cost, scope, rData, depth, err)
+	}
+}
+
+// CaptureEnter implements vm.EVMLogger.
+func (ts tracers) CaptureEnter(typ ethvm.OpCode, from, to ethcmn.Address, input []byte, gas uint64, value *big.Int) {
+	for _, t := range ts {
+		t.CaptureEnter(typ, from, to, input, gas, value)
+	}
+}
+
+// CaptureExit implements vm.EVMLogger.
+func (ts tracers) CaptureExit(output []byte, gasUsed uint64, err error) {
+	for _, t := range ts {
+		t.CaptureExit(output, gasUsed, err)
+	}
+}
+
+// CaptureFault implements vm.EVMLogger.
+func (ts tracers) CaptureFault(pc uint64, op ethvm.OpCode, gas, cost uint64, scope *ethvm.ScopeContext, depth int, err error) {
+	for _, t := range ts {
+		t.CaptureFault(pc, op, gas, cost, scope, depth, err)
+	}
+}
+
+// CaptureEnd implements vm.EVMLogger.
+func (ts tracers) CaptureEnd(output []byte, gasUsed uint64, t time.Duration, err error) {
+	for _, tracer := range ts {
+		tracer.CaptureEnd(output, gasUsed, t, err)
+	}
+}
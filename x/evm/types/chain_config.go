@@ -20,7 +20,7 @@ import (
 //
 // NOTE 2: This type is not a configurable Param since the SDK does not allow for validation against
 // a previous stored parameter values or the current block height (retrieved from context). If you
-// want to update the config values, use an software upgrade procedure.
+// want to schedule new forks, use a ChainConfigUpgradeProposal.
 type ChainConfig struct {
 	HomesteadBlock sdk.Int `json:"homestead_block" yaml:"homestead_block"` // Homestead switch block (< 0 no fork, 0 = already homestead)
 
@@ -40,8 +40,13 @@ type ChainConfig struct {
 	IstanbulBlock       sdk.Int `json:"istanbul_block" yaml:"istanbul_block"`             // Istanbul switch block (< 0 no fork, 0 = already on istanbul)
 	MuirGlacierBlock    sdk.Int `json:"muir_glacier_block" yaml:"muir_glacier_block"`     // Eip-2384 (bomb delay) switch block (< 0 no fork, 0 = already activated)
 
-	YoloV2Block sdk.Int `json:"yoloV2_block" yaml:"yoloV2_block"` // YOLO v1: https://github.com/ethereum/EIPs/pull/2657 (Ephemeral testnet)
+	// NOTE: the YOLO v2 and EWASM forks are no longer defined by go-ethereum. The fields are kept for the
+	// compatibility of the stored chain configs, and the forks must stay disabled.
+	YoloV2Block sdk.Int `json:"yoloV2_block" yaml:"yoloV2_block"` // YOLO v2: https://github.com/ethereum/EIPs/pull/2657 (Ephemeral testnet)
 	EWASMBlock  sdk.Int `json:"ewasm_block" yaml:"ewasm_block"`   // EWASM switch block (< 0 no fork, 0 = already activated)
+
+	BerlinBlock sdk.Int `json:"berlin_block" yaml:"berlin_block"` // Berlin switch block (< 0 no fork, 0 = already on berlin)
+	LondonBlock sdk.Int `json:"london_block" yaml:"london_block"` // London switch block (< 0 no fork, 0 = already on london)
 }
 
 // EthereumConfig returns an Ethereum ChainConfig for EVM state transitions.
@@ -61,11 +66,26 @@ func (cc ChainConfig) EthereumConfig(chainID *big.Int) *params.ChainConfig {
 		PetersburgBlock:     getBlockValue(cc.PetersburgBlock),
 		IstanbulBlock:       getBlockValue(cc.IstanbulBlock),
 		MuirGlacierBlock:    getBlockValue(cc.MuirGlacierBlock),
-		YoloV2Block:         getBlockValue(cc.YoloV2Block),
-		EWASMBlock:          getBlockValue(cc.EWASMBlock),
+		BerlinBlock:         getBlockValue(cc.BerlinBlock),
+		LondonBlock:         getBlockValue(cc.LondonBlock),
 	}
 }
 
+// Rules returns the chain rules for the given block height.
+func (cc ChainConfig) Rules(chainID *big.Int, height int64) params.Rules {
+	return cc.EthereumConfig(chainID).Rules(big.NewInt(height), false)
+}
+
+// IsBerlin returns whether the Berlin fork is active at the given block height.
+func (cc ChainConfig) IsBerlin(height int64) bool {
+	return isForkActive(cc.BerlinBlock, height)
+}
+
+// IsLondon returns whether the London fork is active at the given block height.
+func (cc ChainConfig) IsLondon(height int64) bool {
+	return isForkActive(cc.LondonBlock, height)
+}
+
 // IsIstanbul returns whether the Istanbul version is enabled.
 func (cc ChainConfig) IsIstanbul() bool {
 	return getBlockValue(cc.IstanbulBlock) != nil
@@ -99,7 +119,23 @@ func DefaultChainConfig() ChainConfig {
 		MuirGlacierBlock:    sdk.ZeroInt(),
 		YoloV2Block:         sdk.NewInt(-1),
 		EWASMBlock:          sdk.NewInt(-1),
+		BerlinBlock:         sdk.ZeroInt(),
+		LondonBlock:         sdk.ZeroInt(),
+	}
+}
+
+// Migrate disables the forks that weren't defined by the chain configs stored or
+// exported by previous versions (i.e the ones with a nil block value). The forks
+// can then be scheduled with a ChainConfigUpgradeProposal.
+func (cc ChainConfig) Migrate() ChainConfig {
+	if cc.BerlinBlock.IsNil() {
+		cc.BerlinBlock = sdk.NewInt(-1)
+	}
+	if cc.LondonBlock.IsNil() {
+		cc.LondonBlock = sdk.NewInt(-1)
 	}
+
+	return cc
 }
 
 func getBlockValue(block sdk.Int) *big.Int {
@@ -146,16 +182,98 @@ func (cc ChainConfig) Validate() error {
 	if err := validateBlock(cc.MuirGlacierBlock); err != nil {
 		return sdkerrors.Wrap(err, "muirGlacierBlock")
 	}
-	if err := validateBlock(cc.YoloV2Block); err != nil {
+	if err := validateDisabledBlock(cc.YoloV2Block); err != nil {
 		return sdkerrors.Wrap(err, "yoloV2Block")
 	}
-	if err := validateBlock(cc.EWASMBlock); err != nil {
+	if err := validateDisabledBlock(cc.EWASMBlock); err != nil {
 		return sdkerrors.Wrap(err, "eWASMBlock")
 	}
+	if err := validateBlock(cc.BerlinBlock); err != nil {
+		return sdkerrors.Wrap(err, "berlinBlock")
+	}
+	if err := validateBlock(cc.LondonBlock); err != nil {
+		return sdkerrors.Wrap(err, "londonBlock")
+	}
 
 	return nil
 }
 
+// ValidateForkOrder checks that the forks are enabled in the order defined by
+// Ethereum.
+func (cc ChainConfig) ValidateForkOrder() error {
+	if err := cc.EthereumConfig(nil).CheckConfigForkOrder(); err != nil {
+		return sdkerrors.Wrap(ErrInvalidChainConfig, err.Error())
+	}
+
+	return nil
+}
+
+// ValidateUpgrade checks that the given chain config can replace the current one
+// at the given block height. The forks that are already active at the height can't
+// be modified and the blocks of the updated forks must be greater than the height.
+func (cc ChainConfig) ValidateUpgrade(newConfig ChainConfig, height int64) error {
+	current := cc.forkBlocks()
+	for i, fork := range newConfig.forkBlocks() {
+		prev := current[i].block
+		if fork.block.Equal(prev) {
+			continue
+		}
+
+		if isForkActive(prev, height) {
+			return sdkerrors.Wrapf(
+				ErrInvalidChainConfig, "%s is already active at block %s", fork.name, prev,
+			)
+		}
+
+		if isForkActive(fork.block, height) {
+			return sdkerrors.Wrapf(
+				ErrInvalidChainConfig, "%s %s must be greater than the current block %d", fork.name, fork.block, height,
+			)
+		}
+	}
+
+	if cc.DAOForkSupport != newConfig.DAOForkSupport && isForkActive(cc.DAOForkBlock, height) {
+		return sdkerrors.Wrap(ErrInvalidChainConfig, "cannot change the DAO fork support once the fork is active")
+	}
+
+	if cc.EIP150Hash != newConfig.EIP150Hash && isForkActive(cc.EIP150Block, height) {
+		return sdkerrors.Wrap(ErrInvalidChainConfig, "cannot change the EIP150 hash once the fork is active")
+	}
+
+	return nil
+}
+
+// forkBlock defines the activation block of a fork.
+type forkBlock struct {
+	name  string
+	block sdk.Int
+}
+
+func (cc ChainConfig) forkBlocks() []forkBlock {
+	return []forkBlock{
+		{"homesteadBlock", cc.HomesteadBlock},
+		{"daoForkBlock", cc.DAOForkBlock},
+		{"eip150Block", cc.EIP150Block},
+		{"eip155Block", cc.EIP155Block},
+		{"eip158Block", cc.EIP158Block},
+		{"byzantiumBlock", cc.ByzantiumBlock},
+		{"constantinopleBlock", cc.ConstantinopleBlock},
+		{"petersburgBlock", cc.PetersburgBlock},
+		{"istanbulBlock", cc.IstanbulBlock},
+		{"muirGlacierBlock", cc.MuirGlacierBlock},
+		{"yoloV2Block", cc.YoloV2Block},
+		{"eWASMBlock", cc.EWASMBlock},
+		{"berlinBlock", cc.BerlinBlock},
+		{"londonBlock", cc.LondonBlock},
+	}
+}
+
+// isForkActive returns true if the fork block is set and it's not greater than the
+// given height.
+func isForkActive(block sdk.Int, height int64) bool {
+	return !block.IsNegative() && block.LTE(sdk.NewInt(height))
+}
+
 func validateHash(hex string) error {
 	if hex != "" && strings.TrimSpace(hex) == "" {
 		return sdkerrors.Wrapf(ErrInvalidChainConfig, "hash cannot be blank")
@@ -164,6 +282,19 @@ func validateHash(hex string) error {
 	return nil
 }
 
+// validateDisabledBlock checks the block of a fork that go-ethereum doesn't define.
+func validateDisabledBlock(block sdk.Int) error {
+	if err := validateBlock(block); err != nil {
+		return err
+	}
+
+	if !block.IsNegative() {
+		return sdkerrors.Wrap(ErrInvalidChainConfig, "fork not supported by the EVM, set a negative Int value to disable it")
+	}
+
+	return nil
+}
+
 func validateBlock(block sdk.Int) error {
 	if block == (sdk.Int{}) || block.BigInt() == nil {
 		return sdkerrors.Wrapf(
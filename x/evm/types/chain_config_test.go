@@ -1,6 +1,7 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -33,8 +34,10 @@ func TestChainConfigValidate(t *testing.T) {
 				PetersburgBlock:     sdk.OneInt(),
 				IstanbulBlock:       sdk.OneInt(),
 				MuirGlacierBlock:    sdk.OneInt(),
-				YoloV2Block:         sdk.OneInt(),
-				EWASMBlock:          sdk.OneInt(),
+				YoloV2Block:         sdk.NewInt(-1),
+				EWASMBlock:          sdk.NewInt(-1),
+				BerlinBlock:         sdk.OneInt(),
+				LondonBlock:         sdk.OneInt(),
 			},
 			false,
 		},
@@ -207,11 +210,94 @@ func TestChainConfigValidate(t *testing.T) {
 				PetersburgBlock:     sdk.OneInt(),
 				IstanbulBlock:       sdk.OneInt(),
 				MuirGlacierBlock:    sdk.OneInt(),
-				YoloV2Block:         sdk.OneInt(),
+				YoloV2Block:         sdk.NewInt(-1),
 				EWASMBlock:          sdk.Int{},
 			},
 			true,
 		},
+		{
+			"enabled YoloV2Block",
+			ChainConfig{
+				HomesteadBlock:      sdk.OneInt(),
+				DAOForkBlock:        sdk.OneInt(),
+				EIP150Block:         sdk.OneInt(),
+				EIP150Hash:          defaultEIP150Hash,
+				EIP155Block:         sdk.OneInt(),
+				EIP158Block:         sdk.OneInt(),
+				ByzantiumBlock:      sdk.OneInt(),
+				ConstantinopleBlock: sdk.OneInt(),
+				PetersburgBlock:     sdk.OneInt(),
+				IstanbulBlock:       sdk.OneInt(),
+				MuirGlacierBlock:    sdk.OneInt(),
+				YoloV2Block:         sdk.OneInt(),
+				EWASMBlock:          sdk.NewInt(-1),
+				BerlinBlock:         sdk.OneInt(),
+				LondonBlock:         sdk.OneInt(),
+			},
+			true,
+		},
+		{
+			"enabled EWASMBlock",
+			ChainConfig{
+				HomesteadBlock:      sdk.OneInt(),
+				DAOForkBlock:        sdk.OneInt(),
+				EIP150Block:         sdk.OneInt(),
+				EIP150Hash:          defaultEIP150Hash,
+				EIP155Block:         sdk.OneInt(),
+				EIP158Block:         sdk.OneInt(),
+				ByzantiumBlock:      sdk.OneInt(),
+				ConstantinopleBlock: sdk.OneInt(),
+				PetersburgBlock:     sdk.OneInt(),
+				IstanbulBlock:       sdk.OneInt(),
+				MuirGlacierBlock:    sdk.OneInt(),
+				YoloV2Block:         sdk.NewInt(-1),
+				EWASMBlock:          sdk.ZeroInt(),
+				BerlinBlock:         sdk.OneInt(),
+				LondonBlock:         sdk.OneInt(),
+			},
+			true,
+		},
+		{
+			"invalid BerlinBlock",
+			ChainConfig{
+				HomesteadBlock:      sdk.OneInt(),
+				DAOForkBlock:        sdk.OneInt(),
+				EIP150Block:         sdk.OneInt(),
+				EIP150Hash:          defaultEIP150Hash,
+				EIP155Block:         sdk.OneInt(),
+				EIP158Block:         sdk.OneInt(),
+				ByzantiumBlock:      sdk.OneInt(),
+				ConstantinopleBlock: sdk.OneInt(),
+				PetersburgBlock:     sdk.OneInt(),
+				IstanbulBlock:       sdk.OneInt(),
+				MuirGlacierBlock:    sdk.OneInt(),
+				YoloV2Block:         sdk.NewInt(-1),
+				EWASMBlock:          sdk.NewInt(-1),
+				BerlinBlock:         sdk.Int{},
+			},
+			true,
+		},
+		{
+			"invalid LondonBlock",
+			ChainConfig{
+				HomesteadBlock:      sdk.OneInt(),
+				DAOForkBlock:        sdk.OneInt(),
+				EIP150Block:         sdk.OneInt(),
+				EIP150Hash:          defaultEIP150Hash,
+				EIP155Block:         sdk.OneInt(),
+				EIP158Block:         sdk.OneInt(),
+				ByzantiumBlock:      sdk.OneInt(),
+				ConstantinopleBlock: sdk.OneInt(),
+				PetersburgBlock:     sdk.OneInt(),
+				IstanbulBlock:       sdk.OneInt(),
+				MuirGlacierBlock:    sdk.OneInt(),
+				YoloV2Block:         sdk.NewInt(-1),
+				EWASMBlock:          sdk.NewInt(-1),
+				BerlinBlock:         sdk.OneInt(),
+				LondonBlock:         sdk.Int{},
+			},
+			true,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -240,6 +326,162 @@ istanbul_block: "0"
 muir_glacier_block: "0"
 yoloV2_block: "-1"
 ewasm_block: "-1"
+berlin_block: "0"
+london_block: "0"
 `
 	require.Equal(t, configStr, DefaultChainConfig().String())
 }
+
+func TestChainConfigValidateForkOrder(t *testing.T) {
+	require.NoError(t, DefaultChainConfig().ValidateForkOrder())
+
+	config := DefaultChainConfig()
+	config.ByzantiumBlock = sdk.NewInt(10)
+	require.Error(t, config.ValidateForkOrder())
+
+	config = DefaultChainConfig()
+	config.BerlinBlock = sdk.NewInt(10)
+	config.LondonBlock = sdk.NewInt(10)
+	require.NoError(t, config.ValidateForkOrder())
+
+	config.LondonBlock = sdk.NewInt(5)
+	require.Error(t, config.ValidateForkOrder())
+
+	config.BerlinBlock = sdk.NewInt(-1)
+	require.Error(t, config.ValidateForkOrder())
+
+	config = DefaultChainConfig()
+	config.IstanbulBlock = sdk.NewInt(-1)
+	config.MuirGlacierBlock = sdk.NewInt(-1)
+	require.Error(t, config.ValidateForkOrder())
+}
+
+func TestChainConfigMigrate(t *testing.T) {
+	config := DefaultChainConfig()
+	config.BerlinBlock = sdk.Int{}
+	config.LondonBlock = sdk.Int{}
+	require.Error(t, config.Validate())
+
+	migrated := config.Migrate()
+	require.NoError(t, migrated.Validate())
+	require.False(t, migrated.IsBerlin(100))
+	require.False(t, migrated.IsLondon(100))
+
+	// the configured forks are kept
+	require.Equal(t, DefaultChainConfig(), DefaultChainConfig().Migrate())
+}
+
+func TestChainConfigRules(t *testing.T) {
+	config := DefaultChainConfig()
+	config.BerlinBlock = sdk.NewInt(10)
+	config.LondonBlock = sdk.NewInt(20)
+
+	rules := config.Rules(big.NewInt(1), 15)
+	require.True(t, rules.IsIstanbul)
+	require.True(t, rules.IsBerlin)
+	require.False(t, rules.IsLondon)
+
+	rules = config.Rules(big.NewInt(1), 20)
+	require.True(t, rules.IsBerlin)
+	require.True(t, rules.IsLondon)
+}
+
+func TestChainConfigValidateUpgrade(t *testing.T) {
+	// Berlin scheduled at block 100
+	current := DefaultChainConfig()
+	current.BerlinBlock = sdk.NewInt(100)
+	current.LondonBlock = sdk.NewInt(-1)
+
+	testCases := []struct {
+		name     string
+		malleate func(config *ChainConfig)
+		height   int64
+		expError bool
+	}{
+		{"no changes", func(*ChainConfig) {}, 10, false},
+		{
+			"schedule future fork",
+			func(config *ChainConfig) {
+				config.LondonBlock = sdk.NewInt(50)
+			},
+			10,
+			false,
+		},
+		{
+			"move scheduled fork",
+			func(config *ChainConfig) {
+				config.BerlinBlock = sdk.NewInt(200)
+			},
+			10,
+			false,
+		},
+		{
+			"disable scheduled fork",
+			func(config *ChainConfig) {
+				config.BerlinBlock = sdk.NewInt(-1)
+			},
+			10,
+			false,
+		},
+		{
+			"fork at current height",
+			func(config *ChainConfig) {
+				config.LondonBlock = sdk.NewInt(10)
+			},
+			10,
+			true,
+		},
+		{
+			"fork in the past",
+			func(config *ChainConfig) {
+				config.LondonBlock = sdk.NewInt(5)
+			},
+			10,
+			true,
+		},
+		{
+			"move active fork",
+			func(config *ChainConfig) {
+				config.BerlinBlock = sdk.NewInt(200)
+			},
+			100,
+			true,
+		},
+		{
+			"disable active fork",
+			func(config *ChainConfig) {
+				config.IstanbulBlock = sdk.NewInt(-1)
+			},
+			10,
+			true,
+		},
+		{
+			"change active DAO fork support",
+			func(config *ChainConfig) {
+				config.DAOForkSupport = false
+			},
+			10,
+			true,
+		},
+		{
+			"change active EIP150 hash",
+			func(config *ChainConfig) {
+				config.EIP150Hash = common.BytesToHash([]byte("hash")).String()
+			},
+			10,
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		config := current
+		tc.malleate(&config)
+
+		err := current.ValidateUpgrade(config, tc.height)
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
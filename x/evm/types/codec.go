@@ -14,6 +14,11 @@ func RegisterCodec(cdc *codec.Codec) {
 	cdc.RegisterConcrete(MsgEthermint{}, "ethermint/MsgEthermint", nil)
 	cdc.RegisterConcrete(TxData{}, "ethermint/TxData", nil)
 	cdc.RegisterConcrete(ChainConfig{}, "ethermint/ChainConfig", nil)
+	cdc.RegisterConcrete(ChainConfigUpgradeProposal{}, "ethermint/ChainConfigUpgradeProposal", nil)
+	cdc.RegisterConcrete(RegisterSystemContractProposal{}, "ethermint/RegisterSystemContractProposal", nil)
+	cdc.RegisterConcrete(RemoveSystemContractProposal{}, "ethermint/RemoveSystemContractProposal", nil)
+	cdc.RegisterConcrete(DeployContractProposal{}, "ethermint/DeployContractProposal", nil)
+	cdc.RegisterConcrete(PatchContractProposal{}, "ethermint/PatchContractProposal", nil)
 }
 
 func init() {
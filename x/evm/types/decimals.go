@@ -0,0 +1,60 @@
+package types
+
+import (
+	"encoding/binary"
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+)
+
+// EVMDecimals defines the decimals of the balances on the EVM
+const EVMDecimals uint32 = 18
+
+// GetEVMDenomDecimals returns the EVM denom decimals stored on the EVM module
+// store. The chains without stored decimals, i.e created before the decimals were
+// configurable, use 18 decimals.
+func GetEVMDenomDecimals(store sdk.KVStore) uint32 {
+	bz := store.Get(KeyEVMDenomDecimals)
+	if len(bz) == 0 {
+		return EVMDecimals
+	}
+
+	return uint32(binary.BigEndian.Uint64(bz))
+}
+
+// SetEVMDenomDecimals stores the EVM denom decimals on the EVM module store.
+func SetEVMDenomDecimals(store sdk.KVStore, decimals uint32) {
+	store.Set(KeyEVMDenomDecimals, sdk.Uint64ToBigEndian(uint64(decimals)))
+}
+
+// ScaleFactor returns the factor between an EVM balance and the amount of the EVM
+// denomination, i.e 10^(18 - decimals).
+func (p Params) ScaleFactor() *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(EVMDecimals-p.EvmDenomDecimals)), nil)
+}
+
+// ToEVMAmount scales up an amount of the EVM denomination to an 18 decimals EVM
+// balance.
+func (p Params) ToEVMAmount(amount sdk.Int) *big.Int {
+	return new(big.Int).Mul(amount.BigInt(), p.ScaleFactor())
+}
+
+// FromEVMAmount splits an 18 decimals EVM balance into the amount of the EVM
+// denomination and the dust, i.e the remainder that can't be represented with the
+// denomination decimals. The dust is always non-negative and lower than the scale
+// factor.
+func (p Params) FromEVMAmount(amount *big.Int) (sdk.Int, *big.Int) {
+	coins, dust := new(big.Int).DivMod(amount, p.ScaleFactor(), new(big.Int))
+	return sdk.NewIntFromBigInt(coins), dust
+}
+
+// FromEVMAmountCeil returns the amount of the EVM denomination needed to cover
+// the given 18 decimals EVM amount, rounding up any sub-unit remainder.
+func (p Params) FromEVMAmountCeil(amount *big.Int) sdk.Int {
+	coins, dust := p.FromEVMAmount(amount)
+	if dust.Sign() != 0 {
+		coins = coins.AddRaw(1)
+	}
+
+	return coins
+}
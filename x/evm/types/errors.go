@@ -21,4 +21,31 @@ var (
 
 	// ErrCallDisabled returns an error if the EnableCall parameter is false.
 	ErrCallDisabled = sdkerrors.Register(ModuleName, 6, "EVM Call operation is disabled")
+
+	// ErrDeployerNotAllowed returns an error if the address isn't part of the DeployerAllowlist parameter.
+	ErrDeployerNotAllowed = sdkerrors.Register(ModuleName, 7, "address is not allowed to deploy contracts")
+
+	// ErrContractDenied returns an error if the contract is part of the ContractDenylist parameter.
+	ErrContractDenied = sdkerrors.Register(ModuleName, 8, "contract calls are denied")
+
+	// ErrABIPack returns an error if the contract method arguments can't be packed.
+	ErrABIPack = sdkerrors.Register(ModuleName, 9, "contract ABI pack failed")
+
+	// ErrABIUnpack returns an error if the contract method outputs can't be unpacked.
+	ErrABIUnpack = sdkerrors.Register(ModuleName, 10, "contract ABI unpack failed")
+
+	// ErrInvalidSystemContract returns an error if a system contract can't be registered.
+	ErrInvalidSystemContract = sdkerrors.Register(ModuleName, 11, "invalid system contract")
+
+	// ErrSystemContractNotFound returns an error if the system contract isn't registered.
+	ErrSystemContractNotFound = sdkerrors.Register(ModuleName, 12, "system contract not found")
+
+	// ErrContractAlreadyExists returns an error if an account already exists at the address of a deployed contract.
+	ErrContractAlreadyExists = sdkerrors.Register(ModuleName, 13, "contract address already in use")
+
+	// ErrContractNotFound returns an error if the account has no code.
+	ErrContractNotFound = sdkerrors.Register(ModuleName, 14, "contract not found")
+
+	// ErrBlockedAddress returns an error if an EVM value transfer is sent to a blocked address.
+	ErrBlockedAddress = sdkerrors.Register(ModuleName, 15, "address is not allowed to receive EVM value transfers")
 )
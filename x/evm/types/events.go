@@ -5,7 +5,25 @@ const (
 	EventTypeEthermint  = TypeMsgEthermint
 	EventTypeEthereumTx = TypeMsgEthereumTx
 
+	EventTypeChainConfigUpgrade      = "chain_config_upgrade"
+	EventTypeRegisterSystemContract  = "register_system_contract"
+	EventTypeRemoveSystemContract    = "remove_system_contract"
+	EventTypeSystemContractExecution = "system_contract_execution"
+	EventTypeDeployContract          = "deploy_contract"
+	EventTypePatchContract           = "patch_contract"
+	EventTypeSetContractStorage      = "set_contract_storage"
+
 	AttributeKeyContractAddress = "contract"
 	AttributeKeyRecipient       = "recipient"
+	AttributeKeyHook            = "hook"
+	AttributeKeySuccess         = "success"
+	AttributeKeyGasUsed         = "gas_used"
+	AttributeKeyError           = "error"
+	AttributeKeySalt            = "salt"
+	AttributeKeyCodeHash        = "code_hash"
+	AttributeKeyPrevCodeHash    = "previous_code_hash"
+	AttributeKeyStorageKey      = "key"
+	AttributeKeyPrevValue       = "previous_value"
+	AttributeKeyValue           = "value"
 	AttributeValueCategory      = ModuleName
 )
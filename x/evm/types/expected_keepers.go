@@ -3,14 +3,32 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	authexported "github.com/cosmos/cosmos-sdk/x/auth/exported"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
 // AccountKeeper defines the expected account keeper interface
 type AccountKeeper interface {
 	NewAccountWithAddress(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
+	NewAccount(ctx sdk.Context, acc authexported.Account) authexported.Account
 	GetAllAccounts(ctx sdk.Context) (accounts []authexported.Account)
 	IterateAccounts(ctx sdk.Context, cb func(account authexported.Account) bool)
 	GetAccount(ctx sdk.Context, addr sdk.AccAddress) authexported.Account
 	SetAccount(ctx sdk.Context, account authexported.Account)
 	RemoveAccount(ctx sdk.Context, account authexported.Account)
 }
+
+// SupplyKeeper defines the expected supply keeper interface used to refund the fees
+// of the unused gas
+type SupplyKeeper interface {
+	SendCoinsFromModuleToAccount(ctx sdk.Context, senderModule string, recipientAddr sdk.AccAddress, amt sdk.Coins) error
+}
+
+// EvmHooks event hooks for the EVM transactions
+type EvmHooks interface {
+	// PostTxProcessing is called after a successful EVM state transition. The
+	// recipient is nil for contract creations. Returning an error reverts the
+	// whole transaction.
+	PostTxProcessing(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, receipt *ethtypes.Receipt) error
+}
@@ -3,6 +3,7 @@ package types
 import (
 	"errors"
 	"fmt"
+	"math/big"
 
 	ethermint "github.com/cosmos/ethermint/types"
 
@@ -12,20 +13,24 @@ import (
 type (
 	// GenesisState defines the evm module genesis state
 	GenesisState struct {
-		Accounts    []GenesisAccount  `json:"accounts"`
-		TxsLogs     []TransactionLogs `json:"txs_logs"`
-		ChainConfig ChainConfig       `json:"chain_config"`
-		Params      Params            `json:"params"`
+		Accounts        []GenesisAccount  `json:"accounts"`
+		TxsLogs         []TransactionLogs `json:"txs_logs"`
+		ChainConfig     ChainConfig       `json:"chain_config"`
+		Params          Params            `json:"params"`
+		SystemContracts []SystemContract  `json:"system_contracts"`
 	}
 
 	// GenesisAccount defines an account to be initialized in the genesis state.
 	// Its main difference between with Geth's GenesisAccount is that it uses a custom
 	// storage type and that it doesn't contain the private key field.
 	// NOTE: balance is omitted as it is imported from the auth account balance.
+	// Only the dust of the EVM balance, i.e the remainder that can't be represented
+	// with the EVM denomination decimals, is defined on the genesis account.
 	GenesisAccount struct {
 		Address string  `json:"address"`
 		Code    string  `json:"code,omitempty"`
 		Storage Storage `json:"storage,omitempty"`
+		Dust    string  `json:"dust,omitempty"`
 	}
 )
 
@@ -38,17 +43,37 @@ func (ga GenesisAccount) Validate() error {
 		return errors.New("code cannot be empty")
 	}
 
+	if _, err := ga.GetDust(); err != nil {
+		return err
+	}
+
 	return ga.Storage.Validate()
 }
 
+// GetDust returns the dust of the EVM balance of the genesis account. An empty
+// dust value is parsed to zero.
+func (ga GenesisAccount) GetDust() (*big.Int, error) {
+	if ga.Dust == "" {
+		return new(big.Int), nil
+	}
+
+	dust, ok := new(big.Int).SetString(ga.Dust, 10)
+	if !ok || dust.Sign() < 0 {
+		return nil, fmt.Errorf("invalid dust %s", ga.Dust)
+	}
+
+	return dust, nil
+}
+
 // DefaultGenesisState sets default evm genesis state with empty accounts and default params and
 // chain config values.
 func DefaultGenesisState() GenesisState {
 	return GenesisState{
-		Accounts:    []GenesisAccount{},
-		TxsLogs:     []TransactionLogs{},
-		ChainConfig: DefaultChainConfig(),
-		Params:      DefaultParams(),
+		Accounts:        []GenesisAccount{},
+		TxsLogs:         []TransactionLogs{},
+		ChainConfig:     DefaultChainConfig(),
+		Params:          DefaultParams(),
+		SystemContracts: []SystemContract{},
 	}
 }
 
@@ -64,6 +89,9 @@ func (gs GenesisState) Validate() error {
 		if err := acc.Validate(); err != nil {
 			return fmt.Errorf("invalid genesis account %s: %w", acc.Address, err)
 		}
+		if dust, _ := acc.GetDust(); dust.Cmp(gs.Params.ScaleFactor()) >= 0 {
+			return fmt.Errorf("invalid genesis account %s: dust %s must be lower than %s", acc.Address, dust, gs.Params.ScaleFactor())
+		}
 		seenAccounts[acc.Address] = true
 	}
 
@@ -79,6 +107,20 @@ func (gs GenesisState) Validate() error {
 		seenTxs[tx.Hash] = true
 	}
 
+	seenSystemContracts := make(map[ethcmn.Address]bool)
+	for _, sc := range gs.SystemContracts {
+		if err := sc.Validate(); err != nil {
+			return err
+		}
+
+		address := sc.GetAddress()
+		if seenSystemContracts[address] {
+			return fmt.Errorf("duplicated system contract %s", sc.Address)
+		}
+
+		seenSystemContracts[address] = true
+	}
+
 	if err := gs.ChainConfig.Validate(); err != nil {
 		return err
 	}
@@ -64,6 +64,15 @@ func (suite *GenesisTestSuite) TestValidateGenesisAccount() {
 			},
 			false,
 		},
+		{
+			"invalid dust",
+			GenesisAccount{
+				Address: suite.address.String(),
+				Code:    suite.code,
+				Dust:    "-1",
+			},
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -234,6 +243,21 @@ func (suite *GenesisTestSuite) TestValidateGenesis() {
 			},
 			expPass: false,
 		},
+		{
+			name: "dust greater than scale factor",
+			genState: GenesisState{
+				Accounts: []GenesisAccount{
+					{
+						Address: suite.address.String(),
+						Code:    suite.code,
+						Dust:    "1",
+					},
+				},
+				ChainConfig: DefaultChainConfig(),
+				Params:      DefaultParams(),
+			},
+			expPass: false,
+		},
 		{
 			name: "invalid chain config",
 			genState: GenesisState{
@@ -242,6 +266,40 @@ func (suite *GenesisTestSuite) TestValidateGenesis() {
 			},
 			expPass: false,
 		},
+		{
+			name: "valid system contract",
+			genState: GenesisState{
+				ChainConfig: DefaultChainConfig(),
+				Params:      DefaultParams(),
+				SystemContracts: []SystemContract{
+					NewSystemContract(suite.address, SystemContractHookEndBlock, 10, nil, 100000),
+				},
+			},
+			expPass: true,
+		},
+		{
+			name: "invalid system contract",
+			genState: GenesisState{
+				ChainConfig: DefaultChainConfig(),
+				Params:      DefaultParams(),
+				SystemContracts: []SystemContract{
+					NewSystemContract(suite.address, SystemContractHookEndBlock, 0, nil, 100000),
+				},
+			},
+			expPass: false,
+		},
+		{
+			name: "duplicated system contract",
+			genState: GenesisState{
+				ChainConfig: DefaultChainConfig(),
+				Params:      DefaultParams(),
+				SystemContracts: []SystemContract{
+					NewSystemContract(suite.address, SystemContractHookEndBlock, 10, nil, 100000),
+					NewSystemContract(suite.address, SystemContractHookBeginBlock, 1, nil, 100000),
+				},
+			},
+			expPass: false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -254,3 +312,23 @@ func (suite *GenesisTestSuite) TestValidateGenesis() {
 		}
 	}
 }
+
+func (suite *GenesisTestSuite) TestAddPreinstalls() {
+	genState := DefaultGenesisState()
+	genState.Accounts = append(genState.Accounts, GenesisAccount{Address: suite.address.String(), Code: suite.code})
+
+	suite.Require().NoError(genState.AddPreinstalls(DefaultPreinstalls()...))
+	suite.Require().Len(genState.Accounts, 4)
+	suite.Require().NoError(genState.Validate())
+
+	wtoken := WrappedTokenPreinstall(DefaultWrappedTokenName, DefaultWrappedTokenSymbol)
+	suite.Require().Equal(wtoken.GenesisAccount(), genState.Accounts[3])
+
+	// the preinstalls can't replace an existing account
+	suite.Require().Error(genState.AddPreinstalls(Multicall3Preinstall()))
+	suite.Require().Len(genState.Accounts, 4)
+
+	genState = DefaultGenesisState()
+	suite.Require().Error(genState.AddPreinstalls(Multicall3Preinstall(), Multicall3Preinstall()))
+	suite.Require().Empty(genState.Accounts)
+}
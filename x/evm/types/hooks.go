@@ -0,0 +1,29 @@
+package types
+
+import (
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+)
+
+var _ EvmHooks = MultiEvmHooks{}
+
+// MultiEvmHooks combine multiple EVM hooks, all hook functions are run in array sequence
+type MultiEvmHooks []EvmHooks
+
+// NewMultiEvmHooks combines multiple EVM hooks
+func NewMultiEvmHooks(hooks ...EvmHooks) MultiEvmHooks {
+	return hooks
+}
+
+// PostTxProcessing runs the hooks in sequence and returns the first error, if any.
+func (mh MultiEvmHooks) PostTxProcessing(ctx sdk.Context, from ethcmn.Address, to *ethcmn.Address, receipt *ethtypes.Receipt) error {
+	for i := range mh {
+		if err := mh[i].PostTxProcessing(ctx, from, to, receipt); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
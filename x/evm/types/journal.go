@@ -3,6 +3,8 @@ package types
 import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
+	ethermint "github.com/cosmos/ethermint/types"
+
 	ethcmn "github.com/ethereum/go-ethereum/common"
 )
 
@@ -153,6 +155,17 @@ type (
 		prev    sdk.Int
 	}
 
+	coinBalanceChange struct {
+		account *ethcmn.Address
+		denom   string
+		prev    sdk.Int
+	}
+
+	accountChange struct {
+		account *ethcmn.Address
+		prev    *ethermint.EthAccount
+	}
+
 	nonceChange struct {
 		account *ethcmn.Address
 		prev    uint64
@@ -193,6 +206,11 @@ type (
 		address *ethcmn.Address
 		slot    *ethcmn.Hash
 	}
+
+	// Changes to the SDK state done by stateful precompiles.
+	precompileWriteChange struct {
+		prevLen int
+	}
 )
 
 func (ch createObjectChange) revert(s *CommitStateDB) {
@@ -240,8 +258,7 @@ func (ch suicideChange) revert(s *CommitStateDB) {
 	so := s.getStateObject(*ch.account)
 	if so != nil {
 		so.suicided = ch.prev
-		evmDenom := s.GetParams().EvmDenom
-		so.setBalance(evmDenom, ch.prevBalance)
+		so.setEVMBalance(ch.prevBalance.BigInt())
 	}
 }
 
@@ -257,14 +274,29 @@ func (ch touchChange) dirtied() *ethcmn.Address {
 }
 
 func (ch balanceChange) revert(s *CommitStateDB) {
-	evmDenom := s.GetParams().EvmDenom
-	s.getStateObject(*ch.account).setBalance(evmDenom, ch.prev)
+	s.getStateObject(*ch.account).setEVMBalance(ch.prev.BigInt())
 }
 
 func (ch balanceChange) dirtied() *ethcmn.Address {
 	return ch.account
 }
 
+func (ch coinBalanceChange) revert(s *CommitStateDB) {
+	s.getStateObject(*ch.account).setBalance(ch.denom, ch.prev)
+}
+
+func (ch coinBalanceChange) dirtied() *ethcmn.Address {
+	return ch.account
+}
+
+func (ch accountChange) revert(s *CommitStateDB) {
+	s.getStateObject(*ch.account).account = ch.prev
+}
+
+func (ch accountChange) dirtied() *ethcmn.Address {
+	return ch.account
+}
+
 func (ch nonceChange) revert(s *CommitStateDB) {
 	s.getStateObject(*ch.account).setNonce(ch.prev)
 }
@@ -375,3 +407,11 @@ func (ch accessListAddSlotChange) revert(s *CommitStateDB) {
 func (ch accessListAddSlotChange) dirtied() *ethcmn.Address {
 	return nil
 }
+
+func (ch precompileWriteChange) revert(s *CommitStateDB) {
+	s.precompileWrites = s.precompileWrites[:ch.prevLen]
+}
+
+func (ch precompileWriteChange) dirtied() *ethcmn.Address {
+	return nil
+}
@@ -1,9 +1,12 @@
 package types
 
 import (
+	"encoding/binary"
+
 	sdk "github.com/cosmos/cosmos-sdk/types"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 const (
@@ -17,17 +20,47 @@ const (
 
 	// RouterKey uses module name for routing
 	RouterKey = ModuleName
+
+	// ReserveName is the name of the module account that holds the whole coins of
+	// the EVM denomination backing the dust of the EVM balances.
+	ReserveName = "evm_reserve"
 )
 
+// ModuleAddress is the EVM address of the module, derived from the module name.
+// It's the sender of the system contract calls and the deployer of the contracts
+// deployed through governance.
+var ModuleAddress = ethcmn.BytesToAddress(crypto.Keccak256([]byte(ModuleName))[12:])
+
+// ModuleContractAddress returns the address of a contract deployed by the module
+// through governance. As for CREATE2, it's derived from the module address, the
+// salt and the hash of the contract code.
+func ModuleContractAddress(salt ethcmn.Hash, code []byte) ethcmn.Address {
+	return crypto.CreateAddress2(ModuleAddress, salt, crypto.Keccak256(code))
+}
+
+// ModuleTxHash returns the hash that stores the logs of the EVM executions done by
+// the modules during the transaction with the given hash. It's derived from the
+// module address so that it never matches the hash of an Ethereum transaction.
+func ModuleTxHash(txHash ethcmn.Hash) ethcmn.Hash {
+	return crypto.Keccak256Hash(ModuleAddress.Bytes(), txHash.Bytes())
+}
+
 // KVStore key prefixes
 var (
-	KeyPrefixBlockHash   = []byte{0x01}
-	KeyPrefixBloom       = []byte{0x02}
-	KeyPrefixLogs        = []byte{0x03}
-	KeyPrefixCode        = []byte{0x04}
-	KeyPrefixStorage     = []byte{0x05}
-	KeyPrefixChainConfig = []byte{0x06}
-	KeyPrefixHeightHash  = []byte{0x07}
+	KeyPrefixBlockHash      = []byte{0x01}
+	KeyPrefixBloom          = []byte{0x02}
+	KeyPrefixLogs           = []byte{0x03}
+	KeyPrefixCode           = []byte{0x04}
+	KeyPrefixStorage        = []byte{0x05}
+	KeyPrefixChainConfig    = []byte{0x06}
+	KeyPrefixHeightHash     = []byte{0x07}
+	KeyPrefixDust           = []byte{0x08}
+	KeyPrefixSystemContract = []byte{0x09}
+	KeyPrefixHeightLogs     = []byte{0x0A}
+	KeyTotalDust            = []byte{0x0C}
+	KeyPrefixStorageEpoch   = []byte{0x0D}
+	KeyPrefixStorageQueue   = []byte{0x0E}
+	KeyEVMDenomDecimals     = []byte{0x0F}
 )
 
 // HeightHashKey returns the key for the given chain epoch and height.
@@ -44,7 +77,36 @@ func BloomKey(height int64) []byte {
 	return sdk.Uint64ToBigEndian(uint64(height))
 }
 
+// HeightLogsKey returns the key that indexes the logs of a transaction by the
+// height they were stored at. The key will be composed in the following order:
+//   key = prefix + bytes(height) + tx hash
+// This ordering allows to prune the logs of the expired heights.
+func HeightLogsKey(height uint64, txHash ethcmn.Hash) []byte {
+	return append(sdk.Uint64ToBigEndian(height), txHash.Bytes()...)
+}
+
 // AddressStoragePrefix returns a prefix to iterate over a given account storage.
 func AddressStoragePrefix(address ethcmn.Address) []byte {
 	return append(KeyPrefixStorage, address.Bytes()...)
 }
+
+// EncodeStorageValue returns the stored bytes of a storage slot value written on
+// the given storage epoch of its account. The values of the epoch 0, i.e of the
+// accounts that were never destructed, are stored without the epoch.
+func EncodeStorageValue(epoch uint64, value ethcmn.Hash) []byte {
+	if epoch == 0 {
+		return value.Bytes()
+	}
+
+	return append(sdk.Uint64ToBigEndian(epoch), value.Bytes()...)
+}
+
+// DecodeStorageValue returns the storage epoch on which a stored storage slot value
+// was written and the value.
+func DecodeStorageValue(bz []byte) (uint64, ethcmn.Hash) {
+	if len(bz) != 8+ethcmn.HashLength {
+		return 0, ethcmn.BytesToHash(bz)
+	}
+
+	return binary.BigEndian.Uint64(bz[:8]), ethcmn.BytesToHash(bz[8:])
+}
@@ -8,9 +8,10 @@ import (
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	"github.com/cosmos/cosmos-sdk/x/params"
 
-	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/common"
 
 	ethermint "github.com/cosmos/ethermint/types"
+	"github.com/cosmos/ethermint/x/evm/vm"
 )
 
 const (
@@ -24,8 +25,21 @@ var (
 	ParamStoreKeyEnableCreate = []byte("EnableCreate")
 	ParamStoreKeyEnableCall   = []byte("EnableCall")
 	ParamStoreKeyExtraEIPs    = []byte("EnableExtraEIPs")
+
+	ParamStoreKeyActivePrecompiles = []byte("ActivePrecompiles")
+	ParamStoreKeyDeployerAllowlist = []byte("DeployerAllowlist")
+	ParamStoreKeyContractDenylist  = []byte("ContractDenylist")
+	ParamStoreKeyTransferDenylist  = []byte("TransferDenylist")
+
+	ParamStoreKeyLogRetentionBlocks       = []byte("LogRetentionBlocks")
+	ParamStoreKeyBlockHashRetentionBlocks = []byte("BlockHashRetentionBlocks")
 )
 
+// MinBlockHashRetentionBlocks is the minimum number of block hashes that must be
+// retained when the block hash pruning is enabled, as the BLOCKHASH operation can
+// access the hashes of the last 256 blocks.
+const MinBlockHashRetentionBlocks = 256
+
 // ParamKeyTable returns the parameter key table.
 func ParamKeyTable() params.KeyTable {
 	return params.NewKeyTable().RegisterParamSet(&Params{})
@@ -36,31 +50,67 @@ type Params struct {
 	// EVMDenom defines the token denomination used for state transitions on the
 	// EVM module.
 	EvmDenom string `json:"evm_denom" yaml:"evm_denom"`
+	// EvmDenomDecimals defines the decimals of the EVM denomination. The EVM
+	// balances always have 18 decimals, so the amounts of a denomination with fewer
+	// decimals are scaled up on the EVM. It's only set on genesis, as changing it
+	// would rescale all the EVM balances, so it's stored on the EVM module store
+	// instead of the governable param space.
+	EvmDenomDecimals uint32 `json:"evm_denom_decimals" yaml:"evm_denom_decimals"`
 	// EnableCreate toggles state transitions that use the vm.Create function
 	EnableCreate bool `json:"enable_create" yaml:"enable_create"`
 	// EnableCall toggles state transitions that use the vm.Call function
 	EnableCall bool `json:"enable_call" yaml:"enable_call"`
 	// ExtraEIPs defines the additional EIPs for the vm.Config
 	ExtraEIPs []int64 `json:"extra_eips" yaml:"extra_eips"`
+	// ActivePrecompiles defines the hex addresses of the stateful precompiled
+	// contracts that are enabled on the EVM
+	ActivePrecompiles []string `json:"active_precompiles" yaml:"active_precompiles"`
+	// DeployerAllowlist defines the hex addresses that are allowed to deploy
+	// contracts, including through the CREATE and CREATE2 operations of a contract.
+	// Any address can deploy contracts if the list is empty.
+	DeployerAllowlist []string `json:"deployer_allowlist" yaml:"deployer_allowlist"`
+	// ContractDenylist defines the hex addresses of the contracts that cannot be
+	// called
+	ContractDenylist []string `json:"contract_denylist" yaml:"contract_denylist"`
+	// TransferDenylist defines the hex addresses that cannot receive value
+	// transfers from the EVM, on top of the module accounts blocked by the app
+	TransferDenylist []string `json:"transfer_denylist" yaml:"transfer_denylist"`
+	// LogRetentionBlocks defines the number of blocks for which the transaction
+	// logs and the block blooms are kept on the store. They are never pruned if
+	// the value is 0.
+	LogRetentionBlocks uint64 `json:"log_retention_blocks" yaml:"log_retention_blocks"`
+	// BlockHashRetentionBlocks defines the number of blocks for which the block hash
+	// to height and height to block hash mappings are kept on the store. They are
+	// never pruned if the value is 0.
+	BlockHashRetentionBlocks uint64 `json:"block_hash_retention_blocks" yaml:"block_hash_retention_blocks"`
 }
 
 // NewParams creates a new Params instance
 func NewParams(evmDenom string, enableCreate, enableCall bool, extraEIPs ...int64) Params {
 	return Params{
-		EvmDenom:     evmDenom,
-		EnableCreate: enableCreate,
-		EnableCall:   enableCall,
-		ExtraEIPs:    extraEIPs,
+		EvmDenom:         evmDenom,
+		EvmDenomDecimals: EVMDecimals,
+		EnableCreate:     enableCreate,
+		EnableCall:       enableCall,
+		ExtraEIPs:        extraEIPs,
 	}
 }
 
 // DefaultParams returns default evm parameters
 func DefaultParams() Params {
 	return Params{
-		EvmDenom:     ethermint.AttoPhoton,
-		EnableCreate: true,
-		EnableCall:   true,
-		ExtraEIPs:    []int64(nil), // TODO: define default values
+		EvmDenom:          ethermint.AttoPhoton,
+		EvmDenomDecimals:  EVMDecimals,
+		EnableCreate:      true,
+		EnableCall:        true,
+		ExtraEIPs:         []int64(nil), // TODO: define default values
+		ActivePrecompiles: []string(nil),
+		DeployerAllowlist: []string(nil),
+		ContractDenylist:  []string(nil),
+		TransferDenylist:  []string(nil),
+
+		LogRetentionBlocks:       0,
+		BlockHashRetentionBlocks: 0,
 	}
 }
 
@@ -70,13 +120,20 @@ func (p Params) String() string {
 	return string(out)
 }
 
-// ParamSetPairs returns the parameter set pairs.
+// ParamSetPairs returns the parameter set pairs. The EVM denom decimals aren't part
+// of the param set, so that they can't be changed by a param change proposal.
 func (p *Params) ParamSetPairs() params.ParamSetPairs {
 	return params.ParamSetPairs{
 		params.NewParamSetPair(ParamStoreKeyEVMDenom, &p.EvmDenom, validateEVMDenom),
 		params.NewParamSetPair(ParamStoreKeyEnableCreate, &p.EnableCreate, validateBool),
 		params.NewParamSetPair(ParamStoreKeyEnableCall, &p.EnableCall, validateBool),
 		params.NewParamSetPair(ParamStoreKeyExtraEIPs, &p.ExtraEIPs, validateEIPs),
+		params.NewParamSetPair(ParamStoreKeyActivePrecompiles, &p.ActivePrecompiles, validateActivePrecompiles),
+		params.NewParamSetPair(ParamStoreKeyDeployerAllowlist, &p.DeployerAllowlist, validateDeployerAllowlist),
+		params.NewParamSetPair(ParamStoreKeyContractDenylist, &p.ContractDenylist, validateContractDenylist),
+		params.NewParamSetPair(ParamStoreKeyTransferDenylist, &p.TransferDenylist, validateTransferDenylist),
+		params.NewParamSetPair(ParamStoreKeyLogRetentionBlocks, &p.LogRetentionBlocks, validateLogRetentionBlocks),
+		params.NewParamSetPair(ParamStoreKeyBlockHashRetentionBlocks, &p.BlockHashRetentionBlocks, validateBlockHashRetentionBlocks),
 	}
 }
 
@@ -86,7 +143,56 @@ func (p Params) Validate() error {
 		return err
 	}
 
-	return validateEIPs(p.ExtraEIPs)
+	if err := validateEVMDenomDecimals(p.EvmDenomDecimals); err != nil {
+		return err
+	}
+
+	if err := validateEIPs(p.ExtraEIPs); err != nil {
+		return err
+	}
+
+	if err := validateActivePrecompiles(p.ActivePrecompiles); err != nil {
+		return err
+	}
+
+	if err := validateDeployerAllowlist(p.DeployerAllowlist); err != nil {
+		return err
+	}
+
+	if err := validateContractDenylist(p.ContractDenylist); err != nil {
+		return err
+	}
+
+	if err := validateTransferDenylist(p.TransferDenylist); err != nil {
+		return err
+	}
+
+	return validateBlockHashRetentionBlocks(p.BlockHashRetentionBlocks)
+}
+
+// IsDeployerAllowed returns true if the address is allowed to deploy contracts.
+func (p Params) IsDeployerAllowed(address common.Address) bool {
+	return len(p.DeployerAllowlist) == 0 || containsAddress(p.DeployerAllowlist, address)
+}
+
+// IsContractDenied returns true if the contract address cannot be called.
+func (p Params) IsContractDenied(address common.Address) bool {
+	return containsAddress(p.ContractDenylist, address)
+}
+
+// IsTransferDenied returns true if the address cannot receive EVM value transfers.
+func (p Params) IsTransferDenied(address common.Address) bool {
+	return containsAddress(p.TransferDenylist, address)
+}
+
+func containsAddress(addresses []string, address common.Address) bool {
+	for _, addr := range addresses {
+		if common.HexToAddress(addr) == address {
+			return true
+		}
+	}
+
+	return false
 }
 
 func validateEVMDenom(i interface{}) error {
@@ -98,6 +204,19 @@ func validateEVMDenom(i interface{}) error {
 	return sdk.ValidateDenom(denom)
 }
 
+func validateEVMDenomDecimals(i interface{}) error {
+	decimals, ok := i.(uint32)
+	if !ok {
+		return fmt.Errorf("invalid parameter EVM denom decimals type: %T", i)
+	}
+
+	if decimals > EVMDecimals {
+		return fmt.Errorf("EVM denom decimals cannot be greater than %d, got %d", EVMDecimals, decimals)
+	}
+
+	return nil
+}
+
 func validateBool(i interface{}) error {
 	_, ok := i.(bool)
 	if !ok {
@@ -120,3 +239,80 @@ func validateEIPs(i interface{}) error {
 
 	return nil
 }
+
+func validateActivePrecompiles(i interface{}) error {
+	precompiles, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid active precompiles slice type: %T", i)
+	}
+
+	return validateAddresses("precompile", precompiles)
+}
+
+func validateDeployerAllowlist(i interface{}) error {
+	deployers, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid deployer allowlist slice type: %T", i)
+	}
+
+	return validateAddresses("deployer", deployers)
+}
+
+func validateContractDenylist(i interface{}) error {
+	contracts, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid contract denylist slice type: %T", i)
+	}
+
+	return validateAddresses("contract", contracts)
+}
+
+func validateTransferDenylist(i interface{}) error {
+	addresses, ok := i.([]string)
+	if !ok {
+		return fmt.Errorf("invalid transfer denylist slice type: %T", i)
+	}
+
+	return validateAddresses("transfer", addresses)
+}
+
+func validateLogRetentionBlocks(i interface{}) error {
+	_, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid log retention blocks type: %T", i)
+	}
+
+	return nil
+}
+
+func validateBlockHashRetentionBlocks(i interface{}) error {
+	blocks, ok := i.(uint64)
+	if !ok {
+		return fmt.Errorf("invalid block hash retention blocks type: %T", i)
+	}
+
+	if blocks != 0 && blocks < MinBlockHashRetentionBlocks {
+		return fmt.Errorf("block hash retention blocks must be 0 or at least %d, got %d", MinBlockHashRetentionBlocks, blocks)
+	}
+
+	return nil
+}
+
+// validateAddresses checks that the list contains valid and unique hex addresses.
+func validateAddresses(kind string, addresses []string) error {
+	seen := make(map[common.Address]bool)
+	for _, address := range addresses {
+		if !common.IsHexAddress(address) {
+			return fmt.Errorf("invalid %s address %s", kind, address)
+		}
+
+		addr := common.HexToAddress(address)
+		if seen[addr] {
+			return fmt.Errorf("duplicated %s address %s", kind, address)
+		}
+
+		seen[addr] = true
+	}
+
+	return nil
+}
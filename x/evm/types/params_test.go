@@ -1,8 +1,13 @@
 package types
 
 import (
+	"math/big"
 	"testing"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/ethereum/go-ethereum/common"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -30,6 +35,14 @@ func TestParamsValidate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"invalid evm denom decimals",
+			Params{
+				EvmDenom:         "stake",
+				EvmDenomDecimals: 19,
+			},
+			true,
+		},
 		{
 			"invalid eip",
 			Params{
@@ -38,6 +51,55 @@ func TestParamsValidate(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"invalid precompile address",
+			Params{
+				EvmDenom:          "stake",
+				ActivePrecompiles: []string{"0x1"},
+			},
+			true,
+		},
+		{
+			"duplicated precompile address",
+			Params{
+				EvmDenom:          "stake",
+				ActivePrecompiles: []string{"0x0000000000000000000000000000000000000800", "0x0000000000000000000000000000000000000800"},
+			},
+			true,
+		},
+		{
+			"invalid deployer address",
+			Params{
+				EvmDenom:          "stake",
+				DeployerAllowlist: []string{"0x1"},
+			},
+			true,
+		},
+		{
+			"duplicated denied contract address",
+			Params{
+				EvmDenom:         "stake",
+				ContractDenylist: []string{"0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000001"},
+			},
+			true,
+		},
+		{
+			"block hash retention lower than the BLOCKHASH window",
+			Params{
+				EvmDenom:                 "stake",
+				BlockHashRetentionBlocks: 255,
+			},
+			true,
+		},
+		{
+			"valid retention blocks",
+			Params{
+				EvmDenom:                 "stake",
+				LogRetentionBlocks:       1,
+				BlockHashRetentionBlocks: 256,
+			},
+			false,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -54,12 +116,69 @@ func TestParamsValidate(t *testing.T) {
 func TestParamsValidatePriv(t *testing.T) {
 	require.Error(t, validateEVMDenom(false))
 	require.NoError(t, validateEVMDenom("aphoton"))
+	require.Error(t, validateEVMDenomDecimals(""))
+	require.Error(t, validateEVMDenomDecimals(uint32(19)))
+	require.NoError(t, validateEVMDenomDecimals(uint32(6)))
 	require.Error(t, validateBool(""))
 	require.NoError(t, validateBool(true))
 	require.Error(t, validateEIPs(""))
 	require.NoError(t, validateEIPs([]int64{1884}))
+	require.Error(t, validateActivePrecompiles(""))
+	require.NoError(t, validateActivePrecompiles([]string{"0x0000000000000000000000000000000000000800"}))
+	require.Error(t, validateDeployerAllowlist(""))
+	require.NoError(t, validateDeployerAllowlist([]string{"0x0000000000000000000000000000000000000001"}))
+	require.Error(t, validateContractDenylist(""))
+	require.NoError(t, validateContractDenylist([]string{"0x0000000000000000000000000000000000000001"}))
+	require.Error(t, validateTransferDenylist(""))
+	require.Error(t, validateTransferDenylist([]string{"invalid"}))
+	require.NoError(t, validateTransferDenylist([]string{"0x0000000000000000000000000000000000000001"}))
+	require.Error(t, validateLogRetentionBlocks(int64(1)))
+	require.NoError(t, validateLogRetentionBlocks(uint64(1)))
+	require.Error(t, validateBlockHashRetentionBlocks(int64(256)))
+	require.Error(t, validateBlockHashRetentionBlocks(uint64(1)))
+	require.NoError(t, validateBlockHashRetentionBlocks(uint64(0)))
+	require.NoError(t, validateBlockHashRetentionBlocks(uint64(1000)))
 }
 
 func TestParams_String(t *testing.T) {
-	require.Equal(t, "evm_denom: aphoton\nenable_create: true\nenable_call: true\nextra_eips: []\n", DefaultParams().String())
+	require.Equal(t, "evm_denom: aphoton\nevm_denom_decimals: 18\nenable_create: true\nenable_call: true\nextra_eips: []\nactive_precompiles: []\ndeployer_allowlist: []\ncontract_denylist: []\ntransfer_denylist: []\nlog_retention_blocks: 0\nblock_hash_retention_blocks: 0\n", DefaultParams().String())
+}
+
+func TestParamsDecimalConversion(t *testing.T) {
+	params := DefaultParams()
+	params.EvmDenomDecimals = 6
+
+	require.Equal(t, big.NewInt(1000000000000), params.ScaleFactor())
+	require.Equal(t, big.NewInt(5000000000000), params.ToEVMAmount(sdk.NewInt(5)))
+
+	coins, dust := params.FromEVMAmount(big.NewInt(5000000000001))
+	require.Equal(t, sdk.NewInt(5), coins)
+	require.Equal(t, big.NewInt(1), dust)
+	require.Equal(t, sdk.NewInt(6), params.FromEVMAmountCeil(big.NewInt(5000000000001)))
+	require.Equal(t, sdk.NewInt(5), params.FromEVMAmountCeil(big.NewInt(5000000000000)))
+
+	coins, dust = DefaultParams().FromEVMAmount(big.NewInt(7))
+	require.Equal(t, sdk.NewInt(7), coins)
+	require.Zero(t, dust.Sign())
+}
+
+func TestParamsRestrictions(t *testing.T) {
+	addr := common.HexToAddress("0x0000000000000000000000000000000000000001")
+	other := common.HexToAddress("0x0000000000000000000000000000000000000002")
+
+	params := DefaultParams()
+	require.True(t, params.IsDeployerAllowed(addr))
+	require.False(t, params.IsContractDenied(addr))
+
+	params.DeployerAllowlist = []string{addr.String()}
+	params.ContractDenylist = []string{addr.String()}
+	require.True(t, params.IsDeployerAllowed(addr))
+	require.False(t, params.IsDeployerAllowed(other))
+	require.True(t, params.IsContractDenied(addr))
+	require.False(t, params.IsContractDenied(other))
+
+	require.False(t, params.IsTransferDenied(addr))
+	params.TransferDenylist = []string{addr.String()}
+	require.True(t, params.IsTransferDenied(addr))
+	require.False(t, params.IsTransferDenied(other))
 }
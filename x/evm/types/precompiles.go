@@ -0,0 +1,392 @@
+package types
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethermint "github.com/cosmos/ethermint/types"
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethtypes "github.com/ethereum/go-ethereum/core/types"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// revertSelector is the 4 bytes selector of the Solidity Error(string) revert reason.
+var revertSelector = ethcrypto.Keccak256([]byte("Error(string)"))[:4]
+
+// PrecompiledContract defines a stateful precompiled contract. Contrary to the
+// precompiles defined on Geth, a stateful precompile has access to the SDK context
+// and the EVM state, which allows it to interact with other Cosmos SDK modules.
+type PrecompiledContract interface {
+	// Address returns the fixed address where the precompile is registered.
+	Address() ethcmn.Address
+	// RequiredGas calculates the gas cost of running the precompile for the given
+	// ABI encoded input.
+	RequiredGas(input []byte) uint64
+	// Run executes the precompiled contract for the given ABI encoded input.
+	Run(pc *PrecompileContext, input []byte) ([]byte, error)
+}
+
+// PrecompileContext defines the execution environment of a stateful precompiled
+// contract call.
+type PrecompileContext struct {
+	// Ctx is a branched SDK context. Its writes are only persisted if the call and
+	// all its parent EVM frames succeed.
+	Ctx sdk.Context
+	// StateDB is the EVM state used by the current state transition. Changes done
+	// through it are journaled and reverted with the EVM snapshots.
+	StateDB *CommitStateDB
+	// Caller is the address of the account or contract that called the precompile.
+	Caller ethcmn.Address
+	// Value is the amount of EVM denom tokens transferred to the precompile.
+	Value *big.Int
+	// ReadOnly is true when the precompile has been invoked through a STATICCALL,
+	// DELEGATECALL or CALLCODE operation, or by a CALL within a STATICCALL frame.
+	ReadOnly bool
+}
+
+// FlushAccount writes the live EVM state of the given account to the branched
+// context so that the SDK keepers used by the precompile observe the latest balance
+// and nonce. Accounts that haven't been loaded on the state db are already up to
+// date on the context.
+func (pc *PrecompileContext) FlushAccount(addr ethcmn.Address) {
+	so := pc.StateDB.liveStateObject(addr)
+	if so == nil {
+		return
+	}
+
+	pc.StateDB.accountKeeper.SetAccount(pc.Ctx, so.account)
+}
+
+// ReloadAccount refreshes the EVM state of the given account with the account
+// stored on the branched context. It must be called after an SDK keeper modifies an
+// account, so that the following EVM operations don't read a stale value and the
+// state db doesn't overwrite the keeper changes when it's committed.
+func (pc *PrecompileContext) ReloadAccount(addr ethcmn.Address) {
+	acc := pc.StateDB.accountKeeper.GetAccount(pc.Ctx, sdk.AccAddress(addr.Bytes()))
+	ethAcc, ok := acc.(*ethermint.EthAccount)
+	if !ok {
+		return
+	}
+
+	so := pc.StateDB.liveStateObject(addr)
+	if so == nil {
+		// load the account updated by the precompile as the state db context doesn't
+		// contain the changes until the transition is finalised
+		so = newStateObject(pc.StateDB, ethAcc)
+		pc.StateDB.journal.append(createObjectChange{account: &so.address})
+		pc.StateDB.setStateObject(so)
+		return
+	}
+
+	so.setAccount(ethAcc)
+}
+
+// AddLog emits an EVM log from the precompile address.
+func (pc *PrecompileContext) AddLog(address ethcmn.Address, topics []ethcmn.Hash, data []byte) {
+	pc.StateDB.AddLog(&ethtypes.Log{
+		Address:     address,
+		Topics:      topics,
+		Data:        data,
+		BlockNumber: uint64(pc.Ctx.BlockHeight()),
+	})
+}
+
+// PrecompileRegistry contains the set of stateful precompiled contracts that can
+// be enabled on the EVM through the ActivePrecompiles parameter.
+type PrecompileRegistry struct {
+	contracts map[ethcmn.Address]PrecompiledContract
+}
+
+// NewPrecompileRegistry creates a new empty PrecompileRegistry instance.
+func NewPrecompileRegistry() *PrecompileRegistry {
+	return &PrecompileRegistry{
+		contracts: make(map[ethcmn.Address]PrecompiledContract),
+	}
+}
+
+// Register adds the precompiled contracts to the registry. It returns an error if
+// the address of a contract is already used by another precompile.
+func (pr *PrecompileRegistry) Register(contracts ...PrecompiledContract) error {
+	for _, contract := range contracts {
+		addr := contract.Address()
+		if _, exists := pr.contracts[addr]; exists {
+			return fmt.Errorf("precompiled contract already registered at address %s", addr.String())
+		}
+
+		if _, exists := ethvm.PrecompiledContractsBerlin[addr]; exists {
+			return fmt.Errorf("address %s is reserved for an Ethereum precompiled contract", addr.String())
+		}
+
+		pr.contracts[addr] = contract
+	}
+
+	return nil
+}
+
+// Get returns the precompiled contract registered at the given address.
+func (pr *PrecompileRegistry) Get(addr ethcmn.Address) (PrecompiledContract, bool) {
+	if pr == nil {
+		return nil, false
+	}
+
+	contract, found := pr.contracts[addr]
+	return contract, found
+}
+
+// Addresses returns the sorted list of addresses of the registered precompiles.
+func (pr *PrecompileRegistry) Addresses() []ethcmn.Address {
+	if pr == nil {
+		return nil
+	}
+
+	addrs := make([]ethcmn.Address, 0, len(pr.contracts))
+	for addr := range pr.contracts {
+		addrs = append(addrs, addr)
+	}
+
+	sort.Slice(addrs, func(i, j int) bool {
+		return addrs[i].Hex() < addrs[j].Hex()
+	})
+
+	return addrs
+}
+
+// ----------------------------------------------------------------------------
+// EVM integration
+//
+// The precompiles of Geth are defined on the package level maps of the vm
+// package, which are shared by every EVM instance and whose contracts have no
+// access to the state. The EVM module uses a copy of the vm package instead, on
+// which the precompiles are set per EVM instance. Each state transition creates a
+// precompile session, whose adapters are set on the EVM it executes, so that the
+// EVMs executed concurrently (e.g a query and the block execution) or nested
+// ones never share a session.
+// ----------------------------------------------------------------------------
+
+// precompileAdapter implements the vm.PrecompiledContract interface for a
+// stateful precompile of a session.
+type precompileAdapter struct {
+	session *precompileSession
+	address ethcmn.Address
+}
+
+var _ ethvm.PrecompiledContract = precompileAdapter{}
+
+// RequiredGas implements vm.PrecompiledContract.
+func (pa precompileAdapter) RequiredGas(input []byte) uint64 {
+	return pa.session.requiredGas(pa.address, input)
+}
+
+// Run implements vm.PrecompiledContract.
+func (pa precompileAdapter) Run(input []byte) ([]byte, error) {
+	return pa.session.run(pa.address, input)
+}
+
+// precompileFrame is an EVM call frame of a precompile session.
+type precompileFrame struct {
+	caller ethcmn.Address
+	value  *big.Int
+	// static is true for the STATICCALL frames and all their descendants, on
+	// which the EVM doesn't allow state modifications
+	static bool
+	// readOnly is true if a precompile called by the frame can't modify the state
+	readOnly bool
+}
+
+// precompileSession binds the stateful precompiles to the context and state of a
+// single EVM state transition. It traces the call frames of the EVM so that each
+// precompile call observes its caller, value and read only mode.
+type precompileSession struct {
+	csdb   *CommitStateDB
+	active map[ethcmn.Address]bool
+
+	// frames is the stack of the open EVM call frames
+	frames []precompileFrame
+}
+
+var _ ethvm.EVMLogger = &precompileSession{}
+
+// newPrecompileSession creates a session for the precompiles registered on the
+// state db that are enabled by the given addresses.
+func newPrecompileSession(csdb *CommitStateDB, activePrecompiles []string) *precompileSession {
+	active := make(map[ethcmn.Address]bool, len(activePrecompiles))
+	for _, addr := range activePrecompiles {
+		active[ethcmn.HexToAddress(addr)] = true
+	}
+
+	return &precompileSession{
+		csdb:   csdb,
+		active: active,
+	}
+}
+
+// contracts returns the adapters of the registered and enabled precompiles, which
+// are set on the EVM of the session.
+func (ps *precompileSession) contracts() map[ethcmn.Address]ethvm.PrecompiledContract {
+	addrs := ps.addresses()
+	contracts := make(map[ethcmn.Address]ethvm.PrecompiledContract, len(addrs))
+	for _, addr := range addrs {
+		contracts[addr] = precompileAdapter{session: ps, address: addr}
+	}
+
+	return contracts
+}
+
+// traced returns true if the EVM calls must be traced by the session, i.e if any
+// precompile is registered and enabled.
+func (ps *precompileSession) traced() bool {
+	return len(ps.addresses()) > 0
+}
+
+// addresses returns the addresses of the registered and enabled precompiles.
+func (ps *precompileSession) addresses() []ethcmn.Address {
+	addrs := make([]ethcmn.Address, 0, len(ps.active))
+	for addr := range ps.active {
+		if _, ok := ps.contract(addr); ok {
+			addrs = append(addrs, addr)
+		}
+	}
+
+	return addrs
+}
+
+// contract returns the precompiled contract for the address if it's registered
+// and enabled.
+func (ps *precompileSession) contract(addr ethcmn.Address) (PrecompiledContract, bool) {
+	if !ps.active[addr] {
+		return nil, false
+	}
+
+	return ps.csdb.precompiles.Get(addr)
+}
+
+// CaptureStart implements vm.EVMLogger. It opens the top-level frame.
+func (ps *precompileSession) CaptureStart(_ *ethvm.EVM, from, _ ethcmn.Address, _ bool, _ []byte, _ uint64, value *big.Int) {
+	ps.frames = append(ps.frames[:0], precompileFrame{
+		caller: from,
+		value:  new(big.Int).Set(value),
+	})
+}
+
+// CaptureState implements vm.EVMLogger.
+func (*precompileSession) CaptureState(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, []byte, int, error) {
+}
+
+// CaptureEnter implements vm.EVMLogger. The read only mode is derived from the
+// opcode of the frame and of its parents: the precompiles called by a STATICCALL,
+// DELEGATECALL or CALLCODE operation, or from within a STATICCALL frame, can't
+// modify the state.
+func (ps *precompileSession) CaptureEnter(typ ethvm.OpCode, from, _ ethcmn.Address, _ []byte, _ uint64, value *big.Int) {
+	var parentStatic bool
+	if len(ps.frames) > 0 {
+		parentStatic = ps.frames[len(ps.frames)-1].static
+	}
+
+	frame := precompileFrame{
+		caller: from,
+		value:  new(big.Int),
+		static: parentStatic || typ == ethvm.STATICCALL,
+	}
+
+	if value != nil {
+		frame.value.Set(value)
+	}
+
+	frame.readOnly = frame.static || typ != ethvm.CALL
+	ps.frames = append(ps.frames, frame)
+}
+
+// CaptureExit implements vm.EVMLogger. It closes the innermost frame.
+func (ps *precompileSession) CaptureExit([]byte, uint64, error) {
+	if len(ps.frames) > 0 {
+		ps.frames = ps.frames[:len(ps.frames)-1]
+	}
+}
+
+// CaptureFault implements vm.EVMLogger.
+func (*precompileSession) CaptureFault(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, int, error) {
+}
+
+// CaptureEnd implements vm.EVMLogger.
+func (*precompileSession) CaptureEnd([]byte, uint64, time.Duration, error) {}
+
+// currentFrame returns the frame of the precompile being executed, which is the
+// innermost frame opened by the EVM right before running the precompile.
+func (ps *precompileSession) currentFrame() precompileFrame {
+	if len(ps.frames) == 0 {
+		return precompileFrame{value: new(big.Int), readOnly: true}
+	}
+
+	return ps.frames[len(ps.frames)-1]
+}
+
+func (ps *precompileSession) requiredGas(addr ethcmn.Address, input []byte) uint64 {
+	contract, ok := ps.contract(addr)
+	if !ok {
+		return 0
+	}
+
+	return contract.RequiredGas(input)
+}
+
+func (ps *precompileSession) run(addr ethcmn.Address, input []byte) ([]byte, error) {
+	contract, ok := ps.contract(addr)
+	if !ok {
+		// disabled precompiles behave as an account without code
+		return nil, nil
+	}
+
+	// branch the latest precompile context so that the call only observes the
+	// writes from the previous calls that haven't been reverted
+	cacheCtx, write := ps.csdb.precompileCtx().CacheContext()
+	// the events are emitted once the writes are committed
+	cacheCtx = cacheCtx.WithEventManager(sdk.NewEventManager())
+
+	frame := ps.currentFrame()
+	pc := &PrecompileContext{
+		Ctx:      cacheCtx,
+		StateDB:  ps.csdb,
+		Caller:   frame.caller,
+		Value:    frame.value,
+		ReadOnly: frame.readOnly,
+	}
+
+	ret, err := contract.Run(pc, input)
+	if err != nil {
+		return encodeRevertReason(err), ethvm.ErrExecutionReverted
+	}
+
+	if !pc.ReadOnly {
+		ps.csdb.addPrecompileWrite(cacheCtx, write)
+	}
+
+	return ret, nil
+}
+
+// encodeRevertReason ABI encodes the error as a Solidity Error(string) revert reason.
+func encodeRevertReason(err error) []byte {
+	reason := []byte(err.Error())
+
+	offset := ethcmn.LeftPadBytes(big.NewInt(32).Bytes(), 32)
+	length := ethcmn.LeftPadBytes(big.NewInt(int64(len(reason))).Bytes(), 32)
+	padded := ethcmn.RightPadBytes(reason, (len(reason)+31)/32*32)
+
+	bz := make([]byte, 0, 4+32+32+len(padded))
+	bz = append(bz, revertSelector...)
+	bz = append(bz, offset...)
+	bz = append(bz, length...)
+	return append(bz, padded...)
+}
+
+// precompileWrite is a branched context from a successful stateful precompile call
+// whose writes are pending to be committed.
+type precompileWrite struct {
+	ctx   sdk.Context
+	write func()
+}
@@ -0,0 +1,96 @@
+package types
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+)
+
+// noopPrecompile is a stateful precompile that doesn't do anything.
+type noopPrecompile struct {
+	address ethcmn.Address
+}
+
+func (np noopPrecompile) Address() ethcmn.Address { return np.address }
+
+func (noopPrecompile) RequiredGas([]byte) uint64 { return 10 }
+
+func (noopPrecompile) Run(*PrecompileContext, []byte) ([]byte, error) { return nil, nil }
+
+func TestPrecompileSessionContracts(t *testing.T) {
+	enabled := ethcmn.BytesToAddress([]byte("enabled precompile"))
+	disabled := ethcmn.BytesToAddress([]byte("disabled precompile"))
+
+	registry := NewPrecompileRegistry()
+	require.NoError(t, registry.Register(noopPrecompile{enabled}, noopPrecompile{disabled}))
+
+	// the Ethereum precompiles can't be replaced
+	require.Error(t, registry.Register(noopPrecompile{ethcmn.BytesToAddress([]byte{1})}))
+
+	csdb := &CommitStateDB{precompiles: registry}
+	first := newPrecompileSession(csdb, []string{enabled.String()})
+	second := newPrecompileSession(csdb, []string{enabled.String()})
+
+	// each session has its own adapters, which are only set on its EVM
+	contracts := first.contracts()
+	require.Len(t, contracts, 1)
+	require.Equal(t, precompileAdapter{session: first, address: enabled}, contracts[enabled])
+	require.Equal(t, precompileAdapter{session: second, address: enabled}, second.contracts()[enabled])
+	require.Equal(t, uint64(10), contracts[enabled].RequiredGas(nil))
+
+	// the precompiles of Geth aren't modified
+	for _, precompiles := range []map[ethcmn.Address]ethvm.PrecompiledContract{
+		ethvm.PrecompiledContractsHomestead,
+		ethvm.PrecompiledContractsByzantium,
+		ethvm.PrecompiledContractsIstanbul,
+		ethvm.PrecompiledContractsBerlin,
+	} {
+		require.NotContains(t, precompiles, enabled)
+		require.NotContains(t, precompiles, disabled)
+	}
+}
+
+func TestPrecompileSessionFrames(t *testing.T) {
+	sender := ethcmn.BytesToAddress([]byte("sender"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	precompile := ethcmn.BytesToAddress([]byte("precompile"))
+
+	ps := newPrecompileSession(nil, nil)
+	ps.CaptureStart(nil, sender, precompile, false, nil, 0, big.NewInt(10))
+	require.Equal(t, precompileFrame{caller: sender, value: big.NewInt(10)}, ps.currentFrame())
+
+	testCases := []struct {
+		name        string
+		calls       []ethvm.OpCode
+		expReadOnly bool
+	}{
+		{"call", []ethvm.OpCode{ethvm.CALL}, false},
+		{"staticcall", []ethvm.OpCode{ethvm.STATICCALL}, true},
+		{"delegatecall", []ethvm.OpCode{ethvm.DELEGATECALL}, true},
+		{"callcode", []ethvm.OpCode{ethvm.CALLCODE}, true},
+		{"call from delegatecall", []ethvm.OpCode{ethvm.DELEGATECALL, ethvm.CALL}, false},
+		{"call from staticcall", []ethvm.OpCode{ethvm.STATICCALL, ethvm.CALL}, true},
+		{"call from call from staticcall", []ethvm.OpCode{ethvm.CALL, ethvm.STATICCALL, ethvm.CALL, ethvm.CALL}, true},
+	}
+
+	for _, tc := range testCases {
+		for _, typ := range tc.calls {
+			ps.CaptureEnter(typ, contract, precompile, nil, 0, big.NewInt(0))
+		}
+
+		frame := ps.currentFrame()
+		require.Equal(t, contract, frame.caller, tc.name)
+		require.Equal(t, tc.expReadOnly, frame.readOnly, tc.name)
+
+		for range tc.calls {
+			ps.CaptureExit(nil, 0, nil)
+		}
+
+		require.Len(t, ps.frames, 1, tc.name)
+	}
+}
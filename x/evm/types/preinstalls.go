@@ -0,0 +1,125 @@
+package types
+
+import (
+	"fmt"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Addresses of the well-known contracts that can be preinstalled at genesis. They
+// match the addresses used on Ethereum and most EVM chains, so that tooling which
+// relies on them works without any configuration.
+var (
+	// DeterministicDeployerAddress is the address of the CREATE2 factory used by
+	// Foundry, Hardhat and most deployment tooling
+	DeterministicDeployerAddress = ethcmn.HexToAddress("0x4e59b44847b379578588920cA78FbF26c0B4956C")
+	// Multicall3Address is the address of the Multicall3 contract
+	Multicall3Address = ethcmn.HexToAddress("0xcA11bde05977b3631167028862bE2a173976CA11")
+)
+
+// DeterministicDeployerCode is the canonical runtime code of the deterministic
+// deployment proxy (https://github.com/Arachnid/deterministic-deployment-proxy).
+// The call data is the 32 bytes salt followed by the init code, which is deployed
+// with CREATE2. The call returns the 20 bytes address of the deployed contract and
+// reverts if the deployment fails.
+var DeterministicDeployerCode = ethcmn.FromHex("0x7fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffe03601600081602082378035828234f58015156039578182fd5b8082525050506014600cf3")
+
+// Preinstall defines a contract that is added to the genesis state with its
+// runtime code and storage instead of being deployed by a transaction.
+type Preinstall struct {
+	Name    string
+	Address ethcmn.Address
+	Code    []byte
+	Storage Storage
+}
+
+// GenesisAccount returns the EVM genesis account of the preinstalled contract.
+// NOTE: the matching auth account must be added to the auth genesis state.
+func (p Preinstall) GenesisAccount() GenesisAccount {
+	return GenesisAccount{
+		Address: p.Address.String(),
+		Code:    ethcmn.Bytes2Hex(p.Code),
+		Storage: p.Storage,
+	}
+}
+
+// CodeHash returns the keccak256 hash of the contract code.
+func (p Preinstall) CodeHash() []byte {
+	return ethcrypto.Keccak256(p.Code)
+}
+
+// DeterministicDeployerPreinstall returns the deterministic deployment proxy
+// preinstall.
+func DeterministicDeployerPreinstall() Preinstall {
+	return Preinstall{
+		Name:    "DeterministicDeployer",
+		Address: DeterministicDeployerAddress,
+		Code:    DeterministicDeployerCode,
+	}
+}
+
+// Multicall3Preinstall returns the Multicall3 preinstall.
+func Multicall3Preinstall() Preinstall {
+	return Preinstall{
+		Name:    "Multicall3",
+		Address: Multicall3Address,
+		Code:    Multicall3Code,
+	}
+}
+
+// WrappedTokenAddress returns the address of the preinstalled wrapped native
+// token, which is the address of a governance deployment of its code with an
+// empty salt (see ModuleContractAddress).
+func WrappedTokenAddress() ethcmn.Address {
+	return ModuleContractAddress(ethcmn.Hash{}, WrappedTokenCode)
+}
+
+// WrappedTokenPreinstall returns the preinstall of the WETH9 wrapped native token
+// with the given name and symbol.
+func WrappedTokenPreinstall(name, symbol string) Preinstall {
+	return Preinstall{
+		Name:    "WrappedToken",
+		Address: WrappedTokenAddress(),
+		Code:    WrappedTokenCode,
+		Storage: wrappedTokenStorage(name, symbol),
+	}
+}
+
+// DefaultPreinstalls returns the deterministic deployer, Multicall3 and the
+// wrapped native token with the default name and symbol.
+func DefaultPreinstalls() []Preinstall {
+	return []Preinstall{
+		DeterministicDeployerPreinstall(),
+		Multicall3Preinstall(),
+		WrappedTokenPreinstall(DefaultWrappedTokenName, DefaultWrappedTokenSymbol),
+	}
+}
+
+// AddPreinstalls adds the genesis accounts of the preinstalled contracts to the
+// genesis state. It fails, without modifying the genesis state, if any of the
+// accounts is already defined.
+func (gs *GenesisState) AddPreinstalls(preinstalls ...Preinstall) error {
+	seenAccounts := make(map[string]bool)
+	for _, acc := range gs.Accounts {
+		seenAccounts[ethcmn.HexToAddress(acc.Address).String()] = true
+	}
+
+	accounts := make([]GenesisAccount, len(preinstalls))
+	for i, p := range preinstalls {
+		account := p.GenesisAccount()
+		if err := account.Validate(); err != nil {
+			return fmt.Errorf("invalid %s preinstall: %w", p.Name, err)
+		}
+
+		if seenAccounts[account.Address] {
+			return fmt.Errorf("%s preinstall account %s already exists", p.Name, account.Address)
+		}
+
+		seenAccounts[account.Address] = true
+		accounts[i] = account
+	}
+
+	gs.Accounts = append(gs.Accounts, accounts...)
+	return nil
+}
@@ -0,0 +1,273 @@
+package types_test
+
+import (
+	"math/big"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	"github.com/cosmos/ethermint/x/evm/types"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+type multicall3Call struct {
+	Target       ethcmn.Address
+	AllowFailure bool
+	CallData     []byte
+}
+
+type multicall3Result struct {
+	Success    bool
+	ReturnData []byte
+}
+
+// installPreinstalls sets the code and storage of the default preinstalls on the
+// state, as done by InitGenesis.
+func (suite *StateDBTestSuite) installPreinstalls() {
+	for _, p := range types.DefaultPreinstalls() {
+		suite.stateDB.SetNonce(p.Address, 1)
+		suite.stateDB.SetCode(p.Address, p.Code)
+		for _, state := range p.Storage {
+			suite.stateDB.SetState(p.Address, ethcmn.HexToHash(state.Key), ethcmn.HexToHash(state.Value))
+		}
+	}
+}
+
+// call executes a transaction from the suite address and returns its return data.
+// The state is finalised first, as the state transition reloads the accounts.
+func (suite *StateDBTestSuite) call(recipient ethcmn.Address, amount *big.Int, payload []byte) ([]byte, error) {
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+
+	st := types.StateTransition{
+		AccountNonce: suite.stateDB.GetNonce(suite.address),
+		Price:        big.NewInt(0),
+		GasLimit:     1000000,
+		Recipient:    &recipient,
+		Amount:       amount,
+		Payload:      payload,
+		ChainID:      big.NewInt(1),
+		Csdb:         suite.stateDB,
+		TxHash:       &ethcmn.Hash{},
+		Sender:       suite.address,
+	}
+
+	res, err := st.TransitionDb(suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter()), types.DefaultChainConfig())
+	if err != nil {
+		return nil, err
+	}
+
+	resultData, err := types.DecodeResultData(res.Result.Data)
+	if err != nil {
+		return nil, err
+	}
+
+	return resultData.Ret, nil
+}
+
+func (suite *StateDBTestSuite) TestDeterministicDeployerPreinstall() {
+	suite.installPreinstalls()
+
+	// CODECOPY(0, 12, 1), RETURN(0, 1) followed by the runtime code STOP
+	initCode := ethcmn.Hex2Bytes("6001600c60003960016000f3" + "00")
+	salt := ethcmn.BytesToHash([]byte("salt"))
+
+	ret, err := suite.call(types.DeterministicDeployerAddress, big.NewInt(0), append(salt.Bytes(), initCode...))
+	suite.Require().NoError(err)
+
+	expAddress := ethcrypto.CreateAddress2(types.DeterministicDeployerAddress, salt, ethcrypto.Keccak256(initCode))
+	suite.Require().Equal(expAddress.Bytes(), ret)
+	suite.Require().Equal([]byte{0x00}, suite.stateDB.GetCode(expAddress))
+
+	// the same deployment fails as the address is already used
+	_, err = suite.call(types.DeterministicDeployerAddress, big.NewInt(0), append(salt.Bytes(), initCode...))
+	suite.Require().Error(err)
+}
+
+func (suite *StateDBTestSuite) TestWrappedTokenPreinstall() {
+	suite.installPreinstalls()
+
+	wtoken := types.WrappedTokenAddress()
+	other := ethcmn.BytesToAddress([]byte("other"))
+	suite.stateDB.SetBalance(suite.address, big.NewInt(1000))
+
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := types.WrappedTokenABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		return data
+	}
+
+	unpack := func(method string, ret []byte) interface{} {
+		values, err := types.WrappedTokenABI.Unpack(method, ret)
+		suite.Require().NoError(err)
+		return values[0]
+	}
+
+	ret, err := suite.call(wtoken, big.NewInt(0), pack("name"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.DefaultWrappedTokenName, unpack("name", ret))
+
+	ret, err = suite.call(wtoken, big.NewInt(0), pack("symbol"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(types.DefaultWrappedTokenSymbol, unpack("symbol", ret))
+
+	ret, err = suite.call(wtoken, big.NewInt(0), pack("decimals"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(uint8(18), unpack("decimals", ret))
+
+	// plain value transfers and deposits are minted to the sender
+	_, err = suite.call(wtoken, big.NewInt(100), nil)
+	suite.Require().NoError(err)
+	_, err = suite.call(wtoken, big.NewInt(50), pack("deposit"))
+	suite.Require().NoError(err)
+
+	suite.Require().Equal(ethcmn.BigToHash(big.NewInt(150)), suite.stateDB.GetState(wtoken, types.WrappedTokenBalanceSlot(suite.address)))
+
+	ret, err = suite.call(wtoken, big.NewInt(0), pack("totalSupply"))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(150), unpack("totalSupply", ret))
+
+	// non payable functions reject value transfers
+	_, err = suite.call(wtoken, big.NewInt(1), pack("totalSupply"))
+	suite.Require().Error(err)
+
+	_, err = suite.call(wtoken, big.NewInt(0), pack("transfer", other, big.NewInt(30)))
+	suite.Require().NoError(err)
+
+	ret, err = suite.call(wtoken, big.NewInt(0), pack("balanceOf", other))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(30), unpack("balanceOf", ret))
+
+	_, err = suite.call(wtoken, big.NewInt(0), pack("transfer", other, big.NewInt(1000)))
+	suite.Require().Error(err)
+
+	_, err = suite.call(wtoken, big.NewInt(0), pack("approve", other, big.NewInt(10)))
+	suite.Require().NoError(err)
+
+	ret, err = suite.call(wtoken, big.NewInt(0), pack("allowance", suite.address, other))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(10), unpack("allowance", ret))
+
+	// the allowance isn't required for the own tokens of the sender
+	_, err = suite.call(wtoken, big.NewInt(0), pack("transferFrom", suite.address, other, big.NewInt(20)))
+	suite.Require().NoError(err)
+	_, err = suite.call(wtoken, big.NewInt(0), pack("transferFrom", other, suite.address, big.NewInt(1)))
+	suite.Require().Error(err)
+
+	_, err = suite.call(wtoken, big.NewInt(0), pack("withdraw", big.NewInt(100)))
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(950), suite.stateDB.GetBalance(suite.address))
+	suite.Require().Equal(big.NewInt(50), suite.stateDB.GetBalance(wtoken))
+
+	_, err = suite.call(wtoken, big.NewInt(0), pack("withdraw", big.NewInt(1)))
+	suite.Require().Error(err)
+}
+
+func (suite *StateDBTestSuite) TestWrappedTokenLongName() {
+	name := "Wrapped Photon of the Ethermint test network"
+	p := types.WrappedTokenPreinstall(name, "WPHOTON")
+
+	suite.stateDB.SetCode(p.Address, p.Code)
+	for _, state := range p.Storage {
+		suite.stateDB.SetState(p.Address, ethcmn.HexToHash(state.Key), ethcmn.HexToHash(state.Value))
+	}
+
+	data, err := types.WrappedTokenABI.Pack("name")
+	suite.Require().NoError(err)
+
+	ret, err := suite.call(p.Address, big.NewInt(0), data)
+	suite.Require().NoError(err)
+
+	values, err := types.WrappedTokenABI.Unpack("name", ret)
+	suite.Require().NoError(err)
+	suite.Require().Equal(name, values[0])
+}
+
+func (suite *StateDBTestSuite) TestPreinstallCodeHashes() {
+	// code hashes of the canonical deployments on Ethereum
+	suite.Require().Equal(
+		ethcmn.HexToHash("0x2fa86add0aed31f33a762c9d88e807c475bd51d0f52bd0955754b2608f7e4989"),
+		ethcmn.BytesToHash(types.DeterministicDeployerPreinstall().CodeHash()),
+	)
+	suite.Require().Equal(
+		ethcmn.HexToHash("0xd5c15df687b16f2ff992fc8d767b4216323184a2bbc6ee2f9c398c318e770891"),
+		ethcmn.BytesToHash(types.Multicall3Preinstall().CodeHash()),
+	)
+	suite.Require().Equal(
+		ethcmn.HexToHash("0xd0a06b12ac47863b5c7be4185c2deaad1c61557033f56c7d4ea74429cbb25e23"),
+		ethcmn.BytesToHash(types.WrappedTokenPreinstall(types.DefaultWrappedTokenName, types.DefaultWrappedTokenSymbol).CodeHash()),
+	)
+}
+
+func (suite *StateDBTestSuite) TestMulticall3Preinstall() {
+	suite.installPreinstalls()
+
+	other := ethcmn.BytesToAddress([]byte("other"))
+	suite.stateDB.SetBalance(suite.address, big.NewInt(1000))
+	suite.stateDB.SetBalance(other, big.NewInt(100))
+
+	pack := func(method string, args ...interface{}) []byte {
+		data, err := types.Multicall3ABI.Pack(method, args...)
+		suite.Require().NoError(err)
+		return data
+	}
+
+	calls := []multicall3Call{
+		{types.Multicall3Address, false, pack("getChainId")},
+		{types.Multicall3Address, false, pack("getEthBalance", other)},
+		// the deterministic deployer reverts without salt
+		{types.DeterministicDeployerAddress, true, nil},
+		{types.Multicall3Address, false, pack("getBlockNumber")},
+	}
+
+	ret, err := suite.call(types.Multicall3Address, big.NewInt(0), pack("aggregate3", calls))
+	suite.Require().NoError(err)
+
+	values, err := types.Multicall3ABI.Unpack("aggregate3", ret)
+	suite.Require().NoError(err)
+
+	results := *abi.ConvertType(values[0], new([]multicall3Result)).(*[]multicall3Result)
+	suite.Require().Equal([]multicall3Result{
+		{true, ethcmn.BigToHash(big.NewInt(1)).Bytes()},
+		{true, ethcmn.BigToHash(big.NewInt(100)).Bytes()},
+		{false, []byte{}},
+		{true, ethcmn.BigToHash(big.NewInt(suite.ctx.BlockHeight())).Bytes()},
+	}, results)
+
+	// the failures must be allowed
+	calls[2].AllowFailure = false
+	_, err = suite.call(types.Multicall3Address, big.NewInt(0), pack("aggregate3", calls))
+	suite.Require().Error(err)
+
+	type call struct {
+		Target   ethcmn.Address
+		CallData []byte
+	}
+
+	aggregateCalls := []call{
+		{types.Multicall3Address, pack("getEthBalance", other)},
+		{types.Multicall3Address, pack("getBlockNumber")},
+	}
+
+	ret, err = suite.call(types.Multicall3Address, big.NewInt(0), pack("aggregate", aggregateCalls))
+	suite.Require().NoError(err)
+
+	values, err = types.Multicall3ABI.Unpack("aggregate", ret)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(suite.ctx.BlockHeight()), values[0])
+	suite.Require().Equal([][]byte{
+		ethcmn.BigToHash(big.NewInt(100)).Bytes(),
+		ethcmn.BigToHash(big.NewInt(suite.ctx.BlockHeight())).Bytes(),
+	}, values[1])
+
+	ret, err = suite.call(types.Multicall3Address, big.NewInt(0), pack("tryBlockAndAggregate", false, aggregateCalls))
+	suite.Require().NoError(err)
+
+	values, err = types.Multicall3ABI.Unpack("tryBlockAndAggregate", ret)
+	suite.Require().NoError(err)
+	suite.Require().Equal(big.NewInt(suite.ctx.BlockHeight()), values[0])
+	results = *abi.ConvertType(values[2], new([]multicall3Result)).(*[]multicall3Result)
+	suite.Require().Len(results, 2)
+	suite.Require().True(results[1].Success)
+}
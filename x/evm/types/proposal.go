@@ -0,0 +1,399 @@
+package types
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	govtypes "github.com/cosmos/cosmos-sdk/x/gov/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+const (
+	// ProposalTypeChainConfigUpgrade defines the type for a ChainConfigUpgradeProposal
+	ProposalTypeChainConfigUpgrade = "ChainConfigUpgrade"
+	// ProposalTypeRegisterSystemContract defines the type for a RegisterSystemContractProposal
+	ProposalTypeRegisterSystemContract = "RegisterSystemContract"
+	// ProposalTypeRemoveSystemContract defines the type for a RemoveSystemContractProposal
+	ProposalTypeRemoveSystemContract = "RemoveSystemContract"
+	// ProposalTypeDeployContract defines the type for a DeployContractProposal
+	ProposalTypeDeployContract = "DeployContract"
+	// ProposalTypePatchContract defines the type for a PatchContractProposal
+	ProposalTypePatchContract = "PatchContract"
+)
+
+// Assert the proposals implement govtypes.Content at compile-time
+var (
+	_ govtypes.Content = ChainConfigUpgradeProposal{}
+	_ govtypes.Content = RegisterSystemContractProposal{}
+	_ govtypes.Content = RemoveSystemContractProposal{}
+	_ govtypes.Content = DeployContractProposal{}
+	_ govtypes.Content = PatchContractProposal{}
+)
+
+func init() {
+	govtypes.RegisterProposalType(ProposalTypeChainConfigUpgrade)
+	govtypes.RegisterProposalType(ProposalTypeRegisterSystemContract)
+	govtypes.RegisterProposalType(ProposalTypeRemoveSystemContract)
+	govtypes.RegisterProposalType(ProposalTypeDeployContract)
+	govtypes.RegisterProposalType(ProposalTypePatchContract)
+	govtypes.RegisterProposalTypeCodec(ChainConfigUpgradeProposal{}, "ethermint/ChainConfigUpgradeProposal")
+	govtypes.RegisterProposalTypeCodec(RegisterSystemContractProposal{}, "ethermint/RegisterSystemContractProposal")
+	govtypes.RegisterProposalTypeCodec(RemoveSystemContractProposal{}, "ethermint/RemoveSystemContractProposal")
+	govtypes.RegisterProposalTypeCodec(DeployContractProposal{}, "ethermint/DeployContractProposal")
+	govtypes.RegisterProposalTypeCodec(PatchContractProposal{}, "ethermint/PatchContractProposal")
+}
+
+// ChainConfigUpgradeProposal replaces the EVM chain configuration in order to
+// schedule the activation of new hard forks at future block heights. The forks
+// that are already active cannot be modified.
+type ChainConfigUpgradeProposal struct {
+	Title       string      `json:"title" yaml:"title"`
+	Description string      `json:"description" yaml:"description"`
+	ChainConfig ChainConfig `json:"chain_config" yaml:"chain_config"`
+}
+
+// NewChainConfigUpgradeProposal creates a new ChainConfigUpgradeProposal instance.
+func NewChainConfigUpgradeProposal(title, description string, config ChainConfig) ChainConfigUpgradeProposal {
+	return ChainConfigUpgradeProposal{
+		Title:       title,
+		Description: description,
+		ChainConfig: config,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (ccup ChainConfigUpgradeProposal) GetTitle() string { return ccup.Title }
+
+// GetDescription returns the description of the proposal.
+func (ccup ChainConfigUpgradeProposal) GetDescription() string { return ccup.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (ccup ChainConfigUpgradeProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (ccup ChainConfigUpgradeProposal) ProposalType() string { return ProposalTypeChainConfigUpgrade }
+
+// ValidateBasic runs basic stateless validity checks
+func (ccup ChainConfigUpgradeProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(ccup); err != nil {
+		return err
+	}
+
+	if err := ccup.ChainConfig.Validate(); err != nil {
+		return err
+	}
+
+	return ccup.ChainConfig.ValidateForkOrder()
+}
+
+// String implements the Stringer interface.
+func (ccup ChainConfigUpgradeProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Chain Config Upgrade Proposal:
+  Title:        %s
+  Description:  %s
+  Chain Config:
+`, ccup.Title, ccup.Description))
+
+	for _, line := range strings.Split(strings.TrimSpace(ccup.ChainConfig.String()), "\n") {
+		b.WriteString(fmt.Sprintf("    %s\n", line))
+	}
+
+	return b.String()
+}
+
+// RegisterSystemContractProposal registers a contract that is called by the EVM
+// module on every block, or every given number of blocks, from BeginBlock or
+// EndBlock. Registering an already registered contract replaces its settings.
+type RegisterSystemContractProposal struct {
+	Title          string         `json:"title" yaml:"title"`
+	Description    string         `json:"description" yaml:"description"`
+	SystemContract SystemContract `json:"system_contract" yaml:"system_contract"`
+}
+
+// NewRegisterSystemContractProposal creates a new RegisterSystemContractProposal instance.
+func NewRegisterSystemContractProposal(title, description string, sc SystemContract) RegisterSystemContractProposal {
+	return RegisterSystemContractProposal{
+		Title:          title,
+		Description:    description,
+		SystemContract: sc,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (rscp RegisterSystemContractProposal) GetTitle() string { return rscp.Title }
+
+// GetDescription returns the description of the proposal.
+func (rscp RegisterSystemContractProposal) GetDescription() string { return rscp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (rscp RegisterSystemContractProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (rscp RegisterSystemContractProposal) ProposalType() string {
+	return ProposalTypeRegisterSystemContract
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (rscp RegisterSystemContractProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(rscp); err != nil {
+		return err
+	}
+
+	return rscp.SystemContract.Validate()
+}
+
+// String implements the Stringer interface.
+func (rscp RegisterSystemContractProposal) String() string {
+	var b strings.Builder
+	b.WriteString(fmt.Sprintf(`Register System Contract Proposal:
+  Title:           %s
+  Description:     %s
+  System Contract:
+`, rscp.Title, rscp.Description))
+
+	for _, line := range strings.Split(strings.TrimSpace(rscp.SystemContract.String()), "\n") {
+		b.WriteString(fmt.Sprintf("    %s\n", line))
+	}
+
+	return b.String()
+}
+
+// RemoveSystemContractProposal removes a registered system contract, so that it
+// is no longer called by the EVM module.
+type RemoveSystemContractProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	// Address is the hex address of the system contract
+	Address string `json:"address" yaml:"address"`
+}
+
+// NewRemoveSystemContractProposal creates a new RemoveSystemContractProposal instance.
+func NewRemoveSystemContractProposal(title, description string, address ethcmn.Address) RemoveSystemContractProposal {
+	return RemoveSystemContractProposal{
+		Title:       title,
+		Description: description,
+		Address:     address.String(),
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (rscp RemoveSystemContractProposal) GetTitle() string { return rscp.Title }
+
+// GetDescription returns the description of the proposal.
+func (rscp RemoveSystemContractProposal) GetDescription() string { return rscp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (rscp RemoveSystemContractProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (rscp RemoveSystemContractProposal) ProposalType() string {
+	return ProposalTypeRemoveSystemContract
+}
+
+// ValidateBasic runs basic stateless validity checks
+func (rscp RemoveSystemContractProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(rscp); err != nil {
+		return err
+	}
+
+	if !ethcmn.IsHexAddress(rscp.Address) {
+		return fmt.Errorf("invalid system contract address %q", rscp.Address)
+	}
+
+	return nil
+}
+
+// String implements the Stringer interface.
+func (rscp RemoveSystemContractProposal) String() string {
+	return fmt.Sprintf(`Remove System Contract Proposal:
+  Title:       %s
+  Description: %s
+  Address:     %s
+`, rscp.Title, rscp.Description, rscp.Address)
+}
+
+// DeployContractProposal deploys the given runtime code, and optionally its initial
+// storage, from the EVM module address. The constructor isn't executed. As for
+// CREATE2, the contract address is derived from the module address, the salt and
+// the code hash, so that it is known before the proposal passes.
+type DeployContractProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	// Salt is the hex encoded 32 bytes salt of the contract address
+	Salt string `json:"salt" yaml:"salt"`
+	// Code is the 0x prefixed hex encoded runtime code of the contract
+	Code    string  `json:"code" yaml:"code"`
+	Storage Storage `json:"storage,omitempty" yaml:"storage,omitempty"`
+}
+
+// NewDeployContractProposal creates a new DeployContractProposal instance.
+func NewDeployContractProposal(title, description string, salt ethcmn.Hash, code []byte, storage Storage) DeployContractProposal {
+	return DeployContractProposal{
+		Title:       title,
+		Description: description,
+		Salt:        salt.String(),
+		Code:        hexutil.Encode(code),
+		Storage:     storage,
+	}
+}
+
+// GetTitle returns the title of the proposal.
+func (dcp DeployContractProposal) GetTitle() string { return dcp.Title }
+
+// GetDescription returns the description of the proposal.
+func (dcp DeployContractProposal) GetDescription() string { return dcp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (dcp DeployContractProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (dcp DeployContractProposal) ProposalType() string { return ProposalTypeDeployContract }
+
+// ValidateBasic runs basic stateless validity checks
+func (dcp DeployContractProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(dcp); err != nil {
+		return err
+	}
+
+	salt, err := hexutil.Decode(dcp.Salt)
+	if err != nil || len(salt) != ethcmn.HashLength {
+		return fmt.Errorf("invalid salt %q, expected a 0x prefixed 32 bytes hex string", dcp.Salt)
+	}
+
+	if _, err := decodeContractCode(dcp.Code); err != nil {
+		return err
+	}
+
+	return dcp.Storage.Validate()
+}
+
+// GetCode returns the decoded runtime code of the contract.
+func (dcp DeployContractProposal) GetCode() []byte {
+	return hexutil.MustDecode(dcp.Code)
+}
+
+// ContractAddress returns the address of the deployed contract.
+func (dcp DeployContractProposal) ContractAddress() ethcmn.Address {
+	return ModuleContractAddress(ethcmn.HexToHash(dcp.Salt), dcp.GetCode())
+}
+
+// String implements the Stringer interface.
+func (dcp DeployContractProposal) String() string {
+	return fmt.Sprintf(`Deploy Contract Proposal:
+  Title:         %s
+  Description:   %s
+  Salt:          %s
+  Code:          %s
+  Storage Slots: %d
+`, dcp.Title, dcp.Description, dcp.Salt, dcp.Code, len(dcp.Storage))
+}
+
+// PatchContractProposal replaces the code and/or sets the storage slots of an
+// existing contract. It allows to fix a contract without a coordinated genesis
+// export and import.
+type PatchContractProposal struct {
+	Title       string `json:"title" yaml:"title"`
+	Description string `json:"description" yaml:"description"`
+	// Address is the hex address of the patched contract
+	Address string `json:"address" yaml:"address"`
+	// Code is the 0x prefixed hex encoded runtime code that replaces the current
+	// one. The code isn't modified if it's empty.
+	Code    string  `json:"code,omitempty" yaml:"code,omitempty"`
+	Storage Storage `json:"storage,omitempty" yaml:"storage,omitempty"`
+}
+
+// NewPatchContractProposal creates a new PatchContractProposal instance.
+func NewPatchContractProposal(title, description string, address ethcmn.Address, code []byte, storage Storage) PatchContractProposal {
+	pcp := PatchContractProposal{
+		Title:       title,
+		Description: description,
+		Address:     address.String(),
+		Storage:     storage,
+	}
+
+	if len(code) != 0 {
+		pcp.Code = hexutil.Encode(code)
+	}
+
+	return pcp
+}
+
+// GetTitle returns the title of the proposal.
+func (pcp PatchContractProposal) GetTitle() string { return pcp.Title }
+
+// GetDescription returns the description of the proposal.
+func (pcp PatchContractProposal) GetDescription() string { return pcp.Description }
+
+// ProposalRoute returns the routing key of the proposal.
+func (pcp PatchContractProposal) ProposalRoute() string { return RouterKey }
+
+// ProposalType returns the type of the proposal.
+func (pcp PatchContractProposal) ProposalType() string { return ProposalTypePatchContract }
+
+// ValidateBasic runs basic stateless validity checks
+func (pcp PatchContractProposal) ValidateBasic() error {
+	if err := govtypes.ValidateAbstract(pcp); err != nil {
+		return err
+	}
+
+	if !ethcmn.IsHexAddress(pcp.Address) {
+		return fmt.Errorf("invalid contract address %q", pcp.Address)
+	}
+
+	if pcp.Code == "" && len(pcp.Storage) == 0 {
+		return errors.New("the patch must replace the code or set storage slots")
+	}
+
+	if pcp.Code != "" {
+		if _, err := decodeContractCode(pcp.Code); err != nil {
+			return err
+		}
+	}
+
+	return pcp.Storage.Validate()
+}
+
+// GetCode returns the decoded runtime code of the patch, which is nil if the code
+// isn't modified.
+func (pcp PatchContractProposal) GetCode() []byte {
+	if pcp.Code == "" {
+		return nil
+	}
+
+	return hexutil.MustDecode(pcp.Code)
+}
+
+// String implements the Stringer interface.
+func (pcp PatchContractProposal) String() string {
+	return fmt.Sprintf(`Patch Contract Proposal:
+  Title:         %s
+  Description:   %s
+  Address:       %s
+  Code:          %s
+  Storage Slots: %d
+`, pcp.Title, pcp.Description, pcp.Address, pcp.Code, len(pcp.Storage))
+}
+
+// decodeContractCode decodes the hex encoded runtime code of a contract, which
+// can't be empty nor exceed the EIP-170 size limit.
+func decodeContractCode(code string) ([]byte, error) {
+	bz, err := hexutil.Decode(code)
+	if err != nil {
+		return nil, fmt.Errorf("invalid contract code: %w", err)
+	}
+
+	if len(bz) == 0 {
+		return nil, errors.New("contract code cannot be empty")
+	}
+
+	if len(bz) > params.MaxCodeSize {
+		return nil, fmt.Errorf("contract code size %d exceeds the limit %d", len(bz), params.MaxCodeSize)
+	}
+
+	return bz, nil
+}
@@ -0,0 +1,198 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/ethereum/go-ethereum/params"
+)
+
+func TestChainConfigUpgradeProposalValidateBasic(t *testing.T) {
+	invalidOrder := DefaultChainConfig()
+	invalidOrder.IstanbulBlock = sdk.NewInt(-1)
+
+	testCases := []struct {
+		name     string
+		proposal ChainConfigUpgradeProposal
+		expError bool
+	}{
+		{"valid", NewChainConfigUpgradeProposal("title", "description", DefaultChainConfig()), false},
+		{"empty title", NewChainConfigUpgradeProposal("", "description", DefaultChainConfig()), true},
+		{"empty description", NewChainConfigUpgradeProposal("title", "", DefaultChainConfig()), true},
+		{"invalid chain config", NewChainConfigUpgradeProposal("title", "description", ChainConfig{}), true},
+		{"invalid fork order", NewChainConfigUpgradeProposal("title", "description", invalidOrder), true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestChainConfigUpgradeProposal(t *testing.T) {
+	proposal := NewChainConfigUpgradeProposal("title", "description", DefaultChainConfig())
+	require.Equal(t, "title", proposal.GetTitle())
+	require.Equal(t, "description", proposal.GetDescription())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, ProposalTypeChainConfigUpgrade, proposal.ProposalType())
+	require.Contains(t, proposal.String(), "yoloV2_block: \"-1\"")
+}
+
+func TestRegisterSystemContractProposalValidateBasic(t *testing.T) {
+	address := ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
+
+	testCases := []struct {
+		name     string
+		proposal RegisterSystemContractProposal
+		expError bool
+	}{
+		{
+			"valid",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(address, SystemContractHookBeginBlock, 1, []byte{0x01}, 100000)),
+			false,
+		},
+		{
+			"empty title",
+			NewRegisterSystemContractProposal("", "description", NewSystemContract(address, SystemContractHookBeginBlock, 1, nil, 100000)),
+			true,
+		},
+		{
+			"zero address",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(ethcmn.Address{}, SystemContractHookBeginBlock, 1, nil, 100000)),
+			true,
+		},
+		{
+			"invalid hook",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(address, "mid_block", 1, nil, 100000)),
+			true,
+		},
+		{
+			"zero interval",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(address, SystemContractHookEndBlock, 0, nil, 100000)),
+			true,
+		},
+		{
+			"zero gas limit",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(address, SystemContractHookEndBlock, 1, nil, 0)),
+			true,
+		},
+		{
+			"gas limit above maximum",
+			NewRegisterSystemContractProposal("title", "description", NewSystemContract(address, SystemContractHookEndBlock, 1, nil, MaxSystemContractGasLimit+1)),
+			true,
+		},
+		{
+			"invalid input",
+			NewRegisterSystemContractProposal("title", "description", SystemContract{
+				Address: address.String(), Hook: SystemContractHookEndBlock, Interval: 1, Input: "0xzz", GasLimit: 100000,
+			}),
+			true,
+		},
+	}
+
+	for _, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestRemoveSystemContractProposal(t *testing.T) {
+	address := ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
+
+	proposal := NewRemoveSystemContractProposal("title", "description", address)
+	require.NoError(t, proposal.ValidateBasic())
+	require.Equal(t, RouterKey, proposal.ProposalRoute())
+	require.Equal(t, ProposalTypeRemoveSystemContract, proposal.ProposalType())
+	require.Contains(t, proposal.String(), address.String())
+
+	proposal.Address = "invalid"
+	require.Error(t, proposal.ValidateBasic())
+}
+
+func TestSystemContractIsDue(t *testing.T) {
+	sc := NewSystemContract(ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0"), SystemContractHookEndBlock, 5, nil, 100000)
+
+	require.False(t, sc.IsDue(SystemContractHookEndBlock, 0))
+	require.False(t, sc.IsDue(SystemContractHookEndBlock, 4))
+	require.True(t, sc.IsDue(SystemContractHookEndBlock, 5))
+	require.True(t, sc.IsDue(SystemContractHookEndBlock, 10))
+	require.False(t, sc.IsDue(SystemContractHookBeginBlock, 10))
+}
+
+func TestDeployContractProposalValidateBasic(t *testing.T) {
+	salt := ethcmn.BytesToHash([]byte{1})
+	storage := Storage{NewState(ethcmn.BytesToHash([]byte{1}), ethcmn.BytesToHash([]byte{2}))}
+
+	testCases := []struct {
+		name     string
+		proposal DeployContractProposal
+		expError bool
+	}{
+		{"valid", NewDeployContractProposal("title", "description", salt, []byte{0x00}, storage), false},
+		{"empty description", NewDeployContractProposal("title", "", salt, []byte{0x00}, nil), true},
+		{"empty code", NewDeployContractProposal("title", "description", salt, nil, nil), true},
+		{"code too large", NewDeployContractProposal("title", "description", salt, make([]byte, params.MaxCodeSize+1), nil), true},
+		{"invalid salt", DeployContractProposal{Title: "title", Description: "description", Salt: "0x01", Code: "0x00"}, true},
+		{"invalid code", DeployContractProposal{Title: "title", Description: "description", Salt: salt.String(), Code: "00"}, true},
+		{"duplicated storage key", NewDeployContractProposal("title", "description", salt, []byte{0x00}, append(storage, storage...)), true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
+
+func TestDeployContractProposalContractAddress(t *testing.T) {
+	salt := ethcmn.BytesToHash([]byte{1})
+	code := []byte{0x00}
+
+	proposal := NewDeployContractProposal("title", "description", salt, code, nil)
+	require.Equal(t, crypto.CreateAddress2(ModuleAddress, salt, crypto.Keccak256(code)), proposal.ContractAddress())
+	require.Equal(t, code, proposal.GetCode())
+	require.Equal(t, ProposalTypeDeployContract, proposal.ProposalType())
+}
+
+func TestPatchContractProposalValidateBasic(t *testing.T) {
+	address := ethcmn.HexToAddress("0x756F45E3FA69347A9A973A725E3C98bC4db0b5a0")
+	storage := Storage{NewState(ethcmn.BytesToHash([]byte{1}), ethcmn.Hash{})}
+
+	testCases := []struct {
+		name     string
+		proposal PatchContractProposal
+		expError bool
+	}{
+		{"valid code and storage", NewPatchContractProposal("title", "description", address, []byte{0x00}, storage), false},
+		{"valid code", NewPatchContractProposal("title", "description", address, []byte{0x00}, nil), false},
+		{"valid storage", NewPatchContractProposal("title", "description", address, nil, storage), false},
+		{"empty patch", NewPatchContractProposal("title", "description", address, nil, nil), true},
+		{"invalid address", PatchContractProposal{Title: "title", Description: "description", Address: "0x01", Code: "0x00"}, true},
+		{"invalid storage key", NewPatchContractProposal("title", "description", address, nil, Storage{{Value: "0x01"}}), true},
+	}
+
+	for _, tc := range testCases {
+		err := tc.proposal.ValidateBasic()
+		if tc.expError {
+			require.Error(t, err, tc.name)
+		} else {
+			require.NoError(t, err, tc.name)
+		}
+	}
+}
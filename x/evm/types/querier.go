@@ -3,6 +3,9 @@ package types
 import (
 	"fmt"
 
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
 )
 
@@ -11,13 +14,21 @@ const (
 	QueryBalance         = "balance"
 	QueryBlockNumber     = "blockNumber"
 	QueryStorage         = "storage"
+	QueryStorageRange    = "storageRange"
 	QueryCode            = "code"
 	QueryNonce           = "nonce"
 	QueryHashToHeight    = "hashToHeight"
 	QueryTransactionLogs = "transactionLogs"
+	QueryBlockLogs       = "blockLogs"
 	QueryBloom           = "bloom"
 	QueryLogs            = "logs"
 	QueryAccount         = "account"
+	QueryCall            = "call"
+	QueryEstimateGas     = "estimateGas"
+	QueryCallBundle      = "callBundle"
+	QueryTraceTx         = "traceTransaction"
+	QueryTraceBlock      = "traceBlock"
+	QueryTraceFilter     = "traceFilter"
 )
 
 // QueryResBalance is response type for balance query
@@ -47,6 +58,31 @@ func (q QueryResStorage) String() string {
 	return string(q.Value)
 }
 
+// MaxStorageRangeLimit is the maximum number of storage slots returned by a
+// storage range query.
+const MaxStorageRangeLimit = 1024
+
+// QueryResStorageRange is response type for the storage range query. NextKey is
+// the key of the first slot of the next range, or empty if the range reaches the
+// end of the account storage.
+type QueryResStorageRange struct {
+	Storage Storage `json:"storage"`
+	NextKey string  `json:"next_key"`
+}
+
+func (q QueryResStorageRange) String() string {
+	var storageStr string
+	for _, state := range q.Storage {
+		storageStr = fmt.Sprintf("%s%s: %s\n", storageStr, state.Key, state.Value)
+	}
+
+	if q.NextKey != "" {
+		storageStr = fmt.Sprintf("%snext key: %s\n", storageStr, q.NextKey)
+	}
+
+	return storageStr
+}
+
 // QueryResCode is response type for code query
 type QueryResCode struct {
 	Code []byte
@@ -65,9 +101,15 @@ func (q QueryResNonce) String() string {
 	return fmt.Sprint(q.Nonce)
 }
 
-// QueryETHLogs is response type for tx logs query
+// MaxLogsPageLimit is the maximum number of entries returned by a page of the
+// transaction and block logs queries.
+const MaxLogsPageLimit = 1024
+
+// QueryETHLogs is response type for tx logs query. Total is the number of logs
+// of the transaction when a page is requested.
 type QueryETHLogs struct {
-	Logs []*ethtypes.Log `json:"logs"`
+	Logs  []*ethtypes.Log `json:"logs"`
+	Total int             `json:"total,omitempty"`
 }
 
 func (q QueryETHLogs) String() string {
@@ -80,6 +122,22 @@ func (q QueryETHLogs) String() string {
 	return logsStr
 }
 
+// QueryResBlockLogs is response type for the block logs query. Total is the
+// number of transactions with logs at the queried height.
+type QueryResBlockLogs struct {
+	TxLogs []TransactionLogs `json:"tx_logs"`
+	Total  int               `json:"total"`
+}
+
+func (q QueryResBlockLogs) String() string {
+	var logsStr string
+	for _, txLogs := range q.TxLogs {
+		logsStr = fmt.Sprintf("%s%s: %d logs\n", logsStr, txLogs.Hash, len(txLogs.Logs))
+	}
+
+	return fmt.Sprintf("%sTotal: %d", logsStr, q.Total)
+}
+
 // QueryBloomFilter is response type for tx logs query
 type QueryBloomFilter struct {
 	Bloom ethtypes.Bloom `json:"bloom"`
@@ -97,3 +155,118 @@ type QueryResAccount struct {
 }
 
 type QueryResExportAccount = GenesisAccount
+
+// QueryCallParams defines a message call of the call, estimate gas and call bundle
+// queries. The params and the responses of these queries are encoded with
+// encoding/json, as amino doesn't support the JSON encoding of the Ethereum types.
+// The sender nonce is read from the state, and checked against the given nonce if
+// any. A zero gas limit defaults to the RPC gas limit, and nil gas price and value
+// default to zero.
+type QueryCallParams struct {
+	From     ethcmn.Address  `json:"from"`
+	To       *ethcmn.Address `json:"to"` // nil for contract creation
+	Nonce    *uint64         `json:"nonce,omitempty"`
+	Gas      uint64          `json:"gas"`
+	GasPrice sdk.Int         `json:"gas_price"`
+	Value    sdk.Int         `json:"value"`
+	Data     []byte          `json:"data"`
+}
+
+// QueryResCall is response type for the call query
+type QueryResCall struct {
+	Result   ResultData `json:"result"`
+	GasUsed  uint64     `json:"gas_used"`
+	GasLimit uint64     `json:"gas_limit"`
+}
+
+func (q QueryResCall) String() string {
+	return fmt.Sprintf("%s\nGasUsed: %d\nGasLimit: %d", q.Result, q.GasUsed, q.GasLimit)
+}
+
+// QueryResEstimateGas is response type for the estimate gas query
+type QueryResEstimateGas struct {
+	Gas uint64 `json:"gas"`
+}
+
+func (q QueryResEstimateGas) String() string {
+	return fmt.Sprint(q.Gas)
+}
+
+// MaxCallBundleSize is the maximum number of message calls of a call bundle query.
+const MaxCallBundleSize = 100
+
+// MaxCallBundleGas is the total gas available to the message calls of a call
+// bundle query. The gas limit of each call is capped to the gas left by the
+// previous ones.
+const MaxCallBundleGas uint64 = 100000000
+
+// QueryCallBundleParams defines the ordered message calls of the call bundle
+// query. Each call is executed on the state changes of the previous ones.
+type QueryCallBundleParams struct {
+	Calls []QueryCallParams `json:"calls"`
+}
+
+// CallResult is the result of a message call of a call bundle. The error and the
+// revert data are set if the call failed, in which case its state changes are
+// discarded.
+type CallResult struct {
+	Ret             []byte          `json:"ret"`
+	ContractAddress *ethcmn.Address `json:"contract_address,omitempty"`
+	Logs            []*ethtypes.Log `json:"logs"`
+	GasUsed         uint64          `json:"gas_used"`
+	Error           string          `json:"error,omitempty"`
+	Revert          []byte          `json:"revert,omitempty"`
+}
+
+// QueryResCallBundle is response type for the call bundle query
+type QueryResCallBundle struct {
+	Results []CallResult `json:"results"`
+}
+
+func (q QueryResCallBundle) String() string {
+	var resultsStr string
+	for i, res := range q.Results {
+		resultsStr = fmt.Sprintf("%s%d: %+v\n", resultsStr, i, res)
+	}
+
+	return resultsStr
+}
+
+// QueryResTraceTx is response type for the trace transaction query. The trace is
+// nil if the transaction hasn't been traced.
+type QueryResTraceTx struct {
+	Trace *TxTrace `json:"trace"`
+}
+
+func (q QueryResTraceTx) String() string {
+	return fmt.Sprintf("%+v", q.Trace)
+}
+
+// QueryResTraceBlock is response type for the trace block query
+type QueryResTraceBlock struct {
+	Traces []TxTrace `json:"traces"`
+}
+
+func (q QueryResTraceBlock) String() string {
+	var tracesStr string
+	for _, trace := range q.Traces {
+		tracesStr = fmt.Sprintf("%s%+v\n", tracesStr, trace)
+	}
+
+	return tracesStr
+}
+
+// QueryResTraceFilter is response type for the trace filter query, which takes a
+// TraceFilter as params.
+type QueryResTraceFilter struct {
+	Frames []FilteredFrame `json:"frames"`
+}
+
+func (q QueryResTraceFilter) String() string {
+	var framesStr string
+	for _, frame := range q.Frames {
+		framesStr = fmt.Sprintf("%s%+v\n", framesStr, frame)
+	}
+
+	return framesStr
+}
@@ -22,5 +22,5 @@ func TestQueryETHLogs_String(t *testing.T) {
 		},
 	}
 
-	require.True(t, strings.EqualFold(expectedQueryETHLogsStr, QueryETHLogs{logs}.String()))
+	require.True(t, strings.EqualFold(expectedQueryETHLogsStr, QueryETHLogs{Logs: logs}.String()))
 }
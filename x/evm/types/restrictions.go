@@ -0,0 +1,133 @@
+package types
+
+import (
+	"math/big"
+	"time"
+
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+)
+
+// restrictionViolation is the panic value used by the restrictionTracer and the
+// transferGuard to abort the EVM execution.
+type restrictionViolation struct {
+	err error
+}
+
+// restrictionTracer enforces the DeployerAllowlist and ContractDenylist parameters
+// on the contract creations and calls done by contracts.
+//
+// Geth doesn't allow to fail a single call frame from outside the interpreter, so
+// the tracer aborts the whole EVM execution when it finds a restricted operation,
+// in the same way the SDK gas meter aborts the transaction execution when it runs
+// out of gas. Hence a restricted nested call fails the whole transaction, which
+// consumes its whole gas limit, instead of only failing its call frame.
+type restrictionTracer struct {
+	params Params
+	// origin is the account that signed the transaction
+	origin ethcmn.Address
+}
+
+var _ ethvm.EVMLogger = &restrictionTracer{}
+
+// newRestrictionTracer returns a tracer for the parameters restrictions or nil if
+// there aren't any.
+func newRestrictionTracer(params Params) *restrictionTracer {
+	if len(params.DeployerAllowlist) == 0 && len(params.ContractDenylist) == 0 {
+		return nil
+	}
+
+	return &restrictionTracer{
+		params: params,
+	}
+}
+
+// execute runs the EVM execution. If the execution is aborted by the tracer or by
+// a blocked value transfer, the state changes are reverted and the restriction
+// error is returned. The tracer may be nil.
+func (rt *restrictionTracer) execute(csdb *CommitStateDB, fn func() error) (err error) {
+	snapshot := csdb.Snapshot()
+
+	defer func() {
+		if r := recover(); r != nil {
+			violation, ok := r.(restrictionViolation)
+			if !ok {
+				panic(r)
+			}
+
+			csdb.RevertToSnapshot(snapshot)
+			err = violation.err
+		}
+	}()
+
+	return fn()
+}
+
+// transferGuard wraps the CommitStateDB used by the EVM to abort the executions
+// that send value to an address blocked by the app, i.e the module accounts, or
+// part of the TransferDenylist parameter. All the balance increases of the EVM go
+// through AddBalance, which includes the value of the calls and contract creations
+// as well as the balance sent to the SELFDESTRUCT beneficiary.
+type transferGuard struct {
+	*CommitStateDB
+	params Params
+}
+
+var _ ethvm.StateDB = transferGuard{}
+
+// AddBalance implements vm.StateDB. It aborts the EVM execution if a non zero
+// amount is sent to a blocked address.
+func (tg transferGuard) AddBalance(addr ethcmn.Address, amount *big.Int) {
+	if amount.Sign() > 0 && (tg.IsBlockedAddr(addr) || tg.params.IsTransferDenied(addr)) {
+		panic(restrictionViolation{
+			err: sdkerrors.Wrapf(ErrBlockedAddress, "address %s", addr.String()),
+		})
+	}
+
+	tg.CommitStateDB.AddBalance(addr, amount)
+}
+
+// CaptureStart implements vm.EVMLogger.
+func (rt *restrictionTracer) CaptureStart(env *ethvm.EVM, _, _ ethcmn.Address, _ bool, _ []byte, _ uint64, _ *big.Int) {
+	rt.origin = env.TxContext.Origin
+}
+
+// CaptureState implements vm.EVMLogger.
+func (*restrictionTracer) CaptureState(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, []byte, int, error) {
+}
+
+// CaptureEnter implements vm.EVMLogger. It is called when a contract creates or
+// calls another contract, before the new frame is executed.
+func (rt *restrictionTracer) CaptureEnter(typ ethvm.OpCode, from, to ethcmn.Address, _ []byte, _ uint64, _ *big.Int) {
+	switch typ {
+	case ethvm.CREATE, ethvm.CREATE2:
+		// the deployment is allowed for the contract that executes the operation or
+		// for the account that signed the transaction
+		if !rt.params.IsDeployerAllowed(from) && !rt.params.IsDeployerAllowed(rt.origin) {
+			panic(restrictionViolation{
+				err: sdkerrors.Wrapf(ErrDeployerNotAllowed, "contract %s, origin %s", from.String(), rt.origin.String()),
+			})
+		}
+	case ethvm.CALL, ethvm.CALLCODE, ethvm.DELEGATECALL, ethvm.STATICCALL:
+		if rt.params.IsContractDenied(to) {
+			panic(restrictionViolation{
+				err: sdkerrors.Wrapf(ErrContractDenied, "contract %s called by %s", to.String(), from.String()),
+			})
+		}
+	}
+}
+
+// CaptureExit implements vm.EVMLogger.
+func (*restrictionTracer) CaptureExit([]byte, uint64, error) {
+}
+
+// CaptureFault implements vm.EVMLogger.
+func (*restrictionTracer) CaptureFault(uint64, ethvm.OpCode, uint64, uint64, *ethvm.ScopeContext, int, error) {
+}
+
+// CaptureEnd implements vm.EVMLogger.
+func (*restrictionTracer) CaptureEnd([]byte, uint64, time.Duration, error) {
+}
@@ -0,0 +1,54 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/ethereum/go-ethereum/common"
+)
+
+func TestNewRestrictionTracer(t *testing.T) {
+	address := common.BytesToAddress([]byte("address")).String()
+
+	testCases := []struct {
+		name     string
+		malleate func(params *Params)
+		expNil   bool
+	}{
+		{"default params", func(*Params) {}, true},
+		{
+			"transfer denylist only",
+			func(params *Params) {
+				params.TransferDenylist = []string{address}
+			},
+			true,
+		},
+		{
+			"deployer allowlist",
+			func(params *Params) {
+				params.DeployerAllowlist = []string{address}
+			},
+			false,
+		},
+		{
+			"contract denylist",
+			func(params *Params) {
+				params.ContractDenylist = []string{address}
+			},
+			false,
+		},
+	}
+
+	for _, tc := range testCases {
+		params := DefaultParams()
+		tc.malleate(&params)
+
+		tracer := newRestrictionTracer(params)
+		if tc.expNil {
+			require.Nil(t, tracer, tc.name)
+		} else {
+			require.NotNil(t, tracer, tc.name)
+		}
+	}
+}
@@ -0,0 +1,74 @@
+package types
+
+import (
+	"bytes"
+	"fmt"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+)
+
+// RevertError is the error of an EVM execution reverted by the REVERT opcode. It
+// contains the revert data returned by the execution, which is the ABI encoded
+// reason of the Solidity revert and require statements.
+type RevertError struct {
+	data   []byte
+	reason string
+}
+
+// NewRevertError creates a new RevertError with the given revert data.
+func NewRevertError(data []byte) *RevertError {
+	return &RevertError{
+		data:   data,
+		reason: UnpackRevertReason(data),
+	}
+}
+
+// Error implements the error interface. The reason is included when the revert
+// data is a Solidity Error(string).
+func (e *RevertError) Error() string {
+	if e.reason == "" {
+		return ethvm.ErrExecutionReverted.Error()
+	}
+
+	return fmt.Sprintf("%s: %s", ethvm.ErrExecutionReverted, e.reason)
+}
+
+// Unwrap returns the EVM revert error, so that errors.Is matches the RevertError
+// with vm.ErrExecutionReverted.
+func (e *RevertError) Unwrap() error {
+	return ethvm.ErrExecutionReverted
+}
+
+// Data returns the revert data returned by the execution.
+func (e *RevertError) Data() []byte {
+	return e.data
+}
+
+// Reason returns the revert reason, or an empty string if the revert data isn't
+// a Solidity Error(string).
+func (e *RevertError) Reason() string {
+	return e.reason
+}
+
+// UnpackRevertReason returns the reason of a Solidity Error(string) revert data,
+// or an empty string if the data isn't a valid Error(string).
+func UnpackRevertReason(data []byte) string {
+	if len(data) < 4 || !bytes.Equal(data[:4], revertSelector) {
+		return ""
+	}
+
+	typ, err := abi.NewType("string", "", nil)
+	if err != nil {
+		return ""
+	}
+
+	values, err := abi.Arguments{{Type: typ}}.Unpack(data[4:])
+	if err != nil || len(values) != 1 {
+		return ""
+	}
+
+	reason, _ := values[0].(string)
+	return reason
+}
@@ -0,0 +1,27 @@
+package types
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
+)
+
+func TestRevertError(t *testing.T) {
+	data := encodeRevertReason(errors.New("insufficient allowance"))
+
+	err := NewRevertError(data)
+	require.True(t, errors.Is(err, ethvm.ErrExecutionReverted))
+	require.Equal(t, "execution reverted: insufficient allowance", err.Error())
+	require.Equal(t, "insufficient allowance", err.Reason())
+	require.Equal(t, data, err.Data())
+
+	// the revert data isn't a Solidity Error(string)
+	for _, data := range [][]byte{nil, {0x1, 0x2, 0x3, 0x4}, data[:40]} {
+		err = NewRevertError(data)
+		require.Equal(t, ethvm.ErrExecutionReverted.Error(), err.Error())
+		require.Empty(t, err.Reason())
+	}
+}
@@ -0,0 +1,327 @@
+package types
+
+import (
+	"sync"
+
+	"github.com/go-kit/kit/metrics"
+	"github.com/go-kit/kit/metrics/discard"
+	"github.com/go-kit/kit/metrics/prometheus"
+	lru "github.com/hashicorp/golang-lru/simplelru"
+	stdprometheus "github.com/prometheus/client_golang/prometheus"
+
+	stypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// DefaultCodeCacheSize is the default number of contract codes kept in the
+	// StateCache.
+	DefaultCodeCacheSize = 1024
+
+	// DefaultStorageCacheSize is the default number of storage slots kept in the
+	// StateCache.
+	DefaultStorageCacheSize = 65536
+
+	cacheLabel = "cache"
+)
+
+// StateCacheMetrics contains the hit and miss counters of the StateCache, labeled
+// by cache ("code" or "storage").
+type StateCacheMetrics struct {
+	Hits   metrics.Counter
+	Misses metrics.Counter
+}
+
+// PrometheusStateCacheMetrics returns the StateCache metrics registered on the
+// default Prometheus registry, which is served by the Tendermint instrumentation
+// endpoint. It must only be called once per process.
+func PrometheusStateCacheMetrics(namespace string) *StateCacheMetrics {
+	return &StateCacheMetrics{
+		Hits: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: ModuleName,
+			Name:      "state_cache_hits",
+			Help:      "Number of contract code and storage reads served by the state cache.",
+		}, []string{cacheLabel}),
+		Misses: prometheus.NewCounterFrom(stdprometheus.CounterOpts{
+			Namespace: namespace,
+			Subsystem: ModuleName,
+			Name:      "state_cache_misses",
+			Help:      "Number of contract code and storage reads not served by the state cache.",
+		}, []string{cacheLabel}),
+	}
+}
+
+// NopStateCacheMetrics returns StateCache metrics that are discarded.
+func NopStateCacheMetrics() *StateCacheMetrics {
+	return &StateCacheMetrics{
+		Hits:   discard.NewCounter(),
+		Misses: discard.NewCounter(),
+	}
+}
+
+// storageCacheKey is the key of a storage slot in the StateCache. The slot is
+// the prefixed key under which the value is stored.
+type storageCacheKey struct {
+	address ethcmn.Address
+	slot    ethcmn.Hash
+}
+
+// StateCache is a node-local read cache of the contract codes, keyed by code hash,
+// and of the storage slots, keyed by address and slot. It's shared by all the
+// CommitStateDBs created by the Keeper, so that the popular contracts aren't read
+// from the store on every call.
+//
+// The cache is consensus-safe:
+//   - a cached read consumes the same gas as the store read it replaces;
+//   - the codes are immutable for a given hash;
+//   - the storage slots written since the beginning of the block (including the
+//     writes of reverted transactions) aren't cached until the next block, as
+//     the written values are only committed with the block. The cached values
+//     are therefore the ones of the last committed state. They are only used by
+//     the contexts of the block execution, as the check and query contexts can
+//     read a different version of the store.
+type StateCache struct {
+	mtx sync.Mutex
+
+	code    *lru.LRU // code hash -> code
+	storage *lru.LRU // storageCacheKey -> raw value
+
+	// cached storage slots of each account, so that the storage of an account is
+	// invalidated without iterating the whole cache
+	accountSlots map[ethcmn.Address]map[ethcmn.Hash]struct{}
+
+	// slots and accounts written since the beginning of the block
+	dirtySlots    map[storageCacheKey]struct{}
+	dirtyAccounts map[ethcmn.Address]struct{}
+
+	metrics *StateCacheMetrics
+}
+
+// NewStateCache creates a new StateCache with the given number of entries. A zero
+// size disables the corresponding cache.
+func NewStateCache(codeSize, storageSize int) *StateCache {
+	cache := &StateCache{
+		dirtySlots:    make(map[storageCacheKey]struct{}),
+		dirtyAccounts: make(map[ethcmn.Address]struct{}),
+		metrics:       NopStateCacheMetrics(),
+	}
+
+	cache.Resize(codeSize, storageSize)
+	return cache
+}
+
+// Resize replaces the caches with empty ones of the given number of entries. A
+// zero size disables the corresponding cache.
+func (c *StateCache) Resize(codeSize, storageSize int) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.code = newLRU(codeSize, nil)
+	c.storage = newLRU(storageSize, c.onStorageEvict)
+	c.accountSlots = make(map[ethcmn.Address]map[ethcmn.Hash]struct{})
+}
+
+// onStorageEvict removes an evicted or removed storage slot from the slots of its
+// account. It's called by the storage LRU, with the mutex held.
+func (c *StateCache) onStorageEvict(key, _ interface{}) {
+	storageKey := key.(storageCacheKey)
+
+	slots := c.accountSlots[storageKey.address]
+	delete(slots, storageKey.slot)
+	if len(slots) == 0 {
+		delete(c.accountSlots, storageKey.address)
+	}
+}
+
+// SetMetrics sets the metrics updated by the cache reads.
+func (c *StateCache) SetMetrics(metrics *StateCacheMetrics) {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.metrics = metrics
+}
+
+// NewBlock must be called at the beginning of each block, once the state of the
+// previous one is committed. It allows caching again the storage slots written in
+// the previous block.
+func (c *StateCache) NewBlock() {
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.dirtySlots = make(map[storageCacheKey]struct{})
+	c.dirtyAccounts = make(map[ethcmn.Address]struct{})
+}
+
+// InvalidateAccountStorage removes the storage slots of an account from the cache
+// until the next block. It must be called when the storage of an account is
+// deleted.
+func (c *StateCache) InvalidateAccountStorage(address ethcmn.Address) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	c.dirtyAccounts[address] = struct{}{}
+	if c.storage == nil {
+		return
+	}
+
+	// the removals delete the slots from the account slots
+	for slot := range c.accountSlots[address] {
+		c.storage.Remove(storageCacheKey{address: address, slot: slot})
+	}
+}
+
+// getCode returns the cached code of the given hash, consuming the gas of the
+// store read.
+func (c *StateCache) getCode(ctx sdk.Context, codeHash []byte) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.code == nil {
+		return nil, false
+	}
+
+	value, ok := c.code.Get(ethcmn.BytesToHash(codeHash))
+	if !ok {
+		c.metrics.Misses.With(cacheLabel, "code").Add(1)
+		return nil, false
+	}
+
+	c.metrics.Hits.With(cacheLabel, "code").Add(1)
+
+	code := value.([]byte)
+	consumeReadGas(ctx, code)
+	return code, true
+}
+
+// setCode caches the code of the given hash.
+func (c *StateCache) setCode(codeHash []byte, code []byte) {
+	if c == nil || len(code) == 0 {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	if c.code != nil {
+		c.code.Add(ethcmn.BytesToHash(codeHash), code)
+	}
+}
+
+// getStorage returns the cached raw value of a storage slot, consuming the gas
+// of the store read. The cache isn't used for the slots written in the current
+// block and outside of the block execution.
+func (c *StateCache) getStorage(ctx sdk.Context, address ethcmn.Address, slot ethcmn.Hash) ([]byte, bool) {
+	if c == nil {
+		return nil, false
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := storageCacheKey{address: address, slot: slot}
+	if !c.storageCacheable(ctx, key) {
+		return nil, false
+	}
+
+	value, ok := c.storage.Get(key)
+	if !ok {
+		c.metrics.Misses.With(cacheLabel, "storage").Add(1)
+		return nil, false
+	}
+
+	c.metrics.Hits.With(cacheLabel, "storage").Add(1)
+
+	rawValue := value.([]byte)
+	consumeReadGas(ctx, rawValue)
+	return rawValue, true
+}
+
+// setStorage caches the raw value of a storage slot read from the store.
+func (c *StateCache) setStorage(ctx sdk.Context, address ethcmn.Address, slot ethcmn.Hash, rawValue []byte) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := storageCacheKey{address: address, slot: slot}
+	if !c.storageCacheable(ctx, key) {
+		return
+	}
+
+	c.storage.Add(key, rawValue)
+
+	slots, ok := c.accountSlots[address]
+	if !ok {
+		slots = make(map[ethcmn.Hash]struct{})
+		c.accountSlots[address] = slots
+	}
+	slots[slot] = struct{}{}
+}
+
+// invalidateStorage removes a storage slot from the cache until the next block.
+// It must be called when the slot is written.
+func (c *StateCache) invalidateStorage(address ethcmn.Address, slot ethcmn.Hash) {
+	if c == nil {
+		return
+	}
+
+	c.mtx.Lock()
+	defer c.mtx.Unlock()
+
+	key := storageCacheKey{address: address, slot: slot}
+	c.dirtySlots[key] = struct{}{}
+	if c.storage != nil {
+		c.storage.Remove(key)
+	}
+}
+
+// storageCacheable returns true if the value of a storage slot read on the given
+// context is the one of the last committed state, i.e the context is the one of
+// the block execution and the slot hasn't been written in the current block. The
+// mutex must be held.
+func (c *StateCache) storageCacheable(ctx sdk.Context, key storageCacheKey) bool {
+	if c.storage == nil || ctx.IsCheckTx() {
+		return false
+	}
+
+	if _, dirty := c.dirtyAccounts[key.address]; dirty {
+		return false
+	}
+
+	_, dirty := c.dirtySlots[key]
+	return !dirty
+}
+
+// newLRU returns a new LRU cache of the given size, or nil if the size isn't
+// positive. The onEvict callback, if any, is called for the evicted and removed
+// entries.
+func newLRU(size int, onEvict lru.EvictCallback) *lru.LRU {
+	if size <= 0 {
+		return nil
+	}
+
+	// the error is only returned for non-positive sizes
+	cache, _ := lru.NewLRU(size, onEvict)
+	return cache
+}
+
+// consumeReadGas consumes the gas of a KVStore read of the given value, so that
+// the cached reads consume the same gas as the store ones.
+func consumeReadGas(ctx sdk.Context, value []byte) {
+	gasConfig := stypes.KVGasConfig()
+	ctx.GasMeter().ConsumeGas(gasConfig.ReadCostFlat, stypes.GasReadCostFlatDesc)
+	ctx.GasMeter().ConsumeGas(gasConfig.ReadCostPerByte*stypes.Gas(len(value)), stypes.GasReadPerByteDesc)
+}
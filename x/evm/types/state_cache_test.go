@@ -0,0 +1,145 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/tendermint/tendermint/abci/types"
+	tmlog "github.com/tendermint/tendermint/libs/log"
+
+	stypes "github.com/cosmos/cosmos-sdk/store/types"
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func newStateCacheContext(checkTx bool) sdk.Context {
+	return sdk.NewContext(nil, abci.Header{Height: 1}, checkTx, tmlog.NewNopLogger()).
+		WithGasMeter(sdk.NewInfiniteGasMeter())
+}
+
+func TestStateCache_Code(t *testing.T) {
+	cache := NewStateCache(1, 1)
+	codeHash := ethcmn.BytesToHash([]byte("hash")).Bytes()
+	code := []byte("code")
+
+	ctx := newStateCacheContext(false)
+	_, ok := cache.getCode(ctx, codeHash)
+	require.False(t, ok)
+
+	// the codes are cached for the check contexts too, as they don't change
+	cache.setCode(codeHash, code)
+	ctx = newStateCacheContext(true)
+	cached, ok := cache.getCode(ctx, codeHash)
+	require.True(t, ok)
+	require.Equal(t, code, cached)
+
+	// the read consumes the gas of the store read
+	gasConfig := stypes.KVGasConfig()
+	require.Equal(t, gasConfig.ReadCostFlat+gasConfig.ReadCostPerByte*uint64(len(code)), ctx.GasMeter().GasConsumed())
+
+	// the least recently used entry is evicted
+	cache.setCode(ethcmn.BytesToHash([]byte("hash2")).Bytes(), code)
+	_, ok = cache.getCode(ctx, codeHash)
+	require.False(t, ok)
+
+	// disabled and nil caches
+	cache.Resize(0, 0)
+	cache.setCode(codeHash, code)
+	_, ok = cache.getCode(ctx, codeHash)
+	require.False(t, ok)
+
+	var nilCache *StateCache
+	nilCache.setCode(codeHash, code)
+	_, ok = nilCache.getCode(ctx, codeHash)
+	require.False(t, ok)
+}
+
+func TestStateCache_Storage(t *testing.T) {
+	cache := NewStateCache(10, 10)
+	addr := ethcmn.BytesToAddress([]byte("addr"))
+	slot := ethcmn.BytesToHash([]byte("slot"))
+	slot2 := ethcmn.BytesToHash([]byte("slot2"))
+	value := ethcmn.BytesToHash([]byte("value")).Bytes()
+
+	ctx := newStateCacheContext(false)
+	cache.setStorage(ctx, addr, slot, value)
+	cached, ok := cache.getStorage(ctx, addr, slot)
+	require.True(t, ok)
+	require.Equal(t, value, cached)
+
+	gasConfig := stypes.KVGasConfig()
+	require.Equal(t, gasConfig.ReadCostFlat+gasConfig.ReadCostPerByte*uint64(len(value)), ctx.GasMeter().GasConsumed())
+
+	// the check and query contexts don't use the cache
+	checkCtx := newStateCacheContext(true)
+	_, ok = cache.getStorage(checkCtx, addr, slot)
+	require.False(t, ok)
+	cache.setStorage(checkCtx, addr, slot2, value)
+	_, ok = cache.getStorage(ctx, addr, slot2)
+	require.False(t, ok)
+
+	// the written slots aren't cached until the next block
+	cache.invalidateStorage(addr, slot)
+	_, ok = cache.getStorage(ctx, addr, slot)
+	require.False(t, ok)
+	cache.setStorage(ctx, addr, slot, nil)
+	_, ok = cache.getStorage(ctx, addr, slot)
+	require.False(t, ok)
+
+	cache.NewBlock()
+	cache.setStorage(ctx, addr, slot, nil)
+	cached, ok = cache.getStorage(ctx, addr, slot)
+	require.True(t, ok)
+	require.Nil(t, cached)
+
+	// the deleted storage of an account isn't cached until the next block
+	other := ethcmn.BytesToAddress([]byte("other"))
+	cache.setStorage(ctx, addr, slot2, value)
+	cache.setStorage(ctx, other, slot, value)
+	cache.InvalidateAccountStorage(addr)
+
+	_, ok = cache.getStorage(ctx, addr, slot)
+	require.False(t, ok)
+	_, ok = cache.getStorage(ctx, addr, slot2)
+	require.False(t, ok)
+	_, ok = cache.getStorage(ctx, other, slot)
+	require.True(t, ok)
+
+	cache.setStorage(ctx, addr, slot, value)
+	_, ok = cache.getStorage(ctx, addr, slot)
+	require.False(t, ok)
+}
+
+func TestStateCache_InvalidateAccountStorage(t *testing.T) {
+	cache := NewStateCache(10, 2)
+	addr := ethcmn.BytesToAddress([]byte("addr"))
+	other := ethcmn.BytesToAddress([]byte("other"))
+	slot := ethcmn.BytesToHash([]byte("slot"))
+	slot2 := ethcmn.BytesToHash([]byte("slot2"))
+	value := ethcmn.BytesToHash([]byte("value")).Bytes()
+
+	ctx := newStateCacheContext(false)
+	cache.setStorage(ctx, addr, slot, value)
+	cache.setStorage(ctx, other, slot, value)
+	require.Len(t, cache.accountSlots, 2)
+
+	// the evicted slots are removed from the slots of their account
+	cache.setStorage(ctx, other, slot2, value)
+	require.NotContains(t, cache.accountSlots, addr)
+	require.Len(t, cache.accountSlots[other], 2)
+
+	// only the slots of the invalidated account are removed
+	cache.Resize(10, 10)
+	cache.setStorage(ctx, addr, slot, value)
+	cache.setStorage(ctx, other, slot, value)
+	cache.InvalidateAccountStorage(addr)
+
+	_, ok := cache.getStorage(ctx, addr, slot)
+	require.False(t, ok)
+	_, ok = cache.getStorage(ctx, other, slot)
+	require.True(t, ok)
+	require.NotContains(t, cache.accountSlots, addr)
+	require.Equal(t, 1, cache.storage.Len())
+}
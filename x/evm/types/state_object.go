@@ -65,6 +65,12 @@ type stateObject struct {
 	dbErr   error
 	stateDB *CommitStateDB
 	account *ethermint.EthAccount
+	// dust is the remainder of the EVM balance that can't be represented with the
+	// decimals of the EVM denomination
+	dust *big.Int
+	// epoch is the storage epoch of the account, i.e the number of times it was
+	// destructed. The storage slots written on a previous epoch are ignored.
+	epoch uint64
 
 	keyToOriginStorageIndex map[ethcmn.Hash]int
 	keyToDirtyStorageIndex  map[ethcmn.Hash]int
@@ -96,6 +102,8 @@ func newStateObject(db *CommitStateDB, accProto authexported.Account) *stateObje
 		stateDB:                 db,
 		account:                 ethermintAccount,
 		address:                 ethermintAccount.EthAddress(),
+		dust:                    db.getDust(ethermintAccount.EthAddress()),
+		epoch:                   db.GetStorageEpoch(ethermintAccount.EthAddress()),
 		originStorage:           Storage{},
 		dirtyStorage:            Storage{},
 		keyToOriginStorageIndex: make(map[ethcmn.Hash]int),
@@ -176,9 +184,8 @@ func (so *stateObject) AddBalance(amount *big.Int) {
 		return
 	}
 
-	evmDenom := so.stateDB.GetParams().EvmDenom
-	newBalance := so.account.GetCoins().AmountOf(evmDenom).Add(amt)
-	so.SetBalance(newBalance.BigInt())
+	newBalance := new(big.Int).Add(so.Balance(), amount)
+	so.SetBalance(newBalance)
 }
 
 // SubBalance removes an amount from the stateObject's balance. It is used to
@@ -189,22 +196,47 @@ func (so *stateObject) SubBalance(amount *big.Int) {
 		return
 	}
 
-	evmDenom := so.stateDB.GetParams().EvmDenom
-	newBalance := so.account.GetCoins().AmountOf(evmDenom).Sub(amt)
-	so.SetBalance(newBalance.BigInt())
+	newBalance := new(big.Int).Sub(so.Balance(), amount)
+	so.SetBalance(newBalance)
 }
 
-// SetBalance sets the state object's balance.
+// SetBalance sets the state object's 18 decimals EVM balance.
 func (so *stateObject) SetBalance(amount *big.Int) {
-	amt := sdk.NewIntFromBigInt(amount)
-
-	evmDenom := so.stateDB.GetParams().EvmDenom
 	so.stateDB.journal.append(balanceChange{
 		account: &so.address,
-		prev:    so.account.GetCoins().AmountOf(evmDenom),
+		prev:    sdk.NewIntFromBigInt(so.Balance()),
+	})
+
+	so.setEVMBalance(amount)
+}
+
+// setEVMBalance splits the 18 decimals EVM balance into the EVM denomination
+// amount of the account and the dust.
+func (so *stateObject) setEVMBalance(amount *big.Int) {
+	params := so.stateDB.GetParams()
+	coins, dust := params.FromEVMAmount(amount)
+
+	so.setBalance(params.EvmDenom, coins)
+	so.dust = dust
+}
+
+// SetCoinBalance sets the state object's balance for an arbitrary SDK coin
+// denomination. The dust of the EVM denomination is kept when setting its
+// balance.
+func (so *stateObject) SetCoinBalance(denom string, amount sdk.Int) {
+	params := so.stateDB.GetParams()
+	if denom == params.EvmDenom {
+		so.SetBalance(new(big.Int).Add(params.ToEVMAmount(amount), so.dust))
+		return
+	}
+
+	so.stateDB.journal.append(coinBalanceChange{
+		account: &so.address,
+		denom:   denom,
+		prev:    so.account.GetCoins().AmountOf(denom),
 	})
 
-	so.setBalance(evmDenom, amt)
+	so.setBalance(denom, amount)
 }
 
 func (so *stateObject) setBalance(denom string, amount sdk.Int) {
@@ -228,6 +260,21 @@ func (so *stateObject) setNonce(nonce uint64) {
 	so.account.Sequence = nonce
 }
 
+// setAccount replaces the account of the state object with an account updated
+// outside of the EVM (eg: by a stateful precompile).
+func (so *stateObject) setAccount(acc *ethermint.EthAccount) {
+	so.stateDB.journal.append(accountChange{
+		account: &so.address,
+		prev:    so.account,
+	})
+
+	if acc.CodeHash == nil {
+		acc.CodeHash = emptyCodeHash
+	}
+
+	so.account = acc
+}
+
 // setError remembers the first non-nil error it is called with.
 func (so *stateObject) setError(err error) {
 	if so.dbErr == nil {
@@ -251,6 +298,9 @@ func (so *stateObject) commitState() {
 		key := ethcmn.HexToHash(state.Key)
 		value := ethcmn.HexToHash(state.Value)
 
+		// the cached value can't be used until the block is committed
+		so.stateDB.stateCache.invalidateStorage(so.Address(), key)
+
 		// delete empty values from the store
 		if ethermint.IsEmptyHash(state.Value) {
 			store.Delete(key.Bytes())
@@ -274,7 +324,7 @@ func (so *stateObject) commitState() {
 		}
 
 		so.originStorage[idx].Value = state.Value
-		store.Set(key.Bytes(), value.Bytes())
+		store.Set(key.Bytes(), EncodeStorageValue(so.epoch, value))
 	}
 	// clean storage as all entries are dirty
 	so.dirtyStorage = Storage{}
@@ -285,6 +335,7 @@ func (so *stateObject) commitCode() {
 	ctx := so.stateDB.ctx
 	store := prefix.NewStore(ctx.KVStore(so.stateDB.storeKey), KeyPrefixCode)
 	store.Set(so.CodeHash(), so.code)
+	so.stateDB.stateCache.setCode(so.CodeHash(), so.code)
 }
 
 // ----------------------------------------------------------------------------
@@ -296,14 +347,12 @@ func (so stateObject) Address() ethcmn.Address {
 	return so.address
 }
 
-// Balance returns the state object's current balance.
+// Balance returns the state object's current 18 decimals EVM balance, i.e the
+// scaled EVM denomination amount plus the dust.
 func (so *stateObject) Balance() *big.Int {
-	evmDenom := so.stateDB.GetParams().EvmDenom
-	balance := so.account.Balance(evmDenom).BigInt()
-	if balance == nil {
-		return zeroBalance
-	}
-	return balance
+	params := so.stateDB.GetParams()
+	balance := params.ToEVMAmount(so.account.Balance(params.EvmDenom))
+	return balance.Add(balance, so.dust)
 }
 
 // CodeHash returns the state object's code hash.
@@ -333,8 +382,12 @@ func (so *stateObject) Code(_ ethstate.Database) []byte {
 	}
 
 	ctx := so.stateDB.ctx
-	store := prefix.NewStore(ctx.KVStore(so.stateDB.storeKey), KeyPrefixCode)
-	code := store.Get(so.CodeHash())
+	code, cached := so.stateDB.stateCache.getCode(ctx, so.CodeHash())
+	if !cached {
+		store := prefix.NewStore(ctx.KVStore(so.stateDB.storeKey), KeyPrefixCode)
+		code = store.Get(so.CodeHash())
+		so.stateDB.stateCache.setCode(so.CodeHash(), code)
+	}
 
 	if len(code) == 0 {
 		so.setError(fmt.Errorf("failed to get code hash %x for address %s", so.CodeHash(), so.Address().String()))
@@ -378,11 +431,16 @@ func (so *stateObject) GetCommittedState(_ ethstate.Database, key ethcmn.Hash) e
 	value := ethcmn.Hash{}
 
 	ctx := so.stateDB.ctx
-	store := prefix.NewStore(ctx.KVStore(so.stateDB.storeKey), AddressStoragePrefix(so.Address()))
-	rawValue := store.Get(prefixKey.Bytes())
+	rawValue, cached := so.stateDB.stateCache.getStorage(ctx, so.Address(), prefixKey)
+	if !cached {
+		store := prefix.NewStore(ctx.KVStore(so.stateDB.storeKey), AddressStoragePrefix(so.Address()))
+		rawValue = store.Get(prefixKey.Bytes())
+		so.stateDB.stateCache.setStorage(ctx, so.Address(), prefixKey, rawValue)
+	}
 
-	if len(rawValue) > 0 {
-		value.SetBytes(rawValue)
+	// the slots written before the account was destructed are empty
+	if epoch, storedValue := DecodeStorageValue(rawValue); len(rawValue) > 0 && epoch == so.epoch {
+		value = storedValue
 		state.Value = value.String()
 	}
 
@@ -403,6 +461,8 @@ func (so *stateObject) deepCopy(db *CommitStateDB) *stateObject {
 	newStateObj := newStateObject(db, so.account)
 
 	newStateObj.code = so.code
+	newStateObj.dust = new(big.Int).Set(so.dust)
+	newStateObj.epoch = so.epoch
 	newStateObj.dirtyStorage = so.dirtyStorage.Copy()
 	newStateObj.originStorage = so.originStorage.Copy()
 	newStateObj.suicided = so.suicided
@@ -413,13 +473,16 @@ func (so *stateObject) deepCopy(db *CommitStateDB) *stateObject {
 }
 
 // empty returns whether the account is considered empty.
+//
+// NOTE: the balances of all the coin denominations are checked, as the account can
+// hold other coins besides the EVM denomination (eg: transferred by the bank
+// precompile).
 func (so *stateObject) empty() bool {
-	evmDenom := so.stateDB.GetParams().EvmDenom
-	balace := so.account.Balance(evmDenom)
 	return so.account == nil ||
 		(so.account != nil &&
 			so.account.Sequence == 0 &&
-			(balace.BigInt() == nil || balace.IsZero()) &&
+			so.account.GetCoins().IsZero() &&
+			so.dust.Sign() == 0 &&
 			bytes.Equal(so.account.CodeHash, emptyCodeHash))
 }
 
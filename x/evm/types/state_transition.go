@@ -8,10 +8,19 @@ import (
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/core"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	"github.com/ethereum/go-ethereum/core/vm"
+	"github.com/ethereum/go-ethereum/crypto"
+	ethparams "github.com/ethereum/go-ethereum/params"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
 	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
+
+	"github.com/cosmos/ethermint/x/evm/vm"
+)
+
+const (
+	// ModuleGasLimit is the gas limit of the contract calls and deployments executed
+	// by the Cosmos modules through the keeper CallEVM and DeployContract functions
+	ModuleGasLimit uint64 = 10000000
 )
 
 // StateTransition defines data to transitionDB in evm
@@ -29,10 +38,13 @@ type StateTransition struct {
 	TxHash   *common.Hash
 	Sender   common.Address
 	Simulate bool // i.e CheckTx execution
+	// Tracer records the call frames of the EVM execution when set
+	Tracer *CallTracer
 }
 
 // GasInfo returns the gas limit, gas consumed and gas refunded from the EVM transition
-// execution
+// execution. As on Ethereum, the gas consumed includes the intrinsic gas and is net
+// of the gas refunded by the EVM refund counter.
 type GasInfo struct {
 	GasLimit    uint64
 	GasConsumed uint64
@@ -77,19 +89,22 @@ func (st StateTransition) newEVM(
 	gasLimit uint64,
 	gasPrice *big.Int,
 	config ChainConfig,
-	extraEIPs []int64,
+	params Params,
+	precompiles *precompileSession,
+	restrictions *restrictionTracer,
 ) *vm.EVM {
 	// Create contexts for evm
 
 	blockCtx := vm.BlockContext{
-		CanTransfer: core.CanTransfer,
-		Transfer:    core.Transfer,
+		CanTransfer: canTransfer,
+		Transfer:    transfer,
 		GetHash:     GetHashFn(ctx, csdb),
 		Coinbase:    common.Address{}, // there's no beneficiary since we're not mining
 		BlockNumber: big.NewInt(ctx.BlockHeight()),
 		Time:        big.NewInt(ctx.BlockHeader().Time.Unix()),
 		Difficulty:  big.NewInt(0), // unused. Only required in PoW context
 		GasLimit:    gasLimit,
+		BaseFee:     big.NewInt(0), // there's no EIP-1559 base fee, the gas price is set by the validators
 	}
 
 	txCtx := vm.TxContext{
@@ -97,8 +112,8 @@ func (st StateTransition) newEVM(
 		GasPrice: gasPrice,
 	}
 
-	eips := make([]int, len(extraEIPs))
-	for i, eip := range extraEIPs {
+	eips := make([]int, len(params.ExtraEIPs))
+	for i, eip := range params.ExtraEIPs {
 		eips[i] = int(eip)
 	}
 
@@ -106,68 +121,106 @@ func (st StateTransition) newEVM(
 		ExtraEips: eips,
 	}
 
-	return vm.NewEVM(blockCtx, txCtx, csdb, config.EthereumConfig(st.ChainID), vmConfig)
+	// the restrictions and the precompile callers are obtained by tracing the EVM
+	// execution, so it's only enabled when there are restrictions to enforce,
+	// enabled precompiles or calls to record
+	var evmTracers tracers
+	if restrictions != nil {
+		evmTracers = append(evmTracers, restrictions)
+	}
+	if precompiles.traced() {
+		evmTracers = append(evmTracers, precompiles)
+	}
+	if st.Tracer != nil {
+		evmTracers = append(evmTracers, st.Tracer)
+	}
+
+	switch len(evmTracers) {
+	case 0:
+	case 1:
+		vmConfig.Debug = true
+		vmConfig.Tracer = evmTracers[0]
+	default:
+		vmConfig.Debug = true
+		vmConfig.Tracer = evmTracers
+	}
+
+	// the value transfers to the blocked addresses abort the execution
+	statedb := transferGuard{CommitStateDB: csdb, params: params}
+
+	evm := vm.NewEVM(blockCtx, txCtx, statedb, config.EthereumConfig(st.ChainID), vmConfig)
+
+	// the stateful precompiles are bound to the state of this transition
+	evm.WithPrecompiles(precompiles.contracts())
+	return evm
+}
+
+// canTransfer checks whether the account has enough balance for the transfer. It
+// implements vm.CanTransferFunc as core.CanTransfer does for the Geth EVM.
+func canTransfer(db vm.StateDB, addr common.Address, amount *big.Int) bool {
+	return db.GetBalance(addr).Cmp(amount) >= 0
+}
+
+// transfer moves the amount from the sender to the recipient. It implements
+// vm.TransferFunc as core.Transfer does for the Geth EVM.
+func transfer(db vm.StateDB, sender, recipient common.Address, amount *big.Int) {
+	db.SubBalance(sender, amount)
+	db.AddBalance(recipient, amount)
 }
 
-func (st StateTransition) refundGas(gasInfo GasInfo) {
-	// Apply refund counter, capped to half of the used gas.
-	refund := gasInfo.GasConsumed / 2
-	if refund > gasInfo.GasRefunded {
-		refund = gasInfo.GasRefunded
+// Receipt returns the receipt of a successful state transition with the given
+// execution result and transaction index. The block hash and the cumulative gas
+// used are not set.
+func (st StateTransition) Receipt(ctx sdk.Context, res *ExecutionResult, txIndex uint) *ethtypes.Receipt {
+	receipt := &ethtypes.Receipt{
+		Status:           ethtypes.ReceiptStatusSuccessful,
+		Bloom:            ethtypes.BytesToBloom(res.Bloom.Bytes()),
+		Logs:             res.Logs,
+		GasUsed:          res.GasInfo.GasConsumed,
+		BlockNumber:      big.NewInt(ctx.BlockHeight()),
+		TransactionIndex: txIndex,
 	}
 
-	// Return ETH for remaining gas, exchanged at the original rate.
-	gasRefund := new(big.Int).Mul(new(big.Int).SetUint64(refund), st.Price)
+	if st.TxHash != nil {
+		receipt.TxHash = *st.TxHash
+	}
 
-	// add refund back to counter.
-	// add gas information into state db
-	st.Csdb.AddRefund(refund)
-	st.Csdb.AddBalance(st.Sender, gasRefund)
+	if st.Recipient == nil {
+		receipt.ContractAddress = crypto.CreateAddress(st.Sender, st.AccountNonce)
+	}
 
-	// Also return remaining gas to the block gas counter so it is
-	// available for the next transaction.
-	// st.gp.AddGas(st.gas)
-	// do we have a block gas counter?
-	// will we need to track gas using gasmeter for the blocks?
+	return receipt
 }
 
 // TransitionDb will transition the state by applying the current transaction and
-// returning the evm execution result.
+// returning the evm execution result. The CommitStateDB must be created for this
+// transition, and the state changes of a simulated transition are never committed.
 // NOTE: State transition checks are run during AnteHandler execution.
 func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*ExecutionResult, error) {
 	contractCreation := st.Recipient == nil
+	rules := config.Rules(st.ChainID, ctx.BlockHeight())
 
-	cost, err := core.IntrinsicGas(st.Payload, contractCreation, config.IsHomestead(), config.IsIstanbul())
+	// NOTE: the transactions don't support access lists (EIP-2930), which are the
+	// only change of the intrinsic gas on Berlin.
+	cost, err := core.IntrinsicGas(st.Payload, nil, contractCreation, rules.IsHomestead, rules.IsIstanbul)
 	if err != nil {
 		return nil, sdkerrors.Wrap(err, "invalid intrinsic gas for transaction")
 	}
 
+	// the intrinsic gas is checked by the AnteHandler during CheckTx only
+	if st.GasLimit < cost {
+		return nil, sdkerrors.Wrapf(sdkerrors.ErrOutOfGas, "intrinsic gas too low: %d < %d", st.GasLimit, cost)
+	}
+
 	// This gas limit the the transaction gas limit with intrinsic gas subtracted
-	gasLimit := st.GasLimit - ctx.GasMeter().GasConsumed()
+	gasLimit := st.GasLimit - cost
 
 	csdb := st.Csdb.WithContext(ctx)
-	if st.Simulate {
-		// gasLimit is set here because stdTxs incur gaskv charges in the ante handler, but for eth_call
-		// the cost needs to be the same as an Ethereum transaction sent through the web3 API
-		consumedGas := ctx.GasMeter().GasConsumed()
-		gasLimit = st.GasLimit - cost
-		if consumedGas < cost {
-			// If Cosmos standard tx ante handler cost is less than EVM intrinsic cost
-			// gas must be consumed to match to accurately simulate an Ethereum transaction
-			ctx.GasMeter().ConsumeGas(cost-consumedGas, "Intrinsic gas match")
-		}
-
-		csdb = st.Csdb.Copy()
-	}
 
 	// This gas meter is set up to consume gas from gaskv during evm execution and be ignored
-	currentGasMeter := ctx.GasMeter()
 	evmGasMeter := sdk.NewInfiniteGasMeter()
 	csdb.WithContext(ctx.WithGasMeter(evmGasMeter))
 
-	// Clear cache of accounts to handle changes outside of the EVM
-	csdb.UpdateAccounts()
-
 	params := csdb.GetParams()
 
 	gasPrice := ctx.MinGasPrices().AmountOf(params.EvmDenom)
@@ -175,7 +228,9 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 		return nil, errors.New("gas price cannot be nil")
 	}
 
-	evm := st.newEVM(ctx, csdb, gasLimit, gasPrice.Int, config, params.ExtraEIPs)
+	precompiles := newPrecompileSession(csdb, params.ActivePrecompiles)
+	restrictions := newRestrictionTracer(params)
+	evm := st.newEVM(ctx, csdb, gasLimit, gasPrice.Int, config, params, precompiles, restrictions)
 
 	var (
 		ret             []byte
@@ -190,6 +245,18 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 	// Set nonce of sender account before evm state transition for usage in generating Create address
 	csdb.SetNonce(st.Sender, st.AccountNonce)
 
+	// the EVM doesn't warm up the accounts accessed by the transaction (EIP-2929)
+	if rules.IsBerlin {
+		dst := st.Recipient
+		if contractCreation {
+			contractAddr := crypto.CreateAddress(st.Sender, st.AccountNonce)
+			dst = &contractAddr
+		}
+
+		warmPrecompiles := append(append([]common.Address{}, vm.ActivePrecompiles(rules)...), precompiles.addresses()...)
+		csdb.PrepareAccessList(st.Sender, dst, warmPrecompiles, nil)
+	}
+
 	// create contract or execute call
 	switch contractCreation {
 	case true:
@@ -197,24 +264,60 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 			return nil, ErrCreateDisabled
 		}
 
-		ret, contractAddress, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
+		if !params.IsDeployerAllowed(st.Sender) {
+			return nil, sdkerrors.Wrapf(ErrDeployerNotAllowed, "sender %s", st.Sender.String())
+		}
+
+		err = restrictions.execute(csdb, func() (err error) {
+			ret, contractAddress, leftOverGas, err = evm.Create(senderRef, st.Payload, gasLimit, st.Amount)
+			return err
+		})
 		recipientLog = fmt.Sprintf("contract address %s", contractAddress.String())
 	default:
 		if !params.EnableCall {
 			return nil, ErrCallDisabled
 		}
 
+		if params.IsContractDenied(*st.Recipient) {
+			return nil, sdkerrors.Wrapf(ErrContractDenied, "contract %s", st.Recipient.String())
+		}
+
 		// Increment the nonce for the next transaction	(just for evm state transition)
 		csdb.SetNonce(st.Sender, csdb.GetNonce(st.Sender)+1)
-		ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
+		err = restrictions.execute(csdb, func() (err error) {
+			ret, leftOverGas, err = evm.Call(senderRef, *st.Recipient, st.Payload, gasLimit, st.Amount)
+			return err
+		})
 		recipientLog = fmt.Sprintf("recipient address %s", st.Recipient.String())
 	}
 
-	gasConsumed := gasLimit - leftOverGas
+	// The gas used by the transaction is refunded by the EVM refund counter (i.e the
+	// cleared storage slots and, before London, the destructed accounts), up to a
+	// portion of the gas used. Failed executions are charged too.
+	gasUsed := st.GasLimit - leftOverGas
+
+	// the refund is capped to gasUsed / quotient
+	quotient := ethparams.RefundQuotient
+	if rules.IsLondon {
+		quotient = ethparams.RefundQuotientEIP3529
+	}
+
+	refund := csdb.GetRefund()
+	if refund > gasUsed/quotient {
+		refund = gasUsed / quotient
+	}
+
+	gasUsed -= refund
+
+	// Consume the gas used by the transition, which is the gas used on Ethereum. The
+	// gaskv costs of the execution are ignored.
+	ctx.GasMeter().ConsumeGas(gasUsed, "EVM execution consumption")
 
 	if err != nil {
-		// Consume gas before returning
-		ctx.GasMeter().ConsumeGas(gasConsumed, "evm execution consumption")
+		// the revert data is returned with the error, as it contains the revert reason
+		if errors.Is(err, vm.ErrExecutionReverted) {
+			return nil, NewRevertError(ret)
+		}
 		return nil, err
 	}
 
@@ -240,9 +343,10 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 	}
 
 	if !st.Simulate {
-		// Finalise state if not a simulated transaction
+		// Commit the state objects to the context of the transition if not a
+		// simulated transaction
 		// TODO: change to depend on config
-		if err := csdb.Finalise(true); err != nil {
+		if _, err := csdb.Commit(true); err != nil {
 			return nil, err
 		}
 	}
@@ -268,19 +372,12 @@ func (st StateTransition) TransitionDb(ctx sdk.Context, config ChainConfig) (*Ex
 		"executed EVM state transition; sender address %s; %s", st.Sender.String(), recipientLog,
 	)
 
-	// Consume gas from evm execution
-	// Out of gas check does not need to be done here since it is done within the EVM execution
-	ctx.WithGasMeter(currentGasMeter).GasMeter().ConsumeGas(gasConsumed, "EVM execution consumption")
-
 	gasInfo := GasInfo{
-		GasConsumed: gasConsumed,
-		GasLimit:    gasLimit,
-		GasRefunded: leftOverGas,
+		GasLimit:    st.GasLimit,
+		GasConsumed: gasUsed,
+		GasRefunded: refund,
 	}
 
-	// refund gas to sender
-	st.refundGas(gasInfo)
-
 	executionResult := &ExecutionResult{
 		Logs:  logs,
 		Bloom: bloomInt,
@@ -1,15 +1,20 @@
 package types_test
 
 import (
+	"errors"
 	"math/big"
 
 	abci "github.com/tendermint/tendermint/abci/types"
 
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	"github.com/cosmos/cosmos-sdk/x/auth"
+	"github.com/cosmos/cosmos-sdk/x/staking"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
 	"github.com/cosmos/ethermint/crypto/ethsecp256k1"
 	ethermint "github.com/cosmos/ethermint/types"
 	"github.com/cosmos/ethermint/x/evm/types"
+	"github.com/cosmos/ethermint/x/evm/vm"
 
 	"github.com/ethereum/go-ethereum/common"
 	ethcmn "github.com/ethereum/go-ethereum/common"
@@ -108,14 +113,16 @@ func (suite *StateDBTestSuite) TestGetHashFn() {
 }
 
 func (suite *StateDBTestSuite) TestTransitionDb() {
-	suite.stateDB.SetNonce(suite.address, 123)
-
 	addr := sdk.AccAddress(suite.address.Bytes())
 	balance := ethermint.NewPhotonCoin(sdk.NewInt(5000))
 	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, addr)
 	_ = acc.SetCoins(sdk.NewCoins(balance))
 	suite.app.AccountKeeper.SetAccount(suite.ctx, acc)
 
+	// the StateDB is created after the account update, as it caches the accounts
+	suite.stateDB = suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	suite.stateDB.SetNonce(suite.address, 123)
+
 	priv, err := ethsecp256k1.GenerateKey()
 	suite.Require().NoError(err)
 	recipient := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
@@ -132,7 +139,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    &recipient,
 				Amount:       big.NewInt(50),
 				Payload:      []byte("data"),
@@ -150,7 +157,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    nil,
 				Amount:       big.NewInt(10),
 				Payload:      []byte("data"),
@@ -168,7 +175,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    &recipient,
 				Amount:       big.NewInt(10),
 				Payload:      []byte("data"),
@@ -186,7 +193,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    &recipient,
 				Amount:       big.NewInt(500000),
 				Payload:      []byte("data"),
@@ -207,7 +214,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    &recipient,
 				Amount:       big.NewInt(50),
 				Payload:      []byte("data"),
@@ -228,7 +235,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    nil,
 				Amount:       big.NewInt(50),
 				Payload:      []byte("data"),
@@ -252,7 +259,7 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 			types.StateTransition{
 				AccountNonce: 123,
 				Price:        big.NewInt(10),
-				GasLimit:     11,
+				GasLimit:     100000,
 				Recipient:    &recipient,
 				Amount:       big.NewInt(10),
 				Payload:      []byte("data"),
@@ -266,24 +273,343 @@ func (suite *StateDBTestSuite) TestTransitionDb() {
 		},
 	}
 
+	// the refunds are capped to half of the used gas before London
+	config := types.DefaultChainConfig()
+	config.LondonBlock = sdk.NewInt(-1)
+
 	for _, tc := range testCase {
 		tc.malleate()
 
-		_, err = tc.state.TransitionDb(suite.ctx, types.DefaultChainConfig())
+		_, err = tc.state.TransitionDb(suite.ctx, config)
 
 		if tc.expPass {
 			suite.Require().NoError(err, tc.name)
 			fromBalance := suite.app.EvmKeeper.GetBalance(suite.ctx, suite.address)
 			toBalance := suite.app.EvmKeeper.GetBalance(suite.ctx, recipient)
-			if tc.name == "contract creation" || tc.name == "state transition simulation" {
-				// accounts for refunded amount
-				suite.Require().Equal(big.NewInt(4960), fromBalance, tc.name)
-			} else {
-				suite.Require().Equal(big.NewInt(4950), fromBalance, tc.name)
-			}
+			// the simulated transitions don't modify the state
+			suite.Require().Equal(big.NewInt(4950), fromBalance, tc.name)
 			suite.Require().Equal(big.NewInt(50), toBalance, tc.name)
 		} else {
 			suite.Require().Error(err, tc.name)
 		}
 	}
 }
+
+func (suite *StateDBTestSuite) TestTransitionDbRestrictions() {
+	priv, err := ethsecp256k1.GenerateKey()
+	suite.Require().NoError(err)
+	target := ethcrypto.PubkeyToAddress(priv.ToECDSA().PublicKey)
+
+	factory := ethcmn.BytesToAddress([]byte("factory"))
+	caller := ethcmn.BytesToAddress([]byte("caller"))
+
+	// CREATE(0, 0, 0)
+	suite.stateDB.SetCode(factory, ethcmn.Hex2Bytes("600060006000f000"))
+	// CALL(gas, target, 0, 0, 0, 0, 0)
+	suite.stateDB.SetCode(caller, append(append(ethcmn.Hex2Bytes("60006000600060006000"+"73"), target.Bytes()...), ethcmn.Hex2Bytes("5af15000")...))
+	suite.stateDB.SetCode(target, []byte{0x00})
+
+	// the sender nonce is reset after each transition, so the created contract
+	// addresses are derived from an increasing nonce
+	var accountNonce uint64
+	newTransition := func(recipient *ethcmn.Address) types.StateTransition {
+		accountNonce++
+		return types.StateTransition{
+			AccountNonce: accountNonce,
+			Price:        big.NewInt(0),
+			GasLimit:     100000,
+			Recipient:    recipient,
+			Amount:       big.NewInt(0),
+			Payload:      []byte{},
+			ChainID:      big.NewInt(1),
+			Csdb:         suite.stateDB,
+			TxHash:       &ethcmn.Hash{},
+			Sender:       suite.address,
+		}
+	}
+
+	testCases := []struct {
+		name      string
+		malleate  func(params *types.Params)
+		recipient *ethcmn.Address
+		expErr    error
+		// expGas is the gas charged for a rejected transaction
+		expGas uint64
+	}{
+		{
+			"no restrictions, create",
+			func(*types.Params) {},
+			nil,
+			nil,
+			0,
+		},
+		{
+			"no restrictions, create from contract",
+			func(*types.Params) {},
+			&factory,
+			nil,
+			0,
+		},
+		{
+			"allowed deployer, create",
+			func(params *types.Params) {
+				params.DeployerAllowlist = []string{suite.address.String()}
+			},
+			nil,
+			nil,
+			0,
+		},
+		{
+			"allowed deployer, create from contract",
+			func(params *types.Params) {
+				params.DeployerAllowlist = []string{suite.address.String()}
+			},
+			&factory,
+			nil,
+			0,
+		},
+		{
+			"allowed contract, create from contract",
+			func(params *types.Params) {
+				params.DeployerAllowlist = []string{factory.String()}
+			},
+			&factory,
+			nil,
+			0,
+		},
+		{
+			"deployer not allowed, create",
+			func(params *types.Params) {
+				params.DeployerAllowlist = []string{target.String()}
+			},
+			nil,
+			types.ErrDeployerNotAllowed,
+			0,
+		},
+		{
+			"deployer not allowed, create from contract",
+			func(params *types.Params) {
+				params.DeployerAllowlist = []string{target.String()}
+			},
+			&factory,
+			types.ErrDeployerNotAllowed,
+			100000,
+		},
+		{
+			"contract not denied, call from contract",
+			func(params *types.Params) {
+				params.ContractDenylist = []string{factory.String()}
+			},
+			&caller,
+			nil,
+			0,
+		},
+		{
+			"contract denied, call",
+			func(params *types.Params) {
+				params.ContractDenylist = []string{target.String()}
+			},
+			&target,
+			types.ErrContractDenied,
+			0,
+		},
+		{
+			"contract denied, call from contract",
+			func(params *types.Params) {
+				params.ContractDenylist = []string{target.String()}
+			},
+			&caller,
+			types.ErrContractDenied,
+			100000,
+		},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			params := types.DefaultParams()
+			tc.malleate(&params)
+			suite.stateDB.SetParams(params)
+
+			nonce := suite.stateDB.GetNonce(factory)
+
+			ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			_, err := newTransition(tc.recipient).TransitionDb(ctx, types.DefaultChainConfig())
+			if tc.expErr != nil {
+				suite.Require().Error(err)
+				suite.Require().True(errors.Is(err, tc.expErr), err.Error())
+				// the state changes of the execution are reverted
+				suite.Require().Equal(nonce, suite.stateDB.GetNonce(factory))
+				// the transaction is rejected before the execution if the sender or the
+				// recipient are restricted, and it consumes all its gas otherwise
+				suite.Require().Equal(tc.expGas, ctx.GasMeter().GasConsumed())
+			} else {
+				suite.Require().NoError(err)
+			}
+		})
+	}
+}
+
+func (suite *StateDBTestSuite) TestTransitionDbBlockedAddresses() {
+	feeCollector := ethcmn.BytesToAddress(supply.NewModuleAddress(auth.FeeCollectorName).Bytes())
+	// the module accounts that already exist aren't EthAccounts
+	bondedPool := ethcmn.BytesToAddress(suite.app.SupplyKeeper.GetModuleAccount(suite.ctx, staking.BondedPoolName).GetAddress().Bytes())
+	other := ethcmn.BytesToAddress([]byte("other"))
+	forwarder := ethcmn.BytesToAddress([]byte("forwarder"))
+	destructor := ethcmn.BytesToAddress([]byte("destructor"))
+
+	// CALL(gas, feeCollector, CALLVALUE, 0, 0, 0, 0)
+	suite.stateDB.SetCode(forwarder, append(append(ethcmn.Hex2Bytes("60006000600060003473"), feeCollector.Bytes()...), ethcmn.Hex2Bytes("5af15000")...))
+	// SELFDESTRUCT(feeCollector)
+	suite.stateDB.SetCode(destructor, append(append([]byte{0x73}, feeCollector.Bytes()...), 0xff))
+
+	testCases := []struct {
+		name      string
+		malleate  func(params *types.Params)
+		recipient ethcmn.Address
+		amount    int64
+		expErr    error
+	}{
+		{"value transfer", func(*types.Params) {}, other, 10, nil},
+		{"value transfer to module account", func(*types.Params) {}, feeCollector, 10, types.ErrBlockedAddress},
+		{"zero value call to module account", func(*types.Params) {}, feeCollector, 0, nil},
+		{"value transfer to existing module account", func(*types.Params) {}, bondedPool, 10, types.ErrBlockedAddress},
+		{"zero value call to existing module account", func(*types.Params) {}, bondedPool, 0, types.ErrBlockedAddress},
+		{
+			"value transfer to denylisted address",
+			func(params *types.Params) {
+				params.TransferDenylist = []string{other.String()}
+			},
+			other,
+			10,
+			types.ErrBlockedAddress,
+		},
+		{"value forwarded to module account", func(*types.Params) {}, forwarder, 10, types.ErrBlockedAddress},
+		{"selfdestruct to module account", func(*types.Params) {}, destructor, 0, types.ErrBlockedAddress},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			params := types.DefaultParams()
+			tc.malleate(&params)
+			suite.stateDB.SetParams(params)
+
+			suite.stateDB.SetBalance(suite.address, big.NewInt(100))
+			suite.stateDB.SetBalance(destructor, big.NewInt(10))
+			suite.Require().NoError(suite.stateDB.Finalise(false))
+
+			recipient := tc.recipient
+			st := types.StateTransition{
+				AccountNonce: suite.stateDB.GetNonce(suite.address),
+				Price:        big.NewInt(0),
+				GasLimit:     100000,
+				Recipient:    &recipient,
+				Amount:       big.NewInt(tc.amount),
+				Payload:      []byte{},
+				ChainID:      big.NewInt(1),
+				Csdb:         suite.stateDB,
+				TxHash:       &ethcmn.Hash{},
+				Sender:       suite.address,
+			}
+
+			ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			_, err := st.TransitionDb(ctx, types.DefaultChainConfig())
+			if tc.expErr != nil {
+				suite.Require().Error(err)
+				suite.Require().True(errors.Is(err, tc.expErr), err.Error())
+				// the transfers of the execution are reverted
+				suite.Require().Equal(big.NewInt(100), suite.stateDB.GetBalance(suite.address))
+				suite.Require().Equal(big.NewInt(10), suite.stateDB.GetBalance(destructor))
+			} else {
+				suite.Require().NoError(err)
+			}
+
+			suite.Require().Zero(suite.stateDB.GetBalance(feeCollector).Sign())
+		})
+	}
+}
+
+func (suite *StateDBTestSuite) TestTransitionDbForks() {
+	other := ethcmn.BytesToAddress([]byte("other"))
+	contract := ethcmn.BytesToAddress([]byte("contract"))
+	clearer := ethcmn.BytesToAddress([]byte("clearer"))
+
+	// BALANCE(ORIGIN), BALANCE(other)
+	suite.app.EvmKeeper.SetCode(suite.ctx, contract, append(append(ethcmn.Hex2Bytes("3231"+"50"+"73"), other.Bytes()...), ethcmn.Hex2Bytes("315000")...))
+	// SSTORE(0, 0)
+	suite.app.EvmKeeper.SetCode(suite.ctx, clearer, ethcmn.Hex2Bytes("600060005500"))
+	// MSTORE8(0, 0xEF), RETURN(0, 1)
+	efInitCode := ethcmn.Hex2Bytes("60ef60005360016000f3")
+
+	var accountNonce uint64
+	newTransition := func(recipient *ethcmn.Address, payload []byte) types.StateTransition {
+		accountNonce++
+		return types.StateTransition{
+			AccountNonce: accountNonce,
+			Price:        big.NewInt(1),
+			GasLimit:     100000,
+			Recipient:    recipient,
+			Amount:       big.NewInt(0),
+			Payload:      payload,
+			ChainID:      big.NewInt(1),
+			Csdb:         suite.app.EvmKeeper.NewStateDB(suite.ctx),
+			TxHash:       &ethcmn.Hash{},
+			Sender:       suite.address,
+		}
+	}
+
+	istanbul := types.DefaultChainConfig()
+	istanbul.BerlinBlock = sdk.NewInt(-1)
+	istanbul.LondonBlock = sdk.NewInt(-1)
+
+	berlin := types.DefaultChainConfig()
+	berlin.LondonBlock = sdk.NewInt(-1)
+
+	london := types.DefaultChainConfig()
+
+	// the gas used includes the intrinsic gas and is net of the refund, as on Ethereum
+	testCases := []struct {
+		name       string
+		config     types.ChainConfig
+		recipient  *ethcmn.Address
+		payload    []byte
+		expGasUsed uint64
+		expRefund  uint64
+		expErr     error
+	}{
+		// 21000 + 2 + 700 + 2 + 3 + 700 + 2
+		{"istanbul, state access", istanbul, &contract, nil, 22409, 0, nil},
+		// 21000 + 2 + 100 (warm origin) + 2 + 3 + 2600 (cold address) + 2
+		{"berlin, state access", berlin, &contract, nil, 23709, 0, nil},
+		// 21000 + 3 + 3 + 5000 - 13003 (capped to half of the gas used)
+		{"istanbul, clear storage", istanbul, &clearer, nil, 13003, 13003, nil},
+		// 21000 + 3 + 3 + 2100 (cold slot) + 2900 - 13003 (capped to half of the gas used)
+		{"berlin, clear storage", berlin, &clearer, nil, 13003, 13003, nil},
+		// 21000 + 3 + 3 + 2100 (cold slot) + 2900 - 4800 (EIP-3529 clear refund, below a fifth of the gas used)
+		{"london, clear storage", london, &clearer, nil, 21206, 4800, nil},
+		{"berlin, deploy 0xEF code", berlin, nil, efInitCode, 0, 0, nil},
+		{"london, deploy 0xEF code", london, nil, efInitCode, 0, 0, vm.ErrInvalidCode},
+	}
+
+	for _, tc := range testCases {
+		suite.Run(tc.name, func() {
+			suite.app.EvmKeeper.SetState(suite.ctx, clearer, ethcmn.Hash{}, ethcmn.BigToHash(big.NewInt(1)))
+
+			ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+			res, err := newTransition(tc.recipient, tc.payload).TransitionDb(ctx, tc.config)
+			if tc.expErr != nil {
+				suite.Require().Error(err)
+				suite.Require().True(errors.Is(err, tc.expErr), err.Error())
+				return
+			}
+
+			suite.Require().NoError(err)
+			if tc.recipient == nil {
+				return
+			}
+
+			suite.Require().Equal(tc.expGasUsed, res.GasInfo.GasConsumed)
+			suite.Require().Equal(tc.expRefund, res.GasInfo.GasRefunded)
+			suite.Require().Equal(tc.expGasUsed, ctx.GasMeter().GasConsumed())
+		})
+	}
+}
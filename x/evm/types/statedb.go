@@ -1,6 +1,7 @@
 package types
 
 import (
+	"encoding/binary"
 	"fmt"
 	"math/big"
 	"sort"
@@ -8,14 +9,16 @@ import (
 
 	"github.com/cosmos/cosmos-sdk/store/prefix"
 	sdk "github.com/cosmos/cosmos-sdk/types"
+	sdkerrors "github.com/cosmos/cosmos-sdk/types/errors"
 	"github.com/cosmos/cosmos-sdk/x/params"
+	"github.com/cosmos/cosmos-sdk/x/supply"
 
 	ethermint "github.com/cosmos/ethermint/types"
+	ethvm "github.com/cosmos/ethermint/x/evm/vm"
 
 	ethcmn "github.com/ethereum/go-ethereum/common"
 	ethstate "github.com/ethereum/go-ethereum/core/state"
 	ethtypes "github.com/ethereum/go-ethereum/core/types"
-	ethvm "github.com/ethereum/go-ethereum/core/vm"
 	ethcrypto "github.com/ethereum/go-ethereum/crypto"
 )
 
@@ -34,12 +37,13 @@ type revision struct {
 // a trie and database for querying and persistence, the Keeper uses KVStores
 // and an AccountKeeper to facilitate state transitions.
 //
-// TODO: This implementation is subject to change in regards to its statefull
-// manner. In otherwords, how this relates to the keeper in this module.
+// A CommitStateDB is short-lived: the Keeper creates a new one for each state
+// transition, over a cached context that is only written when the transition
+// succeeds. Its state objects must therefore be committed before the cached
+// context is written.
 type CommitStateDB struct {
-	// TODO: We need to store the context as part of the structure itself opposed
-	// to being passed as a parameter (as it should be) in order to implement the
-	// StateDB interface. Perhaps there is a better way.
+	// The context is stored as part of the structure itself, opposed to being
+	// passed as a parameter, in order to implement the StateDB interface.
 	ctx sdk.Context
 
 	storeKey      sdk.StoreKey
@@ -80,6 +84,19 @@ type CommitStateDB struct {
 	// Per-transaction access list
 	accessList *accessList
 
+	// stateful precompiled contracts and the branched contexts from the calls that
+	// are pending to be committed
+	precompiles      *PrecompileRegistry
+	precompileWrites []precompileWrite
+
+	// bech32 addresses that can't receive EVM value transfers, i.e the module
+	// accounts whose balances are tracked by other modules
+	blockedAddrs map[string]bool
+
+	// node-local read cache of the contract codes and storage slots, shared
+	// across the CommitStateDBs
+	stateCache *StateCache
+
 	// mutex for state deep copying
 	lock sync.Mutex
 }
@@ -113,6 +130,35 @@ func (csdb *CommitStateDB) WithContext(ctx sdk.Context) *CommitStateDB {
 	return csdb
 }
 
+// SetPrecompiles sets the registry of stateful precompiled contracts that can be
+// called during the EVM state transitions.
+func (csdb *CommitStateDB) SetPrecompiles(precompiles *PrecompileRegistry) {
+	csdb.precompiles = precompiles
+}
+
+// SetBlockedAddrs sets the bech32 addresses that can't receive value transfers
+// from the EVM.
+func (csdb *CommitStateDB) SetBlockedAddrs(blockedAddrs map[string]bool) {
+	csdb.blockedAddrs = blockedAddrs
+}
+
+// SetStateCache sets the read cache of the contract codes and storage slots. A
+// nil cache disables the caching.
+func (csdb *CommitStateDB) SetStateCache(stateCache *StateCache) {
+	csdb.stateCache = stateCache
+}
+
+// IsBlockedAddr returns true if the address can't receive value transfers from
+// the EVM.
+func (csdb *CommitStateDB) IsBlockedAddr(addr ethcmn.Address) bool {
+	return csdb.blockedAddrs[sdk.AccAddress(addr.Bytes()).String()]
+}
+
+// Precompiles returns the registry of stateful precompiled contracts.
+func (csdb *CommitStateDB) Precompiles() *PrecompileRegistry {
+	return csdb.precompiles
+}
+
 // ----------------------------------------------------------------------------
 // Setters
 // ----------------------------------------------------------------------------
@@ -124,9 +170,11 @@ func (csdb *CommitStateDB) SetHeightHash(height uint64, hash ethcmn.Hash) {
 	store.Set(key, hash.Bytes())
 }
 
-// SetParams sets the evm parameters to the param space.
+// SetParams sets the evm parameters to the param space, and the EVM denom
+// decimals to the module store.
 func (csdb *CommitStateDB) SetParams(params Params) {
 	csdb.paramSpace.SetParamSet(csdb.ctx, &params)
+	SetEVMDenomDecimals(csdb.ctx.KVStore(csdb.storeKey), params.EvmDenomDecimals)
 }
 
 // SetBalance sets the balance of an account.
@@ -153,6 +201,15 @@ func (csdb *CommitStateDB) SubBalance(addr ethcmn.Address, amount *big.Int) {
 	}
 }
 
+// SetCoinBalance sets the balance of an account for an arbitrary SDK coin
+// denomination. The dust of the EVM denomination balance is kept.
+func (csdb *CommitStateDB) SetCoinBalance(addr ethcmn.Address, denom string, amount sdk.Int) {
+	so := csdb.GetOrNewStateObject(addr)
+	if so != nil {
+		so.(*stateObject).SetCoinBalance(denom, amount)
+	}
+}
+
 // SetNonce sets the nonce (sequence number) of an account.
 func (csdb *CommitStateDB) SetNonce(addr ethcmn.Address, nonce uint64) {
 	so := csdb.GetOrNewStateObject(addr)
@@ -184,7 +241,9 @@ func (csdb *CommitStateDB) SetCode(addr ethcmn.Address, code []byte) {
 // which can't use BinaryBare.
 // ----------------------------------------------------------------------------
 
-// SetLogs sets the logs for a transaction in the KVStore.
+// SetLogs sets the logs for a transaction in the KVStore. The transaction is also
+// indexed by the current block height, so that its logs can be pruned once they
+// expire.
 func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error {
 	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixLogs)
 	bz, err := MarshalLogs(logs)
@@ -194,6 +253,9 @@ func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error
 
 	store.Set(hash.Bytes(), bz)
 	csdb.logSize = uint(len(logs))
+
+	heightStore := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixHeightLogs)
+	heightStore.Set(HeightLogsKey(uint64(csdb.ctx.BlockHeight()), hash), []byte{0x01})
 	return nil
 }
 
@@ -201,6 +263,9 @@ func (csdb *CommitStateDB) SetLogs(hash ethcmn.Hash, logs []*ethtypes.Log) error
 func (csdb *CommitStateDB) DeleteLogs(hash ethcmn.Hash) {
 	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixLogs)
 	store.Delete(hash.Bytes())
+
+	heightStore := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixHeightLogs)
+	heightStore.Delete(HeightLogsKey(uint64(csdb.ctx.BlockHeight()), hash))
 }
 
 // AddLog adds a new log to the state and sets the log metadata from the state.
@@ -289,6 +354,28 @@ func (csdb *CommitStateDB) SlotInAccessList(addr ethcmn.Address, slot ethcmn.Has
 	return csdb.accessList.Contains(addr, slot)
 }
 
+// PrepareAccessList resets the access list and adds the addresses that are warm
+// at the beginning of a transaction on Berlin (EIP-2929): the sender, the
+// destination (i.e the recipient or the created contract), the precompiles and
+// the optional access list of the transaction (EIP-2930).
+func (csdb *CommitStateDB) PrepareAccessList(sender ethcmn.Address, dst *ethcmn.Address, precompiles []ethcmn.Address, txAccesses ethtypes.AccessList) {
+	csdb.accessList = newAccessList()
+
+	csdb.AddAddressToAccessList(sender)
+	if dst != nil {
+		csdb.AddAddressToAccessList(*dst)
+	}
+	for _, addr := range precompiles {
+		csdb.AddAddressToAccessList(addr)
+	}
+	for _, el := range txAccesses {
+		csdb.AddAddressToAccessList(el.Address)
+		for _, key := range el.StorageKeys {
+			csdb.AddSlotToAccessList(el.Address, key)
+		}
+	}
+}
+
 // ----------------------------------------------------------------------------
 // Getters
 // ----------------------------------------------------------------------------
@@ -308,6 +395,7 @@ func (csdb *CommitStateDB) GetHeightHash(height uint64) ethcmn.Hash {
 // GetParams returns the total set of evm parameters.
 func (csdb *CommitStateDB) GetParams() (params Params) {
 	csdb.paramSpace.GetParamSet(csdb.ctx, &params)
+	params.EvmDenomDecimals = GetEVMDenomDecimals(csdb.ctx.KVStore(csdb.storeKey))
 	return params
 }
 
@@ -322,6 +410,17 @@ func (csdb *CommitStateDB) GetBalance(addr ethcmn.Address) *big.Int {
 	return zeroBalance
 }
 
+// GetCoinBalance retrieves the balance of an arbitrary SDK coin denomination from
+// the given address or 0 if object not found.
+func (csdb *CommitStateDB) GetCoinBalance(addr ethcmn.Address, denom string) sdk.Int {
+	so := csdb.getStateObject(addr)
+	if so != nil {
+		return so.account.Balance(denom)
+	}
+
+	return sdk.ZeroInt()
+}
+
 // GetNonce returns the nonce (sequence number) for a given account.
 func (csdb *CommitStateDB) GetNonce(addr ethcmn.Address) uint64 {
 	so := csdb.getStateObject(addr)
@@ -466,12 +565,16 @@ func (csdb *CommitStateDB) StorageTrie(addr ethcmn.Address) ethstate.Trie {
 // ----------------------------------------------------------------------------
 
 // Commit writes the state to the appropriate KVStores. For each state object
-// in the cache, it will either be removed, or have it's code set and/or it's
-// state (storage) updated. In addition, the state object (account) itself will
-// be written. Finally, the root hash (version) will be returned.
+// in the cache, it will either be removed, or have it's code and dirty storage
+// set. In addition, the state object (account) itself will be written. Finally,
+// the root hash (version) will be returned.
 func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error) {
 	defer csdb.clearJournalAndRefund()
 
+	// persist the writes from the precompile calls before the accounts as the state
+	// objects contain the latest account values
+	csdb.commitPrecompileWrites()
+
 	// remove dirty state object entries based on the journal
 	for _, dirty := range csdb.journal.dirties {
 		csdb.stateObjectsDirty[dirty.address] = struct{}{}
@@ -494,6 +597,9 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 				stateEntry.stateObject.dirtyCode = false
 			}
 
+			// write the storage changes that haven't been finalised
+			stateEntry.stateObject.commitState()
+
 			// update the object in the KVStore
 			if err := csdb.updateStateObject(stateEntry.stateObject); err != nil {
 				return ethcmn.Hash{}, err
@@ -513,6 +619,10 @@ func (csdb *CommitStateDB) Commit(deleteEmptyObjects bool) (ethcmn.Hash, error)
 // removing the csdb destructed objects and clearing the journal as well as the
 // refunds.
 func (csdb *CommitStateDB) Finalise(deleteEmptyObjects bool) error {
+	// persist the writes from the precompile calls before the accounts as the state
+	// objects contain the latest account values
+	csdb.commitPrecompileWrites()
+
 	for _, dirty := range csdb.journal.dirties {
 		idx, exist := csdb.addressToObjectIndex[dirty.address]
 		if !exist {
@@ -567,7 +677,7 @@ func (csdb *CommitStateDB) IntermediateRoot(deleteEmptyObjects bool) (ethcmn.Has
 func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 	evmDenom := csdb.GetParams().EvmDenom
 	// NOTE: we don't use sdk.NewCoin here to avoid panic on test importer's genesis
-	newBalance := sdk.Coin{Denom: evmDenom, Amount: sdk.NewIntFromBigInt(so.Balance())}
+	newBalance := sdk.Coin{Denom: evmDenom, Amount: so.account.Balance(evmDenom)}
 	if !newBalance.IsValid() {
 		return fmt.Errorf("invalid balance %s", newBalance)
 	}
@@ -583,6 +693,7 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 	}
 
 	csdb.accountKeeper.SetAccount(csdb.ctx, so.account)
+	csdb.setDust(so.address, so.dust)
 	// return csdb.bankKeeper.SetBalance(csdb.ctx, so.account.Address, newBalance)
 	return nil
 }
@@ -591,6 +702,148 @@ func (csdb *CommitStateDB) updateStateObject(so *stateObject) error {
 func (csdb *CommitStateDB) deleteStateObject(so *stateObject) {
 	so.deleted = true
 	csdb.accountKeeper.RemoveAccount(csdb.ctx, so.account)
+	csdb.setDust(so.address, nil)
+	csdb.deleteStorage(so.address)
+}
+
+// deleteStorage deletes the storage of a destructed account, so that a contract
+// re-created at the same address (i.e through CREATE2) starts with an empty
+// storage. The storage epoch of the account is incremented, which hides the slots
+// written on the previous epochs from the reads, and the account is queued so that
+// these slots are deleted from the store on the following EndBlocks, with a bounded
+// number of deletions per block. The bookkeeping doesn't consume gas, as the
+// SELFDESTRUCT has already been charged by the EVM.
+//
+// The accounts without committed storage, e.g the empty accounts touched by the
+// calls to the precompiled contracts, are left untouched, so that the deletion of
+// the empty accounts doesn't grow the state.
+//
+// NOTE: the contract code isn't deleted, as it's stored by hash and can be shared
+// with other accounts.
+func (csdb *CommitStateDB) deleteStorage(addr ethcmn.Address) {
+	ctx := csdb.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	if !csdb.hasStorage(ctx, addr) {
+		return
+	}
+
+	csdb.stateCache.InvalidateAccountStorage(addr)
+
+	epoch := csdb.getStorageEpoch(ctx, addr)
+
+	epochStore := prefix.NewStore(ctx.KVStore(csdb.storeKey), KeyPrefixStorageEpoch)
+	epochStore.Set(addr.Bytes(), sdk.Uint64ToBigEndian(epoch+1))
+
+	// the deletion starts over from the first slot, as the slots skipped by a
+	// previous deletion were written on the previous epoch
+	queueStore := prefix.NewStore(ctx.KVStore(csdb.storeKey), KeyPrefixStorageQueue)
+	queueStore.Set(addr.Bytes(), ethcmn.Hash{}.Bytes())
+}
+
+// hasStorage returns true if any storage slot of the account is committed to the
+// store, including the slots of the previous epochs that aren't deleted yet.
+func (csdb *CommitStateDB) hasStorage(ctx sdk.Context, addr ethcmn.Address) bool {
+	iterator := sdk.KVStorePrefixIterator(ctx.KVStore(csdb.storeKey), AddressStoragePrefix(addr))
+	defer iterator.Close()
+
+	return iterator.Valid()
+}
+
+// GetStorageEpoch returns the storage epoch of an account, i.e the number of times
+// it was destructed. Only the storage slots written on the current epoch of the
+// account are part of its storage.
+func (csdb *CommitStateDB) GetStorageEpoch(addr ethcmn.Address) uint64 {
+	return csdb.getStorageEpoch(csdb.ctx, addr)
+}
+
+func (csdb *CommitStateDB) getStorageEpoch(ctx sdk.Context, addr ethcmn.Address) uint64 {
+	store := prefix.NewStore(ctx.KVStore(csdb.storeKey), KeyPrefixStorageEpoch)
+	bz := store.Get(addr.Bytes())
+	if len(bz) == 0 {
+		return 0
+	}
+
+	return binary.BigEndian.Uint64(bz)
+}
+
+// getDust returns the persisted dust of the EVM denomination balance of an
+// account.
+func (csdb *CommitStateDB) getDust(addr ethcmn.Address) *big.Int {
+	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixDust)
+	return new(big.Int).SetBytes(store.Get(addr.Bytes()))
+}
+
+// setDust persists the dust of the EVM denomination balance of an account. Zero
+// dust is removed from the store. The total dust of the accounts and the reserve
+// backing it are updated when the dust changes.
+func (csdb *CommitStateDB) setDust(addr ethcmn.Address, dust *big.Int) {
+	if dust == nil {
+		dust = new(big.Int)
+	}
+
+	// the bookkeeping of the total dust isn't charged, as the balance changes are
+	// charged by the EVM
+	bookkeepingCtx := csdb.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+	prevDust := new(big.Int).SetBytes(prefix.NewStore(bookkeepingCtx.KVStore(csdb.storeKey), KeyPrefixDust).Get(addr.Bytes()))
+
+	store := prefix.NewStore(csdb.ctx.KVStore(csdb.storeKey), KeyPrefixDust)
+	if dust.Sign() == 0 {
+		store.Delete(addr.Bytes())
+	} else {
+		store.Set(addr.Bytes(), dust.Bytes())
+	}
+
+	if dust.Cmp(prevDust) != 0 {
+		totalDust := new(big.Int).SetBytes(bookkeepingCtx.KVStore(csdb.storeKey).Get(KeyTotalDust))
+		totalDust.Add(totalDust, dust)
+		csdb.setTotalDust(bookkeepingCtx, totalDust.Sub(totalDust, prevDust))
+	}
+}
+
+// GetTotalDust returns the sum of the dust of the EVM denomination balances of
+// all the accounts.
+func (csdb *CommitStateDB) GetTotalDust() *big.Int {
+	store := csdb.ctx.KVStore(csdb.storeKey)
+	return new(big.Int).SetBytes(store.Get(KeyTotalDust))
+}
+
+// setTotalDust persists the total dust of the EVM balances and sets the balance
+// of the reserve module account to the whole coins of the EVM denomination that
+// back it, i.e the total dust rounded up to the denomination decimals.
+//
+// As the EVM value transfers preserve the sum of the 18 decimals EVM balances, the
+// whole coins lost by the accounts when their dust crosses a unit boundary are
+// minted on the reserve and the ones gained are burnt from it. The sum of the
+// account balances therefore stays equal to the supply of the EVM denomination.
+func (csdb *CommitStateDB) setTotalDust(ctx sdk.Context, totalDust *big.Int) {
+	store := ctx.KVStore(csdb.storeKey)
+	if totalDust.Sign() == 0 {
+		store.Delete(KeyTotalDust)
+	} else {
+		store.Set(KeyTotalDust, totalDust.Bytes())
+	}
+
+	params := csdb.GetParams()
+	reserveAddr := supply.NewModuleAddress(ReserveName)
+
+	reserve := csdb.accountKeeper.GetAccount(ctx, reserveAddr)
+	if reserve == nil {
+		reserve = csdb.accountKeeper.NewAccount(ctx, supply.NewEmptyModuleAccount(ReserveName))
+	}
+
+	// the other denominations of the reserve are kept
+	var coins sdk.Coins
+	for _, coin := range reserve.GetCoins() {
+		if coin.Denom != params.EvmDenom {
+			coins = append(coins, coin)
+		}
+	}
+	coins = coins.Add(sdk.NewCoin(params.EvmDenom, params.FromEVMAmountCeil(totalDust)))
+
+	if err := reserve.SetCoins(coins); err != nil {
+		panic(err)
+	}
+
+	csdb.accountKeeper.SetAccount(ctx, reserve)
 }
 
 // ----------------------------------------------------------------------------
@@ -700,28 +953,6 @@ func (csdb *CommitStateDB) Reset(_ ethcmn.Hash) error {
 	return nil
 }
 
-// UpdateAccounts updates the nonce and coin balances of accounts
-func (csdb *CommitStateDB) UpdateAccounts() {
-	for _, stateEntry := range csdb.stateObjects {
-		currAcc := csdb.accountKeeper.GetAccount(csdb.ctx, sdk.AccAddress(stateEntry.address.Bytes()))
-		ethermintAcc, ok := currAcc.(*ethermint.EthAccount)
-		if !ok {
-			continue
-		}
-
-		evmDenom := csdb.GetParams().EvmDenom
-		balance := sdk.Coin{
-			Denom:  evmDenom,
-			Amount: ethermintAcc.GetCoins().AmountOf(evmDenom),
-		}
-
-		if stateEntry.stateObject.Balance() != balance.Amount.BigInt() && balance.IsValid() ||
-			stateEntry.stateObject.Nonce() != ethermintAcc.GetSequence() {
-			stateEntry.stateObject.account = ethermintAcc
-		}
-	}
-}
-
 // ClearStateObjects clears cache of state objects to handle account changes outside of the EVM
 func (csdb *CommitStateDB) ClearStateObjects() {
 	csdb.stateObjects = []stateEntry{}
@@ -733,6 +964,39 @@ func (csdb *CommitStateDB) clearJournalAndRefund() {
 	csdb.journal = newJournal()
 	csdb.validRevisions = csdb.validRevisions[:0]
 	csdb.refund = 0
+	csdb.precompileWrites = nil
+}
+
+// precompileCtx returns the context where the next precompile call must be
+// branched from, which is the context of the latest call that hasn't been reverted.
+func (csdb *CommitStateDB) precompileCtx() sdk.Context {
+	if len(csdb.precompileWrites) == 0 {
+		return csdb.ctx
+	}
+
+	return csdb.precompileWrites[len(csdb.precompileWrites)-1].ctx
+}
+
+// addPrecompileWrite appends the branched context of a successful precompile call
+// to the pending writes.
+func (csdb *CommitStateDB) addPrecompileWrite(ctx sdk.Context, write func()) {
+	csdb.journal.append(precompileWriteChange{prevLen: len(csdb.precompileWrites)})
+	csdb.precompileWrites = append(csdb.precompileWrites, precompileWrite{ctx: ctx, write: write})
+}
+
+// commitPrecompileWrites writes the pending precompile contexts to the state
+// db context and emits their events. Each context is branched from the previous
+// one, so they are written from the latest to the first.
+func (csdb *CommitStateDB) commitPrecompileWrites() {
+	for i := len(csdb.precompileWrites) - 1; i >= 0; i-- {
+		csdb.precompileWrites[i].write()
+	}
+
+	for _, pw := range csdb.precompileWrites {
+		csdb.ctx.EventManager().EmitEvents(pw.ctx.EventManager().Events())
+	}
+
+	csdb.precompileWrites = nil
 }
 
 // Prepare sets the current transaction hash and index and block hash which is
@@ -755,8 +1019,7 @@ func (csdb *CommitStateDB) Prepare(thash ethcmn.Hash, txi int) {
 func (csdb *CommitStateDB) CreateAccount(addr ethcmn.Address) {
 	newobj, prevobj := csdb.createObject(addr)
 	if prevobj != nil {
-		evmDenom := csdb.GetParams().EvmDenom
-		newobj.setBalance(evmDenom, sdk.NewIntFromBigInt(prevobj.Balance()))
+		newobj.setEVMBalance(prevobj.Balance())
 	}
 }
 
@@ -796,6 +1059,11 @@ func CopyCommitStateDB(from, to *CommitStateDB) {
 	to.validRevisions = validRevisions
 	to.nextRevisionID = from.nextRevisionID
 	to.accessList = from.accessList.Copy()
+	to.precompiles = from.precompiles
+	to.blockedAddrs = from.blockedAddrs
+	to.stateCache = from.stateCache
+	to.precompileWrites = make([]precompileWrite, len(from.precompileWrites))
+	copy(to.precompileWrites, from.precompileWrites)
 
 	// copy the dirty states, logs, and preimages
 	for _, dirty := range from.journal.dirties {
@@ -818,7 +1086,11 @@ func CopyCommitStateDB(from, to *CommitStateDB) {
 	// copied, the loop above will be a no-op, since the copy's journal is empty.
 	// Thus, here we iterate over stateObjects, to enable copies of copies.
 	for addr := range from.stateObjectsDirty {
-		if idx, exist := to.addressToObjectIndex[addr]; !exist {
+		if _, exist := to.addressToObjectIndex[addr]; exist {
+			continue
+		}
+
+		if idx, exist := from.addressToObjectIndex[addr]; exist {
 			to.setStateObject(from.stateObjects[idx].stateObject.deepCopy(to))
 			to.stateObjectsDirty[addr] = struct{}{}
 		}
@@ -846,7 +1118,12 @@ func (csdb *CommitStateDB) ForEachStorage(addr ethcmn.Address, cb func(key, valu
 
 	for ; iterator.Valid(); iterator.Next() {
 		key := ethcmn.BytesToHash(iterator.Key())
-		value := ethcmn.BytesToHash(iterator.Value())
+
+		// skip the slots written before the account was destructed
+		epoch, value := DecodeStorageValue(iterator.Value())
+		if epoch != so.epoch {
+			continue
+		}
 
 		if idx, dirty := so.keyToDirtyStorageIndex[key]; dirty {
 			// check if iteration stops
@@ -897,6 +1174,23 @@ func (csdb *CommitStateDB) createObject(addr ethcmn.Address) (newObj, prevObj *s
 	return newObj, prevObj
 }
 
+// liveStateObject returns the cached state object of the given address without
+// fetching it from the account mapper. It returns nil if the object is not loaded
+// or if it has been deleted.
+func (csdb *CommitStateDB) liveStateObject(addr ethcmn.Address) *stateObject {
+	idx, found := csdb.addressToObjectIndex[addr]
+	if !found {
+		return nil
+	}
+
+	so := csdb.stateObjects[idx].stateObject
+	if so == nil || so.deleted {
+		return nil
+	}
+
+	return so
+}
+
 // setError remembers the first non-nil error it is called with.
 func (csdb *CommitStateDB) setError(err error) {
 	if csdb.dbErr == nil {
@@ -925,6 +1219,14 @@ func (csdb *CommitStateDB) getStateObject(addr ethcmn.Address) (stateObject *sta
 		return nil
 	}
 
+	// the blocked module accounts aren't EthAccounts, so the EVM executions that
+	// access them are aborted instead of failing to load the state object
+	if _, ok := acc.(*ethermint.EthAccount); !ok && csdb.IsBlockedAddr(addr) {
+		panic(restrictionViolation{
+			err: sdkerrors.Wrapf(ErrBlockedAddress, "module account %s", addr.String()),
+		})
+	}
+
 	// insert the state object into the live set
 	so := newStateObject(csdb, acc)
 	csdb.setStateObject(so)
@@ -41,7 +41,7 @@ func (suite *StateDBTestSuite) SetupTest() {
 
 	suite.app = app.Setup(checkTx)
 	suite.ctx = suite.app.BaseApp.NewContext(checkTx, abci.Header{Height: 1, ChainID: "ethermint-1"})
-	suite.stateDB = suite.app.EvmKeeper.CommitStateDB.WithContext(suite.ctx)
+	suite.stateDB = suite.app.EvmKeeper.NewStateDB(suite.ctx)
 
 	privkey, err := ethsecp256k1.GenerateKey()
 	suite.Require().NoError(err)
@@ -167,6 +167,38 @@ func (suite *StateDBTestSuite) TestStateDB_Balance() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestStateDB_BalanceDust() {
+	params := suite.stateDB.GetParams()
+	params.EvmDenomDecimals = 6
+	suite.stateDB.SetParams(params)
+
+	// 2 photon and 3 dust
+	suite.stateDB.SetBalance(suite.address, big.NewInt(2000000000003))
+	suite.Require().Equal(big.NewInt(2000000000003), suite.stateDB.GetBalance(suite.address))
+	suite.Require().Equal(sdk.NewInt(2), suite.stateDB.GetCoinBalance(suite.address, params.EvmDenom))
+
+	snapshot := suite.stateDB.Snapshot()
+
+	suite.stateDB.SubBalance(suite.address, big.NewInt(1000000000005))
+	suite.Require().Equal(big.NewInt(999999999998), suite.stateDB.GetBalance(suite.address))
+	suite.Require().True(suite.stateDB.GetCoinBalance(suite.address, params.EvmDenom).IsZero())
+
+	// setting the coin balance keeps the dust
+	suite.stateDB.SetCoinBalance(suite.address, params.EvmDenom, sdk.NewInt(1))
+	suite.Require().Equal(big.NewInt(1999999999998), suite.stateDB.GetBalance(suite.address))
+
+	suite.stateDB.RevertToSnapshot(snapshot)
+	suite.Require().Equal(big.NewInt(2000000000003), suite.stateDB.GetBalance(suite.address))
+
+	// the dust is persisted with the account
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+	suite.stateDB.ClearStateObjects()
+	suite.Require().Equal(big.NewInt(2000000000003), suite.stateDB.GetBalance(suite.address))
+
+	acc := suite.app.AccountKeeper.GetAccount(suite.ctx, suite.address.Bytes())
+	suite.Require().Equal(sdk.NewInt(2), acc.GetCoins().AmountOf(params.EvmDenom))
+}
+
 func (suite *StateDBTestSuite) TestStateDBNonce() {
 	nonce := uint64(123)
 	suite.stateDB.SetNonce(suite.address, nonce)
@@ -463,6 +495,25 @@ func (suite *StateDBTestSuite) TestSuiteDB_CopyState() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestSuiteDB_CopyFinalised() {
+	code := []byte("code")
+	addr2 := ethcmn.BytesToAddress([]byte("address2"))
+
+	suite.stateDB.SetNonce(suite.address, 1)
+	suite.stateDB.SetCode(addr2, code)
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+
+	// the finalised state objects aren't part of the journal anymore, but their
+	// uncommitted code must still be copied
+	copyDB := suite.stateDB.Copy()
+	suite.Require().Equal(code, copyDB.GetCode(addr2))
+	suite.Require().Equal(uint64(1), copyDB.GetNonce(suite.address))
+
+	copyOfCopyDB := copyDB.Copy()
+	suite.Require().Equal(code, copyOfCopyDB.GetCode(addr2))
+	suite.Require().Equal(uint64(1), copyOfCopyDB.GetNonce(suite.address))
+}
+
 func (suite *StateDBTestSuite) TestSuiteDB_Empty() {
 	suite.Require().True(suite.stateDB.Empty(suite.address))
 
@@ -520,6 +571,94 @@ func (suite *StateDBTestSuite) TestSuiteDB_Suicide() {
 	}
 }
 
+func (suite *StateDBTestSuite) TestSuicide_DeletesStorage() {
+	code := []byte("code")
+	key := ethcmn.BytesToHash([]byte("key"))
+	value := ethcmn.BytesToHash([]byte("value"))
+
+	suite.stateDB.SetCode(suite.address, code)
+	suite.stateDB.SetState(suite.address, key, value)
+	suite.stateDB.SetState(suite.address, ethcmn.BytesToHash([]byte("key2")), value)
+	_, err := suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	// the storage of another account isn't affected
+	other := ethcmn.BytesToAddress([]byte("other"))
+	suite.stateDB.SetNonce(other, 1)
+	suite.stateDB.SetState(other, key, value)
+	suite.Require().NoError(suite.stateDB.Finalise(false))
+
+	suite.Require().True(suite.stateDB.Suicide(suite.address))
+	_, err = suite.stateDB.Commit(true)
+	suite.Require().NoError(err)
+
+	// the account is re-created at the same address with an empty storage
+	csdb := suite.app.EvmKeeper.NewStateDB(suite.ctx)
+	suite.Require().False(csdb.Exist(suite.address))
+	suite.Require().Equal(ethcmn.Hash{}, csdb.GetState(suite.address, key))
+
+	var slots int
+	err = csdb.ForEachStorage(other, func(_, _ ethcmn.Hash) bool {
+		slots++
+		return false
+	})
+	suite.Require().NoError(err)
+	suite.Require().Equal(1, slots)
+	suite.Require().Equal(value, csdb.GetState(other, key))
+
+	csdb.CreateAccount(suite.address)
+	csdb.SetCode(suite.address, code)
+	suite.Require().Equal(ethcmn.Hash{}, csdb.GetState(suite.address, key))
+	suite.Require().Equal(code, csdb.GetCode(suite.address))
+
+	// the deletion of an empty account without storage, e.g a touched precompile,
+	// doesn't queue it nor change its storage epoch
+	store := suite.ctx.KVStore(suite.app.GetKey(types.StoreKey))
+	suite.Require().True(store.Has(append(types.KeyPrefixStorageQueue, suite.address.Bytes()...)))
+
+	touched := ethcmn.BytesToAddress([]byte{1})
+	csdb.AddBalance(touched, big.NewInt(0))
+	_, err = csdb.Commit(true)
+	suite.Require().NoError(err)
+	suite.Require().False(store.Has(append(types.KeyPrefixStorageQueue, touched.Bytes()...)))
+	suite.Require().Zero(csdb.GetStorageEpoch(touched))
+}
+
+func (suite *StateDBTestSuite) TestStateCache() {
+	code := []byte("code")
+	key := ethcmn.BytesToHash([]byte("key"))
+	value := ethcmn.BytesToHash([]byte("value"))
+
+	suite.stateDB.SetCode(suite.address, code)
+	suite.stateDB.SetState(suite.address, key, value)
+	_, err := suite.stateDB.Commit(false)
+	suite.Require().NoError(err)
+
+	// the slot written in the current block is cached from the next one
+	stateCache := suite.app.EvmKeeper.StateCache()
+	stateCache.NewBlock()
+
+	readState := func() (ethcmn.Hash, uint64) {
+		ctx := suite.ctx.WithGasMeter(sdk.NewInfiniteGasMeter())
+		csdb := suite.app.EvmKeeper.NewStateDB(ctx)
+		suite.Require().Equal(code, csdb.GetCode(suite.address))
+		return csdb.GetState(suite.address, key), ctx.GasMeter().GasConsumed()
+	}
+
+	// the cached reads consume the same gas as the store ones
+	storeValue, storeGas := readState()
+	cachedValue, cachedGas := readState()
+	suite.Require().Equal(value, storeValue)
+	suite.Require().Equal(value, cachedValue)
+	suite.Require().Equal(storeGas, cachedGas)
+
+	// a written slot is read from the store
+	newValue := ethcmn.BytesToHash([]byte("new value"))
+	suite.app.EvmKeeper.SetState(suite.ctx, suite.address, key, newValue)
+	storeValue, _ = readState()
+	suite.Require().Equal(newValue, storeValue)
+}
+
 func (suite *StateDBTestSuite) TestCommitStateDB_Commit() {
 	testCase := []struct {
 		name       string
@@ -22,9 +22,16 @@ func TestStorageValidate(t *testing.T) {
 			true,
 		},
 		{
-			"empty storage key bytes",
+			"zero storage key",
 			Storage{
-				{Key: ethcmn.Hash{}.String()},
+				NewState(ethcmn.Hash{}, ethcmn.BytesToHash([]byte{1, 2, 3})),
+			},
+			true,
+		},
+		{
+			"empty storage key",
+			Storage{
+				{Key: ""},
 			},
 			false,
 		},
@@ -0,0 +1,116 @@
+package types
+
+import (
+	"fmt"
+
+	ethermint "github.com/cosmos/ethermint/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// Hooks of the block on which the system contracts are called
+const (
+	SystemContractHookBeginBlock = "begin_block"
+	SystemContractHookEndBlock   = "end_block"
+)
+
+// MaxSystemContractGasLimit is the maximum gas limit of a system contract call
+const MaxSystemContractGasLimit = ModuleGasLimit
+
+// SystemContractCaller is the sender of the system contract calls. The contracts
+// can check that the caller is the EVM module to restrict the access to the called
+// method, as no private key controls this address.
+var SystemContractCaller = ModuleAddress
+
+// SystemContract defines a contract registered by governance that is called by the
+// EVM module on BeginBlock or EndBlock, either on every block or every Interval
+// blocks.
+type SystemContract struct {
+	// Address is the hex address of the called contract
+	Address string `json:"address" yaml:"address"`
+	// Hook is the block hook on which the contract is called, either begin_block
+	// or end_block
+	Hook string `json:"hook" yaml:"hook"`
+	// Interval is the number of blocks between calls. The contract is called on
+	// the heights that are a multiple of the interval.
+	Interval uint64 `json:"interval" yaml:"interval"`
+	// Input is the 0x prefixed hex encoded call data
+	Input string `json:"input,omitempty" yaml:"input,omitempty"`
+	// GasLimit is the gas budget of each call
+	GasLimit uint64 `json:"gas_limit" yaml:"gas_limit"`
+}
+
+// NewSystemContract creates a new SystemContract instance.
+func NewSystemContract(address ethcmn.Address, hook string, interval uint64, input []byte, gasLimit uint64) SystemContract {
+	sc := SystemContract{
+		Address:  address.String(),
+		Hook:     hook,
+		Interval: interval,
+		GasLimit: gasLimit,
+	}
+
+	if len(input) != 0 {
+		sc.Input = hexutil.Encode(input)
+	}
+
+	return sc
+}
+
+// Validate performs a stateless validation of the system contract fields.
+func (sc SystemContract) Validate() error {
+	if !ethcmn.IsHexAddress(sc.Address) || ethermint.IsZeroAddress(sc.Address) {
+		return fmt.Errorf("invalid system contract address %q", sc.Address)
+	}
+
+	switch sc.Hook {
+	case SystemContractHookBeginBlock, SystemContractHookEndBlock:
+	default:
+		return fmt.Errorf("invalid system contract hook %q, expected %s or %s", sc.Hook, SystemContractHookBeginBlock, SystemContractHookEndBlock)
+	}
+
+	if sc.Interval == 0 {
+		return fmt.Errorf("system contract %s interval cannot be zero", sc.Address)
+	}
+
+	if sc.GasLimit == 0 || sc.GasLimit > MaxSystemContractGasLimit {
+		return fmt.Errorf("system contract %s gas limit %d must be between 1 and %d", sc.Address, sc.GasLimit, MaxSystemContractGasLimit)
+	}
+
+	if _, err := sc.GetInput(); err != nil {
+		return fmt.Errorf("invalid system contract %s input: %w", sc.Address, err)
+	}
+
+	return nil
+}
+
+// GetAddress returns the address of the system contract.
+func (sc SystemContract) GetAddress() ethcmn.Address {
+	return ethcmn.HexToAddress(sc.Address)
+}
+
+// GetInput returns the decoded call data of the system contract. An empty input
+// is decoded to nil.
+func (sc SystemContract) GetInput() ([]byte, error) {
+	if sc.Input == "" {
+		return nil, nil
+	}
+
+	return hexutil.Decode(sc.Input)
+}
+
+// IsDue returns true if the system contract must be called on the given hook and
+// block height.
+func (sc SystemContract) IsDue(hook string, height int64) bool {
+	return sc.Hook == hook && height > 0 && uint64(height)%sc.Interval == 0
+}
+
+// String implements the Stringer interface.
+func (sc SystemContract) String() string {
+	return fmt.Sprintf(`Address:   %s
+Hook:      %s
+Interval:  %d
+Input:     %s
+Gas Limit: %d
+`, sc.Address, sc.Hook, sc.Interval, sc.Input, sc.GasLimit)
+}
@@ -0,0 +1,344 @@
+package types
+
+import (
+	"bytes"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"sort"
+	"sync"
+
+	dbm "github.com/tendermint/tm-db"
+
+	sdk "github.com/cosmos/cosmos-sdk/types"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+// ErrTracingDisabled is returned by the TraceStore queries when the node doesn't
+// record the call traces.
+var ErrTracingDisabled = errors.New("call tracing is disabled on this node")
+
+// TraceStore database key prefixes
+var (
+	KeyPrefixTrace       = []byte{0x01}
+	KeyPrefixTraceTxHash = []byte{0x02}
+	KeyPrefixTraceFrom   = []byte{0x03}
+	KeyPrefixTraceTo     = []byte{0x04}
+)
+
+// TxTrace defines the call frames recorded for the EVM execution of a transaction.
+// The index is the position of the execution among the EVM executions of the
+// block.
+type TxTrace struct {
+	TxHash ethcmn.Hash `json:"tx_hash"`
+	Height int64       `json:"height"`
+	Index  uint32      `json:"index"`
+	Frames []CallFrame `json:"frames"`
+}
+
+// TraceFilter defines the criteria of the TraceStore FilterTraces query. A frame
+// matches if its sender is one of the from addresses and its recipient one of the
+// to addresses, where an empty list matches any address. The matching frames
+// are paginated by skipping the first After ones and returning at most Count of
+// them if Count isn't zero.
+type TraceFilter struct {
+	FromBlock     int64            `json:"from_block"`
+	ToBlock       int64            `json:"to_block"`
+	FromAddresses []ethcmn.Address `json:"from_addresses"`
+	ToAddresses   []ethcmn.Address `json:"to_addresses"`
+	After         uint64           `json:"after"`
+	Count         uint64           `json:"count"`
+}
+
+// FilteredFrame is a call frame returned by the TraceStore FilterTraces query,
+// with the transaction it belongs to.
+type FilteredFrame struct {
+	CallFrame
+	TxHash ethcmn.Hash `json:"tx_hash"`
+	Height int64       `json:"height"`
+	Index  uint32      `json:"index"`
+}
+
+// TraceStore is a node-local store of the call traces of the executed EVM
+// transactions, which backs the trace_ JSON-RPC namespace. Unlike the module
+// store, it isn't part of the consensus state: the traces are only recorded by
+// the nodes that enable tracing, from the height they're enabled at.
+//
+// The store is disabled until a database is set, and it's shared by all the
+// copies of the Keeper, so it can be configured once the app is created. A
+// transaction executed again, e.g when a block is replayed, overwrites its trace.
+type TraceStore struct {
+	mtx sync.RWMutex
+	db  dbm.DB
+}
+
+// NewTraceStore creates a new disabled TraceStore.
+func NewTraceStore() *TraceStore {
+	return &TraceStore{}
+}
+
+// SetDB enables the TraceStore with the given database.
+func (ts *TraceStore) SetDB(db dbm.DB) {
+	ts.mtx.Lock()
+	defer ts.mtx.Unlock()
+
+	ts.db = db
+}
+
+// Enabled returns true if the call traces are recorded.
+func (ts *TraceStore) Enabled() bool {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	return ts.db != nil
+}
+
+// SetTxTrace stores the trace of a transaction, and indexes it by its tx hash and
+// by the sender and recipient addresses of its call frames.
+func (ts *TraceStore) SetTxTrace(trace TxTrace) error {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	if ts.db == nil {
+		return ErrTracingDisabled
+	}
+
+	bz, err := json.Marshal(trace)
+	if err != nil {
+		return err
+	}
+
+	position := tracePosition(trace.Height, trace.Index)
+
+	batch := ts.db.NewBatch()
+	defer batch.Close()
+
+	batch.Set(TraceKey(trace.Height, trace.Index), bz)
+	batch.Set(TraceTxHashKey(trace.TxHash), position)
+
+	for _, frame := range trace.Frames {
+		batch.Set(TraceAddressKey(KeyPrefixTraceFrom, frame.From, trace.Height, trace.Index), []byte{0x01})
+		batch.Set(TraceAddressKey(KeyPrefixTraceTo, frame.To, trace.Height, trace.Index), []byte{0x01})
+	}
+
+	return batch.Write()
+}
+
+// GetTxTrace returns the trace of the transaction with the given hash. The trace
+// is nil if the transaction hasn't been traced.
+func (ts *TraceStore) GetTxTrace(txHash ethcmn.Hash) (*TxTrace, error) {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	if ts.db == nil {
+		return nil, ErrTracingDisabled
+	}
+
+	position, err := ts.db.Get(TraceTxHashKey(txHash))
+	if err != nil || len(position) == 0 {
+		return nil, err
+	}
+
+	return ts.getTrace(append(append([]byte{}, KeyPrefixTrace...), position...))
+}
+
+// GetBlockTraces returns the traces of the transactions executed at the given
+// height, in execution order.
+func (ts *TraceStore) GetBlockTraces(height int64) ([]TxTrace, error) {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	if ts.db == nil {
+		return nil, ErrTracingDisabled
+	}
+
+	iterator, err := ts.db.Iterator(TraceKey(height, 0), TraceKey(height+1, 0))
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	traces := []TxTrace{}
+	for ; iterator.Valid(); iterator.Next() {
+		var trace TxTrace
+		if err := json.Unmarshal(iterator.Value(), &trace); err != nil {
+			return nil, err
+		}
+
+		traces = append(traces, trace)
+	}
+
+	return traces, nil
+}
+
+// FilterTraces returns the call frames of the given block range that match the
+// filter addresses, ordered by their position on the chain. It uses the address
+// indexes to only read the traces that contain a matching frame.
+func (ts *TraceStore) FilterTraces(filter TraceFilter) ([]FilteredFrame, error) {
+	ts.mtx.RLock()
+	defer ts.mtx.RUnlock()
+
+	if ts.db == nil {
+		return nil, ErrTracingDisabled
+	}
+
+	// the index of the from addresses is used if any, as the traces have fewer
+	// distinct senders than recipients
+	prefix, addresses := KeyPrefixTraceFrom, filter.FromAddresses
+	if len(addresses) == 0 {
+		prefix, addresses = KeyPrefixTraceTo, filter.ToAddresses
+	}
+
+	var keys [][]byte
+	if len(addresses) == 0 {
+		var err error
+		if keys, err = ts.traceKeys(TraceKey(filter.FromBlock, 0), TraceKey(filter.ToBlock+1, 0), false); err != nil {
+			return nil, err
+		}
+	} else {
+		seen := make(map[string]bool)
+		for _, address := range addresses {
+			start := TraceAddressKey(prefix, address, filter.FromBlock, 0)
+			end := TraceAddressKey(prefix, address, filter.ToBlock+1, 0)
+
+			addressKeys, err := ts.traceKeys(start, end, true)
+			if err != nil {
+				return nil, err
+			}
+
+			// skip the traces of the duplicated addresses and of the traces
+			// matched by several addresses
+			for _, key := range addressKeys {
+				if !seen[string(key)] {
+					seen[string(key)] = true
+					keys = append(keys, key)
+				}
+			}
+		}
+
+		// the trace keys are ordered by height and index
+		sort.Slice(keys, func(i, j int) bool {
+			return bytes.Compare(keys[i], keys[j]) < 0
+		})
+	}
+
+	frames := []FilteredFrame{}
+	var skipped uint64
+
+	for _, key := range keys {
+		trace, err := ts.getTrace(key)
+		if err != nil {
+			return nil, err
+		}
+
+		if trace == nil {
+			continue
+		}
+
+		for _, frame := range trace.Frames {
+			if !matchesAddress(filter.FromAddresses, frame.From) || !matchesAddress(filter.ToAddresses, frame.To) {
+				continue
+			}
+
+			if skipped < filter.After {
+				skipped++
+				continue
+			}
+
+			frames = append(frames, FilteredFrame{
+				CallFrame: frame,
+				TxHash:    trace.TxHash,
+				Height:    trace.Height,
+				Index:     trace.Index,
+			})
+
+			if filter.Count != 0 && uint64(len(frames)) == filter.Count {
+				return frames, nil
+			}
+		}
+	}
+
+	return frames, nil
+}
+
+// traceKeys returns the trace keys of the given range. If the range belongs to an
+// address index, the trace key is built from the suffix of the index key.
+func (ts *TraceStore) traceKeys(start, end []byte, isIndex bool) ([][]byte, error) {
+	iterator, err := ts.db.Iterator(start, end)
+	if err != nil {
+		return nil, err
+	}
+	defer iterator.Close()
+
+	var keys [][]byte
+	for ; iterator.Valid(); iterator.Next() {
+		key := iterator.Key()
+		if isIndex {
+			key = append(append([]byte{}, KeyPrefixTrace...), key[len(key)-12:]...)
+		}
+
+		keys = append(keys, key)
+	}
+
+	return keys, nil
+}
+
+func (ts *TraceStore) getTrace(key []byte) (*TxTrace, error) {
+	bz, err := ts.db.Get(key)
+	if err != nil || len(bz) == 0 {
+		return nil, err
+	}
+
+	var trace TxTrace
+	if err := json.Unmarshal(bz, &trace); err != nil {
+		return nil, err
+	}
+
+	return &trace, nil
+}
+
+// TraceKey returns the key of the trace of the transaction executed at the given
+// height and index. The key is composed of the prefix, the big endian height and
+// the big endian index, which allows to iterate the traces of a block range in
+// order.
+func TraceKey(height int64, index uint32) []byte {
+	return append(append([]byte{}, KeyPrefixTrace...), tracePosition(height, index)...)
+}
+
+// TraceTxHashKey returns the key of the position of the trace of the given tx
+// hash.
+func TraceTxHashKey(txHash ethcmn.Hash) []byte {
+	return append(append([]byte{}, KeyPrefixTraceTxHash...), txHash.Bytes()...)
+}
+
+// TraceAddressKey returns the key that indexes a trace by the sender or the
+// recipient of one of its frames, depending on the prefix.
+func TraceAddressKey(prefix []byte, address ethcmn.Address, height int64, index uint32) []byte {
+	key := append(append([]byte{}, prefix...), address.Bytes()...)
+	return append(key, tracePosition(height, index)...)
+}
+
+// tracePosition returns the position of a trace on the chain, which is the suffix
+// of all the trace keys.
+func tracePosition(height int64, index uint32) []byte {
+	bz := make([]byte, 12)
+	copy(bz, sdk.Uint64ToBigEndian(uint64(height)))
+	binary.BigEndian.PutUint32(bz[8:], index)
+	return bz
+}
+
+// matchesAddress returns true if the list is empty or contains the address.
+func matchesAddress(addresses []ethcmn.Address, address ethcmn.Address) bool {
+	if len(addresses) == 0 {
+		return true
+	}
+
+	for _, addr := range addresses {
+		if addr == address {
+			return true
+		}
+	}
+
+	return false
+}
@@ -0,0 +1,100 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/tendermint/tm-db"
+
+	ethcmn "github.com/ethereum/go-ethereum/common"
+)
+
+func TestTraceStore(t *testing.T) {
+	store := NewTraceStore()
+	require.False(t, store.Enabled())
+
+	_, err := store.GetTxTrace(ethcmn.Hash{0x1})
+	require.Equal(t, ErrTracingDisabled, err)
+
+	store.SetDB(dbm.NewMemDB())
+	require.True(t, store.Enabled())
+
+	alice, bob, carol := ethcmn.Address{0xa}, ethcmn.Address{0xb}, ethcmn.Address{0xc}
+
+	traces := []TxTrace{
+		{
+			TxHash: ethcmn.Hash{0x1}, Height: 1, Index: 0,
+			Frames: []CallFrame{{Type: CallTypeCall, From: alice, To: bob, TraceAddress: []int{}}},
+		},
+		{
+			TxHash: ethcmn.Hash{0x2}, Height: 2, Index: 0,
+			Frames: []CallFrame{
+				{Type: CallTypeCall, From: alice, To: carol, TraceAddress: []int{}, Subtraces: 1},
+				{Type: CallTypeStaticCall, From: carol, To: bob, TraceAddress: []int{0}},
+			},
+		},
+		{
+			TxHash: ethcmn.Hash{0x3}, Height: 2, Index: 1,
+			Frames: []CallFrame{{Type: CallTypeCall, From: bob, To: carol, TraceAddress: []int{}}},
+		},
+	}
+
+	for _, trace := range traces {
+		require.NoError(t, store.SetTxTrace(trace))
+	}
+
+	trace, err := store.GetTxTrace(ethcmn.Hash{0x2})
+	require.NoError(t, err)
+	require.Equal(t, traces[1], *trace)
+
+	trace, err = store.GetTxTrace(ethcmn.Hash{0x4})
+	require.NoError(t, err)
+	require.Nil(t, trace)
+
+	blockTraces, err := store.GetBlockTraces(2)
+	require.NoError(t, err)
+	require.Equal(t, traces[1:], blockTraces)
+
+	blockTraces, err = store.GetBlockTraces(3)
+	require.NoError(t, err)
+	require.Empty(t, blockTraces)
+
+	testCases := []struct {
+		name     string
+		filter   TraceFilter
+		expected []ethcmn.Address // senders of the matching frames
+	}{
+		{"all", TraceFilter{FromBlock: 1, ToBlock: 2}, []ethcmn.Address{alice, alice, carol, bob}},
+		{"block range", TraceFilter{FromBlock: 2, ToBlock: 2}, []ethcmn.Address{alice, carol, bob}},
+		{"from", TraceFilter{FromBlock: 1, ToBlock: 2, FromAddresses: []ethcmn.Address{alice}}, []ethcmn.Address{alice, alice}},
+		{"to", TraceFilter{FromBlock: 1, ToBlock: 2, ToAddresses: []ethcmn.Address{bob}}, []ethcmn.Address{alice, carol}},
+		{
+			"from and to",
+			TraceFilter{FromBlock: 1, ToBlock: 2, FromAddresses: []ethcmn.Address{alice, bob}, ToAddresses: []ethcmn.Address{carol}},
+			[]ethcmn.Address{alice, bob},
+		},
+		{"duplicated address", TraceFilter{FromBlock: 1, ToBlock: 2, ToAddresses: []ethcmn.Address{carol, carol}}, []ethcmn.Address{alice, bob}},
+		{"after and count", TraceFilter{FromBlock: 1, ToBlock: 2, After: 1, Count: 2}, []ethcmn.Address{alice, carol}},
+		{"no match", TraceFilter{FromBlock: 3, ToBlock: 10}, []ethcmn.Address{}},
+	}
+
+	for _, tc := range testCases {
+		frames, err := store.FilterTraces(tc.filter)
+		require.NoError(t, err, tc.name)
+
+		senders := []ethcmn.Address{}
+		for _, frame := range frames {
+			senders = append(senders, frame.From)
+		}
+		require.Equal(t, tc.expected, senders, tc.name)
+	}
+
+	// the filtered frames reference their transaction
+	frames, err := store.FilterTraces(TraceFilter{FromBlock: 1, ToBlock: 2, FromAddresses: []ethcmn.Address{carol}})
+	require.NoError(t, err)
+	require.Len(t, frames, 1)
+	require.Equal(t, ethcmn.Hash{0x2}, frames[0].TxHash)
+	require.Equal(t, int64(2), frames[0].Height)
+	require.Equal(t, []int{0}, frames[0].TraceAddress)
+}
@@ -0,0 +1,120 @@
+package types
+
+import (
+	"math/big"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	ethcmn "github.com/ethereum/go-ethereum/common"
+	ethcrypto "github.com/ethereum/go-ethereum/crypto"
+)
+
+// Storage layout of WETH9. The name and symbol are Solidity strings and the
+// decimals a uint8, followed by the balances and allowances mappings.
+const (
+	wrappedTokenSlotName       = 0
+	wrappedTokenSlotSymbol     = 1
+	wrappedTokenSlotDecimals   = 2
+	wrappedTokenSlotBalances   = 3
+	wrappedTokenSlotAllowances = 4
+)
+
+// Default name and symbol of the preinstalled wrapped native token
+const (
+	DefaultWrappedTokenName   = "Wrapped Photon"
+	DefaultWrappedTokenSymbol = "WPHOTON"
+)
+
+// WrappedTokenABIJSON defines the ABI of the wrapped native token, which is the
+// one of WETH9.
+const WrappedTokenABIJSON = `[
+	{"type":"function","name":"name","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"symbol","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"string"}]},
+	{"type":"function","name":"decimals","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint8"}]},
+	{"type":"function","name":"totalSupply","stateMutability":"view","inputs":[],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"balanceOf","stateMutability":"view","inputs":[{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"allowance","stateMutability":"view","inputs":[{"name":"","type":"address"},{"name":"","type":"address"}],"outputs":[{"name":"","type":"uint256"}]},
+	{"type":"function","name":"deposit","stateMutability":"payable","inputs":[],"outputs":[]},
+	{"type":"function","name":"withdraw","stateMutability":"nonpayable","inputs":[{"name":"wad","type":"uint256"}],"outputs":[]},
+	{"type":"function","name":"transfer","stateMutability":"nonpayable","inputs":[{"name":"dst","type":"address"},{"name":"wad","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"approve","stateMutability":"nonpayable","inputs":[{"name":"guy","type":"address"},{"name":"wad","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"function","name":"transferFrom","stateMutability":"nonpayable","inputs":[{"name":"src","type":"address"},{"name":"dst","type":"address"},{"name":"wad","type":"uint256"}],"outputs":[{"name":"","type":"bool"}]},
+	{"type":"event","name":"Deposit","anonymous":false,"inputs":[{"name":"dst","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Withdrawal","anonymous":false,"inputs":[{"name":"src","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Transfer","anonymous":false,"inputs":[{"name":"src","type":"address","indexed":true},{"name":"dst","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]},
+	{"type":"event","name":"Approval","anonymous":false,"inputs":[{"name":"src","type":"address","indexed":true},{"name":"guy","type":"address","indexed":true},{"name":"wad","type":"uint256","indexed":false}]}
+]`
+
+// weth9Code is the canonical runtime bytecode of WETH9, deployed on Ethereum at
+// 0xC02aaA39b223FE8D0A0e5C4F27eAD9083C756Cc2 (solc 0.4.19). Its keccak256 hash is
+// 0xd0a06b12ac47863b5c7be4185c2deaad1c61557033f56c7d4ea74429cbb25e23. The name,
+// symbol and decimals are read from the storage, which is set by the preinstall.
+const weth9Code = "0x6060604052600436106100af576000357c0100000000000000000000000000000000000000000000000000000000900463ffffffff16806306fdde03146100b9578063095ea7b31461014757806318160ddd146101a157806323b872dd146101ca5780632e1a7d4d14610243578063313ce5671461026657806370a082311461029557806395d89b41146102e2578063a9059cbb14610370578063d0e30db0146103ca578063dd62ed3e146103d4575b6100b7610440565b005b34156100c457600080fd5b6100cc6104dd565b6040518080602001828103825283818151815260200191508051906020019080838360005b8381101561010c5780820151818401526020810190506100f1565b50505050905090810190601f1680156101395780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b341561015257600080fd5b610187600480803573ffffffffffffffffffffffffffffffffffffffff1690602001909190803590602001909190505061057b565b604051808215151515815260200191505060405180910390f35b34156101ac57600080fd5b6101b461066d565b6040518082815260200191505060405180910390f35b34156101d557600080fd5b610229600480803573ffffffffffffffffffffffffffffffffffffffff1690602001909190803573ffffffffffffffffffffffffffffffffffffffff1690602001909190803590602001909190505061068c565b604051808215151515815260200191505060405180910390f35b341561024e57600080fd5b61026460048080359060200190919050506109d9565b005b341561027157600080fd5b610279610b05565b604051808260ff1660ff16815260200191505060405180910390f35b34156102a057600080fd5b6102cc600480803573ffffffffffffffffffffffffffffffffffffffff16906020019091905050610b18565b6040518082815260200191505060405180910390f35b34156102ed57600080fd5b6102f5610b30565b6040518080602001828103825283818151815260200191508051906020019080838360005b8381101561033557808201518184015260208101905061031a565b50505050905090810190601f1680156103625780820380516001836020036101000a031916815260200191505b509250505060405180910390f35b341561037b57600080fd5b6103b0600480803573ffffffffffffffffffffffffffffffffffffffff16906020019091908035906020019091905050610bce565b604051808215151515815260200191505060405180910390f35b6103d2610440565b005b34156103df57600080fd5b61042a600480803573ffffffffffffffffffffffffffffffffffffffff1690602001909190803573ffffffffffffffffffffffffffffffffffffffff16906020019091905050610be3565b6040518082815260200191505060405180910390f35b34600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825401925050819055503373ffffffffffffffffffffffffffffffffffffffff167fe1fffcc4923d04b559f4d29a8bfc6cda04eb5b0d3c460751c2402c5c5cc9109c346040518082815260200191505060405180910390a2565b60008054600181600116156101000203166002900480601f0160208091040260200160405190810160405280929190818152602001828054600181600116156101000203166002900480156105735780601f1061054857610100808354040283529160200191610573565b820191906000526020600020905b81548152906001019060200180831161055657829003601f168201915b505050505081565b600081600460003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020819055508273ffffffffffffffffffffffffffffffffffffffff163373ffffffffffffffffffffffffffffffffffffffff167f8c5be1e5ebec7d5bd14f71427d1e84f3dd0314c0f7b2291e5b200ac8c7c3b925846040518082815260200191505060405180910390a36001905092915050565b60003073ffffffffffffffffffffffffffffffffffffffff1631905090565b600081600360008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002054101515156106dc57600080fd5b3373ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff16141580156107b457507fffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffffff600460008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205414155b156108cf5781600460008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020541015151561084457600080fd5b81600460008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff16815260200190815260200160002060003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825403925050819055505b81600360008673ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000206000828254039250508190555081600360008573ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825401925050819055508273ffffffffffffffffffffffffffffffffffffffff168473ffffffffffffffffffffffffffffffffffffffff167fddf252ad1be2c89b69c2b068fc378daa952ba7f163c4a11628f55a4df523b3ef846040518082815260200191505060405180910390a3600190509392505050565b80600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff1681526020019081526020016000205410151515610a2757600080fd5b80600360003373ffffffffffffffffffffffffffffffffffffffff1673ffffffffffffffffffffffffffffffffffffffff168152602001908152602001600020600082825403925050819055503373ffffffffffffffffffffffffffffffffffffffff166108fc829081150290604051600060405180830381858888f193505050501515610ab457600080fd5b3373ffffffffffffffffffffffffffffffffffffffff167f7fcf532c15f0a6db0bd6d0e038bea71d30d808c7d98cb3bf7268a95bf5081b65826040518082815260200191505060405180910390a250565b600260009054906101000a900460ff1681565b60036020528060005260406000206000915090505481565b60018054600181600116156101000203166002900480601f016020809104026020016040519081016040528092919081815260200182805460018160011615610100020316600290048015610bc65780601f10610b9b57610100808354040283529160200191610bc6565b820191906000526020600020905b815481529060010190602001808311610ba957829003601f168201915b505050505081565b6000610bdb33848461068c565b905092915050565b60046020528160005260406000206020528060005260406000206000915091505054815600a165627a7a72305820deb4c2ccab3c2fdca32ab3f46728389c2fe2c165d5fafa07661e4e004f6c344a0029"
+
+var (
+	// WrappedTokenABI is the parsed ABI of the wrapped native token
+	WrappedTokenABI abi.ABI
+	// WrappedTokenCode is the runtime bytecode of the wrapped native token
+	WrappedTokenCode []byte
+)
+
+func init() {
+	var err error
+	WrappedTokenABI, err = abi.JSON(strings.NewReader(WrappedTokenABIJSON))
+	if err != nil {
+		panic(err)
+	}
+
+	WrappedTokenCode = ethcmn.FromHex(weth9Code)
+}
+
+// WrappedTokenBalanceSlot returns the storage slot of the account balance on the
+// wrapped native token.
+func WrappedTokenBalanceSlot(account ethcmn.Address) ethcmn.Hash {
+	return ethcrypto.Keccak256Hash(
+		ethcmn.BytesToHash(account.Bytes()).Bytes(),
+		ethcmn.BigToHash(big.NewInt(wrappedTokenSlotBalances)).Bytes(),
+	)
+}
+
+// wrappedTokenStorage returns the storage of the wrapped native token with the
+// given name and symbol, and 18 decimals as the EVM denomination.
+func wrappedTokenStorage(name, symbol string) Storage {
+	storage := stringStorage(wrappedTokenSlotName, name)
+	storage = append(storage, stringStorage(wrappedTokenSlotSymbol, symbol)...)
+	return append(storage, NewState(
+		ethcmn.BigToHash(big.NewInt(wrappedTokenSlotDecimals)),
+		ethcmn.BigToHash(big.NewInt(int64(EVMDecimals))),
+	))
+}
+
+// stringStorage returns the storage of a Solidity string at the given slot. The
+// strings shorter than 32 bytes are stored at the slot, left aligned, with twice
+// their length on the last byte. The longer ones store twice their length plus
+// one at the slot and their content on consecutive words from keccak256(slot).
+func stringStorage(slot int64, value string) Storage {
+	slotKey := ethcmn.BigToHash(big.NewInt(slot))
+	data := []byte(value)
+
+	if len(data) < 32 {
+		var word ethcmn.Hash
+		copy(word[:], data)
+		word[31] = byte(len(data) * 2)
+		return Storage{NewState(slotKey, word)}
+	}
+
+	storage := Storage{
+		NewState(slotKey, ethcmn.BigToHash(big.NewInt(int64(len(data)*2+1)))),
+	}
+
+	start := ethcrypto.Keccak256Hash(slotKey.Bytes()).Big()
+	for i := 0; i*32 < len(data); i++ {
+		var word ethcmn.Hash
+		copy(word[:], data[i*32:])
+
+		key := ethcmn.BigToHash(new(big.Int).Add(start, big.NewInt(int64(i))))
+		storage = append(storage, NewState(key, word))
+	}
+
+	return storage
+}